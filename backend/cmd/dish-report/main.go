@@ -0,0 +1,94 @@
+// Command dish-report scans the dishes collection and prints a data-quality report.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"nourish-backend/internal/config"
+	"nourish-backend/internal/database"
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/repository"
+	"nourish-backend/internal/service"
+	"nourish-backend/pkg/logger"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	failOnError := flag.Bool("fail-on-error", false, "exit with status 1 when any dish has errors")
+	flag.Parse()
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Printf("Warning: Could not load .env file: %v", err)
+	}
+
+	cfg := config.Load()
+	logger := logger.New(cfg.LogLevel, cfg.LogFormat)
+
+	db, err := database.Connect(cfg.MongoURI, cfg.DatabaseConfig, logger)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	validation := service.NewDishValidationService(repository.NewDishRepository(db.GetDB()), logger)
+	report, err := validation.CatalogReport(ctx)
+	if err != nil {
+		log.Fatalf("Failed to build report: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if *failOnError && report.DishesWithErrors > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport writes a human-readable summary followed by every issue
+func printReport(report *models.CatalogReport) {
+	fmt.Printf("Dish catalog report (%s)\n", report.GeneratedAt.Format(time.RFC3339))
+	fmt.Printf("Dishes: %d, with issues: %d, with errors: %d\n\n", report.TotalDishes, report.DishesWithIssues, report.DishesWithErrors)
+
+	checks := make([]string, 0, len(report.Counts))
+	for check := range report.Counts {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+	for _, check := range checks {
+		fmt.Printf("  %-20s %d\n", check, report.Counts[check])
+	}
+
+	if len(report.Duplicates) > 0 {
+		fmt.Println("\nDuplicate names:")
+		for _, duplicate := range report.Duplicates {
+			fmt.Printf("  %s: %v\n", duplicate.Name, duplicate.DishIDs)
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		fmt.Println("\nIssues:")
+		for _, issue := range report.Issues {
+			fmt.Printf("  [%s] %s (%s) %s: %s\n", issue.Severity, issue.DishName, issue.DishID, issue.Field, issue.Message)
+		}
+	}
+}
@@ -49,6 +49,11 @@ func main() {
 	// Initialize services
 	services := service.NewServices(repos, cfg, logger)
 
+	// Start background jobs, stopped on shutdown
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	services.StartBackgroundJobs(jobsCtx, logger)
+
 	// Initialize API router
 	router := api.NewRouter(services, cfg, logger)
 
@@ -78,6 +83,7 @@ func main() {
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopJobs()
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"nourish-backend/internal/api/middleware"
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/service"
+	"nourish-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AdminHandler handles admin-only requests
+type AdminHandler struct {
+	dishValidationService service.DishValidationService
+	dishService           service.DishService
+	validator             *validator.Validate
+	logger                *logger.Logger
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(dishValidationService service.DishValidationService, dishService service.DishService, log *logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		dishValidationService: dishValidationService,
+		dishService:           dishService,
+		validator:             validator.New(),
+		logger:                log,
+	}
+}
+
+// GetDishQualityReport handles GET /api/admin/dishes/quality-report
+func (h *AdminHandler) GetDishQualityReport(c *gin.Context) {
+	report, err := h.dishValidationService.CatalogReport(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// GetDishReviews handles GET /api/admin/dishes/reviews
+func (h *AdminHandler) GetDishReviews(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	dishes, pagination, err := h.dishService.GetPendingReviews(c.Request.Context(), page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"dishes":     dishes,
+		"pagination": pagination,
+	})
+}
+
+// ReviewDish handles POST /api/admin/dishes/:id/review
+func (h *AdminHandler) ReviewDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	var req models.DishReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	dish, err := h.dishService.Review(c.Request.Context(), id, req, user)
+	if err != nil {
+		writeDishError(c, err)
+		return
+	}
+
+	message := "Dish approved and published"
+	if req.Decision == models.ReviewDecisionReject {
+		message = "Dish rejected"
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    dish.ToResponse(),
+	})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"nourish-backend/internal/api/middleware"
 	"nourish-backend/internal/models"
@@ -37,13 +38,36 @@ func (h *AnalyticsHandler) GetMealAnalytics(c *gin.Context) {
 		return
 	}
 
-	// Parse period parameter (default 30 days)
-	period, err := strconv.Atoi(c.DefaultQuery("period", "30"))
-	if err != nil || period < 1 || period > 365 {
-		period = 30
+	now := time.Now().In(userLocation(c))
+	dateRange, err := parseDateRange(c, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if dateRange == nil {
+		// Parse period parameter (default 30 days)
+		period, err := strconv.Atoi(c.DefaultQuery("period", "30"))
+		if err != nil || period < 1 || period > maxRangeDays {
+			period = 30
+		}
+		lastDays := models.LastNDays(period, now)
+		dateRange = &lastDays
+	}
+
+	opts, err := parseSeriesOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
-	analytics, err := h.mealService.GetAnalytics(c.Request.Context(), userID, period)
+	analytics, err := h.mealService.GetAnalytics(c.Request.Context(), userID, *dateRange, opts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"nourish-backend/internal/models"
 	"nourish-backend/pkg/logger"
@@ -74,8 +75,8 @@ func (m *MockUserServiceForAuth) GetByID(ctx context.Context, userID primitive.O
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceForAuth) UpdateProfile(ctx context.Context, userID primitive.ObjectID, profile models.UserProfile) error {
-	args := m.Called(ctx, userID, profile)
+func (m *MockUserServiceForAuth) UpdateProfile(ctx context.Context, userID primitive.ObjectID, patch models.ProfilePatch) error {
+	args := m.Called(ctx, userID, patch)
 	return args.Error(0)
 }
 
@@ -92,6 +93,51 @@ func (m *MockUserServiceForAuth) GetFavorites(ctx context.Context, userID primit
 	return args.Get(0).([]primitive.ObjectID), args.Error(1)
 }
 
+func (m *MockUserServiceForAuth) UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, goals models.NutritionGoals, source string, effectiveFrom *time.Time) (*models.User, error) {
+	args := m.Called(ctx, userID, goals, source, effectiveFrom)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForAuth) GetGoalTimeline(ctx context.Context, userID primitive.ObjectID) (models.GoalTimeline, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(models.GoalTimeline), args.Error(1)
+}
+
+func (m *MockUserServiceForAuth) CreateHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForAuth) CreateHouseholdInvite(ctx context.Context, userID primitive.ObjectID) (*models.HouseholdInvite, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HouseholdInvite), args.Error(1)
+}
+
+func (m *MockUserServiceForAuth) JoinHousehold(ctx context.Context, userID primitive.ObjectID, inviteToken string) (*models.User, error) {
+	args := m.Called(ctx, userID, inviteToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForAuth) LeaveHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func (m *MockUserServiceForAuth) AddToFavorites(ctx context.Context, userID, dishID primitive.ObjectID) error {
 	args := m.Called(ctx, userID, dishID)
 	return args.Error(0)
@@ -132,6 +178,8 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	}
 
 	authResponse := &models.AuthResponse{
+		Success: true,
+		Message: "User registered successfully",
 		User: models.UserResponse{
 			ID:    primitive.NewObjectID().Hex(),
 			Name:  req.Name,
@@ -201,7 +249,7 @@ func TestAuthHandler_Register_ServiceError(t *testing.T) {
 		Password: "password123",
 	}
 
-	mockAuthService.On("Register", mock.Anything, req).Return(nil, errors.New("user already exists"))
+	mockAuthService.On("Register", mock.Anything, req).Return(nil, errors.New("an account with this email address already exists"))
 
 	requestBody, _ := json.Marshal(req)
 	request := httptest.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(requestBody))
@@ -218,7 +266,7 @@ func TestAuthHandler_Register_ServiceError(t *testing.T) {
 	err := json.Unmarshal(recorder.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.False(t, response.Success)
-	assert.Contains(t, response.Error, "user already exists")
+	assert.Contains(t, response.Error, "an account with this email address already exists")
 	
 	mockAuthService.AssertExpectations(t)
 }
@@ -234,6 +282,8 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	}
 
 	authResponse := &models.AuthResponse{
+		Success: true,
+		Message: "Login successful",
 		User: models.UserResponse{
 			ID:    primitive.NewObjectID().Hex(),
 			Email: req.Email,
@@ -273,7 +323,7 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 		Password: "wrongpassword",
 	}
 
-	mockAuthService.On("Login", mock.Anything, req).Return(nil, errors.New("invalid credentials"))
+	mockAuthService.On("Login", mock.Anything, req).Return(nil, errors.New("invalid email or password"))
 
 	requestBody, _ := json.Marshal(req)
 	request := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(requestBody))
@@ -290,7 +340,7 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 	err := json.Unmarshal(recorder.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.False(t, response.Success)
-	assert.Contains(t, response.Error, "invalid credentials")
+	assert.Contains(t, response.Error, "invalid email or password")
 	
 	mockAuthService.AssertExpectations(t)
 }
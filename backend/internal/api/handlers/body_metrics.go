@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"nourish-backend/internal/api/middleware"
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/service"
+	"nourish-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultBodyMetricsPeriod is the number of days returned when no range is given
+const defaultBodyMetricsPeriod = 90
+
+// BodyMetricsHandler handles body measurement requests
+type BodyMetricsHandler struct {
+	bodyService service.BodyMetricsService
+	validator   *validator.Validate
+	logger      *logger.Logger
+}
+
+// NewBodyMetricsHandler creates a new body metrics handler
+func NewBodyMetricsHandler(bodyService service.BodyMetricsService, log *logger.Logger) *BodyMetricsHandler {
+	return &BodyMetricsHandler{
+		bodyService: bodyService,
+		validator:   validator.New(),
+		logger:      log,
+	}
+}
+
+// CreateMeasurement handles POST /api/body-metrics
+func (h *BodyMetricsHandler) CreateMeasurement(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	req, ok := h.bindMeasurementRequest(c)
+	if !ok {
+		return
+	}
+
+	measurement, err := h.bodyService.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse{
+		Success: true,
+		Message: "Body measurement logged successfully",
+		Data:    measurement,
+	})
+}
+
+// GetMeasurements handles GET /api/body-metrics
+func (h *BodyMetricsHandler) GetMeasurements(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	dateRange, ok := h.parseRange(c)
+	if !ok {
+		return
+	}
+
+	measurements, err := h.bodyService.GetByDateRange(c.Request.Context(), userID, dateRange)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    measurements,
+	})
+}
+
+// GetTrend handles GET /api/body-metrics/trend
+func (h *BodyMetricsHandler) GetTrend(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	dateRange, ok := h.parseRange(c)
+	if !ok {
+		return
+	}
+
+	trend, err := h.bodyService.GetTrend(c.Request.Context(), userID, dateRange)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    trend,
+	})
+}
+
+// GetMeasurement handles GET /api/body-metrics/:id
+func (h *BodyMetricsHandler) GetMeasurement(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid measurement ID",
+		})
+		return
+	}
+
+	measurement, err := h.bodyService.GetByID(c.Request.Context(), userID, id)
+	if err != nil {
+		c.JSON(measurementErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    measurement,
+	})
+}
+
+// UpdateMeasurement handles PUT /api/body-metrics/:id
+func (h *BodyMetricsHandler) UpdateMeasurement(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid measurement ID",
+		})
+		return
+	}
+
+	req, ok := h.bindMeasurementRequest(c)
+	if !ok {
+		return
+	}
+
+	measurement, err := h.bodyService.Update(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.JSON(measurementErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: "Body measurement updated successfully",
+		Data:    measurement,
+	})
+}
+
+// DeleteMeasurement handles DELETE /api/body-metrics/:id
+func (h *BodyMetricsHandler) DeleteMeasurement(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid measurement ID",
+		})
+		return
+	}
+
+	if err := h.bodyService.Delete(c.Request.Context(), userID, id); err != nil {
+		c.JSON(measurementErrorStatus(err), models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: "Body measurement deleted successfully",
+	})
+}
+
+// bindMeasurementRequest parses and validates a measurement request body,
+// writing the error response when it is invalid
+func (h *BodyMetricsHandler) bindMeasurementRequest(c *gin.Context) (models.BodyMeasurementRequest, bool) {
+	var req models.BodyMeasurementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: err.Error(),
+		})
+		return req, false
+	}
+
+	if err := h.validator.Struct(req); err != nil || req.Date.IsZero() {
+		details := "date is required"
+		if err != nil {
+			details = err.Error()
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed - provide a date and at least one of weightKg, waistCm or bodyFatPercent",
+			Details: details,
+		})
+		return req, false
+	}
+
+	// Dates sent without an offset are the user's local time
+	req.Date.Time = req.Date.In(userLocation(c))
+	return req, true
+}
+
+// parseRange reads from/to, range or period query parameters, defaulting to the last 90 days
+func (h *BodyMetricsHandler) parseRange(c *gin.Context) (models.DateRange, bool) {
+	now := time.Now().In(userLocation(c))
+	dateRange, err := parseDateRange(c, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return models.DateRange{}, false
+	}
+	if dateRange != nil {
+		return *dateRange, true
+	}
+
+	period, err := strconv.Atoi(c.DefaultQuery("period", strconv.Itoa(defaultBodyMetricsPeriod)))
+	if err != nil || period <= 0 || period > maxRangeDays {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid period parameter",
+		})
+		return models.DateRange{}, false
+	}
+	return models.LastNDays(period, now), true
+}
+
+// measurementErrorStatus maps body metrics service errors to HTTP status codes
+func measurementErrorStatus(err error) int {
+	if err.Error() == "body measurement not found" {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"nourish-backend/internal/api/middleware"
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/service"
 	"nourish-backend/pkg/logger"
 
@@ -52,16 +54,22 @@ func (h *DishHandler) GetDishes(c *gin.Context) {
 		search = strings.TrimSpace(c.Query("q"))
 	}
 
-	// Parse filter parameters
+	// Parse filter parameters; facets take comma-separated values, e.g. cuisine=Punjabi,Gujarati
 	filter := service.DishFilter{
-		Type:       c.Query("type"),
-		Cuisine:    c.Query("cuisine"),
-		SpiceLevel: c.Query("spiceLevel"),
+		Type:        queryList(c, "type"),
+		Cuisine:     queryList(c, "cuisine"),
+		DietaryTags: queryList(c, "dietaryTags"),
+		SpiceLevel:  queryList(c, "spiceLevel"),
+		Difficulty:  queryList(c, "difficulty"),
 	}
 
-	// Parse dietary tags
-	if tags := c.Query("dietaryTags"); tags != "" {
-		filter.DietaryTags = strings.Split(tags, ",")
+	// Parse calorie and prep time buckets, e.g. calorieRange=under-200,200-400
+	var ok bool
+	if filter.CalorieRanges, ok = bucketKeys(c, "calorieRange", models.GetCalorieBuckets()); !ok {
+		return
+	}
+	if filter.PrepTimeRanges, ok = bucketKeys(c, "prepTimeRange", models.GetPrepTimeBuckets()); !ok {
+		return
 	}
 
 	// Parse calorie range
@@ -76,11 +84,64 @@ func (h *DishHandler) GetDishes(c *gin.Context) {
 		}
 	}
 
+	// Parse glycemic load ceiling
+	if maxGL := c.Query("maxGlycemicLoad"); maxGL != "" {
+		if val, err := strconv.ParseFloat(maxGL, 64); err == nil {
+			filter.MaxGlycemicLoad = val
+		}
+	}
+
 	// Parse ingredients
 	if ingredients := c.Query("ingredients"); ingredients != "" {
 		filter.Ingredients = strings.Split(ingredients, ",")
 	}
 
+	// Parse diet rule sets, e.g. dietRules=jain,navratri
+	if rules := c.Query("dietRules"); rules != "" {
+		for _, rule := range strings.Split(rules, ",") {
+			if !isValidDietRule(rule) {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Success: false,
+					Error:   "Invalid diet rule: " + rule,
+					Details: strings.Join(models.GetDietRules(), ", "),
+				})
+				return
+			}
+			filter.DietRules = append(filter.DietRules, rule)
+		}
+	}
+
+	// Parse all-of and none-of tags and ingredients
+	filter.AllDietaryTags = queryList(c, "allDietaryTags")
+	filter.ExcludeDietaryTags = queryList(c, "excludeDietaryTags")
+	filter.AllIngredients = queryList(c, "allIngredients")
+	filter.ExcludeIngredients = queryList(c, "excludeIngredients")
+	filter.ExcludeDisliked = c.Query("excludeDisliked") == "true"
+
+	// Parse time limits and nutrient minimums
+	filter.MaxPrepTime = queryInt(c, "maxPrepTime")
+	filter.MaxCookTime = queryInt(c, "maxCookTime")
+	filter.MinProtein = queryInt(c, "minProtein")
+	filter.MinFiber = queryInt(c, "minFiber")
+
+	// Parse macro ratio bounds, as percentages of calories, e.g. minProteinPct=25&maxFatPct=30
+	filter.MinProteinShare = float64(queryInt(c, "minProteinPct")) / 100
+	filter.MaxCarbShare = float64(queryInt(c, "maxCarbPct")) / 100
+	filter.MaxFatShare = float64(queryInt(c, "maxFatPct")) / 100
+
+	// Parse sort order
+	if sortBy := c.Query("sort"); sortBy != "" {
+		if !isValidDishSort(sortBy) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid sort: " + sortBy,
+				Details: strings.Join(models.GetDishSorts(), ", "),
+			})
+			return
+		}
+		filter.SortBy = sortBy
+	}
+
 	// Get user ID from context (optional)
 	var userID *primitive.ObjectID
 	if id, exists := middleware.GetUserIDFromContext(c); exists {
@@ -88,21 +149,21 @@ func (h *DishHandler) GetDishes(c *gin.Context) {
 	}
 
 	var dishes []*models.DishResponse
-	var pagination *models.PaginationResponse
+	var pagination interface{}
 	var err error
 
-	// Search or get all dishes
-	if search != "" {
+	// Page with cursors when a cursor is given, even an empty one for the first page;
+	// otherwise search or get all dishes by page number
+	if req, ok := cursorRequest(c, limit); ok {
+		dishes, pagination, err = h.dishService.List(c.Request.Context(), search, filter, req, userID)
+	} else if search != "" {
 		dishes, pagination, err = h.dishService.Search(c.Request.Context(), search, filter, page, limit, userID)
 	} else {
 		dishes, pagination, err = h.dishService.GetAll(c.Request.Context(), filter, page, limit, userID)
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writePaginationError(c, err)
 		return
 	}
 
@@ -112,9 +173,98 @@ func (h *DishHandler) GetDishes(c *gin.Context) {
 		"pagination": pagination,
 	}
 
+	// Facet counts are only computed when asked for with facets=true, and are left out when
+	// they fail rather than failing the listing
+	if c.Query("facets") == "true" {
+		facets, err := h.dishService.GetFacets(c.Request.Context(), search, filter, userID)
+		if err == nil {
+			response["facets"] = facets
+		}
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
+// Autocomplete handles GET /api/dishes/autocomplete?q=bir
+func (h *DishHandler) Autocomplete(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "8"))
+	if limit < 1 || limit > 20 {
+		limit = 8
+	}
+
+	// Too little typed to suggest anything useful
+	if len([]rune(query)) < 2 {
+		c.JSON(http.StatusOK, gin.H{
+			"success":     true,
+			"suggestions": []models.DishSuggestion{},
+		})
+		return
+	}
+
+	var userID *primitive.ObjectID
+	if id, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &id
+	}
+
+	suggestions, err := h.dishService.Autocomplete(c.Request.Context(), query, limit, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"suggestions": suggestions,
+	})
+}
+
+// CompareDishes handles GET /api/dishes/compare?ids=a,b,c
+func (h *DishHandler) CompareDishes(c *gin.Context) {
+	var ids []primitive.ObjectID
+	for _, value := range queryList(c, "ids") {
+		id, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid dish ID: " + value,
+			})
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	comparison, err := h.dishService.Compare(c.Request.Context(), ids, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err.Error() == "dish not found":
+			status = http.StatusNotFound
+		case err.Error() == "choose dishes to compare", strings.HasPrefix(err.Error(), "compare at most"):
+			status = http.StatusBadRequest
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    comparison,
+	})
+}
+
 // GetDish handles GET /api/dishes/:id
 func (h *DishHandler) GetDish(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
@@ -152,6 +302,230 @@ func (h *DishHandler) GetDish(c *gin.Context) {
 	})
 }
 
+// GetCookMode handles GET /api/dishes/:id/cook-mode
+func (h *DishHandler) GetCookMode(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	mode, err := h.dishService.GetCookMode(c.Request.Context(), id, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "dish not found", "dish has no recipe steps":
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    mode,
+	})
+}
+
+// ScaleDish handles GET /api/dishes/:id/scaled
+func (h *DishHandler) ScaleDish(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	servings, err := strconv.Atoi(c.Query("servings"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid servings",
+		})
+		return
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	scaled, err := h.dishService.Scale(c.Request.Context(), id, servings, c.Query("units"), userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err.Error() == "dish not found":
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "servings must be"), err.Error() == "units must be metric or household":
+			status = http.StatusBadRequest
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    scaled,
+	})
+}
+
+// GetDietRules handles GET /api/dishes/:id/diet-rules
+func (h *DishHandler) GetDietRules(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	results, err := h.dishService.GetDietRules(c.Request.Context(), id, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "dish not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    results,
+	})
+}
+
+// GetSubstitutions handles GET /api/dishes/:id/substitutions
+func (h *DishHandler) GetSubstitutions(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	// Without "for", the signed-in user's dietary preferences are used
+	var goals []string
+	if value := c.Query("for"); value != "" {
+		goals, err = nutrition.ParseSubstitutionGoals(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Details: strings.Join(nutrition.SubstitutionGoals(), ", "),
+			})
+			return
+		}
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	result, err := h.dishService.Substitute(c.Request.Context(), id, goals, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "dish not found":
+			status = http.StatusNotFound
+		case "choose a dietary goal to substitute for":
+			status = http.StatusBadRequest
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetSimilar handles GET /api/dishes/:id/similar?excludeIngredients=paneer
+func (h *DishHandler) GetSimilar(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 20 {
+		limit = 10
+	}
+
+	// Narrow the suggestions, e.g. to dishes without an ingredient that is not at hand
+	filter := service.DishFilter{
+		Type:               queryList(c, "type"),
+		AllDietaryTags:     queryList(c, "allDietaryTags"),
+		ExcludeIngredients: queryList(c, "excludeIngredients"),
+		ExcludeDisliked:    c.Query("excludeDisliked") == "true",
+		MaxCalories:        queryInt(c, "maxCalories"),
+	}
+
+	var userID *primitive.ObjectID
+	if uid, exists := middleware.GetUserIDFromContext(c); exists {
+		userID = &uid
+	}
+
+	similar, err := h.dishService.GetSimilar(c.Request.Context(), id, filter, limit, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "dish not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    similar,
+	})
+}
+
 // GetFavorites handles GET /api/dishes/favorites
 func (h *DishHandler) GetFavorites(c *gin.Context) {
 	userID, exists := middleware.GetUserIDFromContext(c)
@@ -212,8 +586,8 @@ func (h *DishHandler) AddToFavorites(c *gin.Context) {
 		return
 	}
 
-	// Check if dish exists
-	_, err = h.dishService.GetByID(c.Request.Context(), dishID, nil)
+	// Check if dish exists and is shared with the user
+	_, err = h.dishService.GetByID(c.Request.Context(), dishID, &userID)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err.Error() == "dish not found" {
@@ -279,6 +653,15 @@ func (h *DishHandler) RemoveFromFavorites(c *gin.Context) {
 
 // CreateDish handles POST /api/dishes
 func (h *DishHandler) CreateDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
 	var req models.DishCreateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -299,47 +682,303 @@ func (h *DishHandler) CreateDish(c *gin.Context) {
 		return
 	}
 
-	// Convert request to dish model
-	dish := &models.Dish{
-		Name:        req.Name,
-		Type:        req.Type,
-		Cuisine:     req.Cuisine,
-		Image:       req.Image,
-		Ingredients: req.Ingredients,
-		Calories:    req.Calories,
-		Nutrition:   req.Nutrition,
-		DietaryTags: req.DietaryTags,
-		SpiceLevel:  req.SpiceLevel,
-		PrepTime:    req.PrepTime,
-		CookTime:    req.CookTime,
-		Servings:    req.Servings,
-		Difficulty:  req.Difficulty,
-		Description: req.Description,
+	dish := req.ToDish()
+
+	// Create dish
+	if err := h.dishService.Create(c.Request.Context(), dish, user); err != nil {
+		writeDishError(c, err)
+		return
 	}
 
-	// Set defaults if not provided
-	if dish.SpiceLevel == "" {
-		dish.SpiceLevel = "medium"
+	c.JSON(http.StatusCreated, models.SuccessResponse{
+		Success: true,
+		Message: "Dish created successfully",
+		Data:    dish.ToResponse(),
+	})
+}
+
+// UpdateDish handles PUT /api/dishes/:id
+func (h *DishHandler) UpdateDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
 	}
-	if dish.Difficulty == "" {
-		dish.Difficulty = "medium"
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
 	}
-	if dish.Servings == 0 {
-		dish.Servings = 2
+
+	var req models.DishCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: err.Error(),
+		})
+		return
 	}
 
-	// Create dish
-	if err := h.dishService.Create(c.Request.Context(), dish); err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
-			Error:   err.Error(),
+			Error:   "Validation failed",
+			Details: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.SuccessResponse{
+	dish := req.ToDish()
+	if err := h.dishService.Update(c.Request.Context(), id, dish, user); err != nil {
+		writeDishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
 		Success: true,
-		Message: "Dish created successfully",
+		Message: "Dish updated successfully",
 		Data:    dish.ToResponse(),
 	})
 }
+
+// PatchDish handles PATCH /api/dishes/:id
+func (h *DishHandler) PatchDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	var req models.DishPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	dish, err := h.dishService.Patch(c.Request.Context(), id, req, user)
+	if err != nil {
+		writeDishError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: "Dish updated successfully",
+		Data:    dish.ToResponse(),
+	})
+}
+
+// DeleteDish handles DELETE /api/dishes/:id?policy=restrict|archive|cascade
+func (h *DishHandler) DeleteDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	result, err := h.dishService.Delete(c.Request.Context(), id, c.DefaultQuery("policy", models.DeletePolicyRestrict), user)
+	if err != nil {
+		writeDishError(c, err)
+		return
+	}
+
+	message := "Dish deleted successfully"
+	if result.Archived {
+		message = "Dish archived successfully"
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// SubmitDish handles POST /api/dishes/:id/submit
+func (h *DishHandler) SubmitDish(c *gin.Context) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid dish ID",
+		})
+		return
+	}
+
+	dish, err := h.dishService.Submit(c.Request.Context(), id, user)
+	if err != nil {
+		writeDishError(c, err)
+		return
+	}
+
+	message := "Dish submitted for review"
+	if dish.ResolvedVisibility() == models.VisibilityPublic {
+		message = "Dish published"
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    dish.ToResponse(),
+	})
+}
+
+// queryList splits a comma-separated query parameter, dropping empty values
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// queryInt parses a non-negative integer query parameter, 0 when missing or invalid
+func queryInt(c *gin.Context, name string) int {
+	value, err := strconv.Atoi(c.Query(name))
+	if err != nil || value < 0 {
+		return 0
+	}
+	return value
+}
+
+// bucketKeys parses a comma-separated list of bucket keys, writing a bad request
+// response and returning false when one is unknown
+func bucketKeys(c *gin.Context, name string, buckets []models.RangeBucket) ([]string, bool) {
+	keys := queryList(c, name)
+	for _, key := range keys {
+		if _, found := models.FindBucket(buckets, key); !found {
+			valid := make([]string, len(buckets))
+			for i, bucket := range buckets {
+				valid[i] = bucket.Key
+			}
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid " + name + ": " + key,
+				Details: strings.Join(valid, ", "),
+			})
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// isValidDishSort reports whether a dish sort order is known
+func isValidDishSort(sortBy string) bool {
+	for _, valid := range models.GetDishSorts() {
+		if sortBy == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidDietRule reports whether a diet rule set name is known
+func isValidDietRule(rule string) bool {
+	for _, valid := range models.GetDietRules() {
+		if rule == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDishError maps dish create, update and delete errors to responses
+func writeDishError(c *gin.Context, err error) {
+	var validationErr *models.DishValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: validationErr.Issues,
+		})
+		return
+	}
+
+	var inUseErr *models.DishInUseError
+	if errors.As(err, &inUseErr) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Details: inUseErr.References,
+			Code:    "DISH_IN_USE",
+		})
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch err.Error() {
+	case "dish not found":
+		status = http.StatusNotFound
+	case "not authorized to modify this dish", "only admins can cascade dish deletion", "only admins can review dishes":
+		status = http.StatusForbidden
+	case "ingredient quantities are required to calculate nutrition",
+		"no ingredients found in the food composition table",
+		"invalid delete policy",
+		"invalid visibility",
+		"join a household before sharing dishes with it",
+		"invalid review decision",
+		"a note is required when rejecting a dish":
+		status = http.StatusBadRequest
+	case "dish is already public", "dish is not awaiting review":
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, models.ErrorResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"nourish-backend/internal/models"
 	"nourish-backend/internal/service"
@@ -32,6 +33,62 @@ func (m *MockDishService) GetByID(ctx context.Context, id primitive.ObjectID, us
 	return args.Get(0).(*models.DishResponse), args.Error(1)
 }
 
+func (m *MockDishService) Autocomplete(ctx context.Context, query string, limit int, userID *primitive.ObjectID) ([]models.DishSuggestion, error) {
+	args := m.Called(ctx, query, limit, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DishSuggestion), args.Error(1)
+}
+
+func (m *MockDishService) GetFacets(ctx context.Context, query string, filter service.DishFilter, userID *primitive.ObjectID) (*models.DishFacets, error) {
+	args := m.Called(ctx, query, filter, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DishFacets), args.Error(1)
+}
+
+func (m *MockDishService) GetCookMode(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.CookMode, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CookMode), args.Error(1)
+}
+
+func (m *MockDishService) Scale(ctx context.Context, id primitive.ObjectID, servings int, units string, userID *primitive.ObjectID) (*models.ScaledDish, error) {
+	args := m.Called(ctx, id, servings, units, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScaledDish), args.Error(1)
+}
+
+func (m *MockDishService) Substitute(ctx context.Context, id primitive.ObjectID, goals []string, userID *primitive.ObjectID) (*models.SubstitutedDish, error) {
+	args := m.Called(ctx, id, goals, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SubstitutedDish), args.Error(1)
+}
+
+func (m *MockDishService) GetDietRules(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) ([]models.DietRuleResult, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DietRuleResult), args.Error(1)
+}
+
+func (m *MockDishService) Compare(ctx context.Context, ids []primitive.ObjectID, userID *primitive.ObjectID) (*models.DishComparison, error) {
+	args := m.Called(ctx, ids, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DishComparison), args.Error(1)
+}
+
 func (m *MockDishService) GetAll(ctx context.Context, filter service.DishFilter, page, limit int, userID *primitive.ObjectID) ([]*models.DishResponse, *models.PaginationResponse, error) {
 	args := m.Called(ctx, filter, page, limit, userID)
 	if args.Get(0) == nil {
@@ -56,18 +113,84 @@ func (m *MockDishService) GetFavorites(ctx context.Context, userID primitive.Obj
 	return args.Get(0).([]*models.DishResponse), args.Get(1).(*models.PaginationResponse), args.Error(2)
 }
 
-func (m *MockDishService) Create(ctx context.Context, dish *models.Dish) error {
-	args := m.Called(ctx, dish)
+func (m *MockDishService) Create(ctx context.Context, dish *models.Dish, actor *models.User) error {
+	args := m.Called(ctx, dish, actor)
+	return args.Error(0)
+}
+
+func (m *MockDishService) Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish, actor *models.User) error {
+	args := m.Called(ctx, id, dish, actor)
+	return args.Error(0)
+}
+
+func (m *MockDishService) Patch(ctx context.Context, id primitive.ObjectID, patch models.DishPatchRequest, actor *models.User) (*models.Dish, error) {
+	args := m.Called(ctx, id, patch, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Dish), args.Error(1)
+}
+
+func (m *MockDishService) Delete(ctx context.Context, id primitive.ObjectID, policy string, actor *models.User) (*models.DishDeleteResult, error) {
+	args := m.Called(ctx, id, policy, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DishDeleteResult), args.Error(1)
+}
+
+func (m *MockDishService) Submit(ctx context.Context, id primitive.ObjectID, actor *models.User) (*models.Dish, error) {
+	args := m.Called(ctx, id, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Dish), args.Error(1)
+}
+
+func (m *MockDishService) GetPendingReviews(ctx context.Context, page, limit int) ([]*models.DishResponse, *models.PaginationResponse, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*models.DishResponse), args.Get(1).(*models.PaginationResponse), args.Error(2)
+}
+
+func (m *MockDishService) List(ctx context.Context, query string, filter service.DishFilter, req models.CursorRequest, userID *primitive.ObjectID) ([]*models.DishResponse, *models.CursorPagination, error) {
+	args := m.Called(ctx, query, filter, req, userID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*models.DishResponse), args.Get(1).(*models.CursorPagination), args.Error(2)
+}
+
+func (m *MockDishService) RefreshStats(ctx context.Context) error {
+	args := m.Called(ctx)
 	return args.Error(0)
 }
 
-func (m *MockDishService) Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish) error {
-	args := m.Called(ctx, id, dish)
+func (m *MockDishService) GetSimilar(ctx context.Context, id primitive.ObjectID, filter service.DishFilter, limit int, userID *primitive.ObjectID) ([]*models.SimilarDish, error) {
+	args := m.Called(ctx, id, filter, limit, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SimilarDish), args.Error(1)
+}
+
+func (m *MockDishService) RefreshNeighbors(ctx context.Context) error {
+	args := m.Called(ctx)
 	return args.Error(0)
 }
 
-func (m *MockDishService) Delete(ctx context.Context, id primitive.ObjectID) error {
-	args := m.Called(ctx, id)
+func (m *MockDishService) Review(ctx context.Context, id primitive.ObjectID, req models.DishReviewRequest, reviewer *models.User) (*models.Dish, error) {
+	args := m.Called(ctx, id, req, reviewer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Dish), args.Error(1)
+}
+
+func (m *MockDishService) MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	args := m.Called(ctx, ownerID, householdID)
 	return args.Error(0)
 }
 
@@ -84,8 +207,8 @@ func (m *MockUserServiceForDish) GetByID(ctx context.Context, userID primitive.O
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceForDish) UpdateProfile(ctx context.Context, userID primitive.ObjectID, profile models.UserProfile) error {
-	args := m.Called(ctx, userID, profile)
+func (m *MockUserServiceForDish) UpdateProfile(ctx context.Context, userID primitive.ObjectID, patch models.ProfilePatch) error {
+	args := m.Called(ctx, userID, patch)
 	return args.Error(0)
 }
 
@@ -112,11 +235,65 @@ func (m *MockUserServiceForDish) GetFavorites(ctx context.Context, userID primit
 	return args.Get(0).([]primitive.ObjectID), args.Error(1)
 }
 
+func (m *MockUserServiceForDish) UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, goals models.NutritionGoals, source string, effectiveFrom *time.Time) (*models.User, error) {
+	args := m.Called(ctx, userID, goals, source, effectiveFrom)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForDish) GetGoalTimeline(ctx context.Context, userID primitive.ObjectID) (models.GoalTimeline, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(models.GoalTimeline), args.Error(1)
+}
+
+func (m *MockUserServiceForDish) CreateHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForDish) CreateHouseholdInvite(ctx context.Context, userID primitive.ObjectID) (*models.HouseholdInvite, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HouseholdInvite), args.Error(1)
+}
+
+func (m *MockUserServiceForDish) JoinHousehold(ctx context.Context, userID primitive.ObjectID, inviteToken string) (*models.User, error) {
+	args := m.Called(ctx, userID, inviteToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForDish) LeaveHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func (m *MockUserServiceForDish) Delete(ctx context.Context, userID primitive.ObjectID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
+// authenticateAs sets the user that the auth middleware would put in the context
+func authenticateAs(user *models.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("user", user)
+		c.Set("userID", user.ID)
+		c.Next()
+	}
+}
+
 func setupDishHandler() (*DishHandler, *MockDishService, *MockUserServiceForDish, *gin.Engine) {
 	gin.SetMode(gin.TestMode)
 	
@@ -254,23 +431,49 @@ func TestDishHandler_GetDishes_Success(t *testing.T) {
 	err := json.Unmarshal(recorder.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.True(t, response.Success)
+	assert.NotContains(t, recorder.Body.String(), `"facets"`)
 	
 	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "GetFacets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDishHandler_GetDishes_WithFacets(t *testing.T) {
+	// Arrange
+	handler, mockService, _, router := setupDishHandler()
+	router.GET("/dishes", handler.GetDishes)
+
+	pagination := &models.PaginationResponse{Page: 1, Limit: 20}
+	facets := &models.DishFacets{}
+
+	mockService.On("GetAll", mock.Anything, mock.AnythingOfType("service.DishFilter"), 1, 20, (*primitive.ObjectID)(nil)).Return([]*models.DishResponse{}, pagination, nil)
+	mockService.On("GetFacets", mock.Anything, "", mock.AnythingOfType("service.DishFilter"), (*primitive.ObjectID)(nil)).Return(facets, nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/dishes?facets=true", nil)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"facets"`)
+	mockService.AssertExpectations(t)
 }
 
 func TestDishHandler_CreateDish_Success(t *testing.T) {
 	// Arrange
 	handler, mockService, _, router := setupDishHandler()
-	router.POST("/dishes", handler.CreateDish)
+	router.POST("/dishes", authenticateAs(&models.User{ID: primitive.NewObjectID()}), handler.CreateDish)
 
 	dish := models.Dish{
-		Name:     "New Dish",
-		Type:     "Veg",
-		Cuisine:  "Indian",
-		Calories: 300,
+		Name:        "New Dish",
+		Type:        "Veg",
+		Cuisine:     "Indian",
+		Ingredients: []string{"paneer"},
+		Calories:    300,
 	}
 
-	mockService.On("Create", mock.Anything, mock.AnythingOfType("*models.Dish")).Return(nil)
+	mockService.On("Create", mock.Anything, mock.AnythingOfType("*models.Dish"), mock.AnythingOfType("*models.User")).Return(nil)
 
 	requestBody, _ := json.Marshal(dish)
 	request := httptest.NewRequest(http.MethodPost, "/dishes", bytes.NewBuffer(requestBody))
@@ -295,7 +498,7 @@ func TestDishHandler_CreateDish_Success(t *testing.T) {
 func TestDishHandler_CreateDish_InvalidJSON(t *testing.T) {
 	// Arrange
 	handler, _, _, router := setupDishHandler()
-	router.POST("/dishes", handler.CreateDish)
+	router.POST("/dishes", authenticateAs(&models.User{ID: primitive.NewObjectID()}), handler.CreateDish)
 
 	request := httptest.NewRequest(http.MethodPost, "/dishes", bytes.NewBufferString("invalid json"))
 	request.Header.Set("Content-Type", "application/json")
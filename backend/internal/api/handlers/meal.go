@@ -67,6 +67,9 @@ func (h *MealHandler) CreateMeal(c *gin.Context) {
 		return
 	}
 
+	// Dates sent without an offset are the user's local time
+	req.Date.Time = req.Date.In(userLocation(c))
+
 	meal, err := h.mealService.Create(c.Request.Context(), userID, req)
 	if err != nil {
 		status := http.StatusInternalServerError
@@ -106,8 +109,9 @@ func (h *MealHandler) GetMeals(c *gin.Context) {
 	endDateStr := c.Query("endDate")
 
 	if startDateStr != "" && endDateStr != "" {
-		// Handle date range query
-		startDate, err := time.Parse("2006-01-02", startDateStr)
+		// Handle date range query in the user's time zone
+		loc := userLocation(c)
+		startDate, err := parseDay(startDateStr, loc)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ErrorResponse{
 				Success: false,
@@ -116,7 +120,7 @@ func (h *MealHandler) GetMeals(c *gin.Context) {
 			return
 		}
 
-		endDate, err := time.Parse("2006-01-02", endDateStr)
+		endDate, err := parseDay(endDateStr, loc)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.ErrorResponse{
 				Success: false,
@@ -125,7 +129,8 @@ func (h *MealHandler) GetMeals(c *gin.Context) {
 			return
 		}
 
-		meals, err := h.mealService.GetByDateRange(c.Request.Context(), userID, startDate, endDate)
+		dateRange := models.NewDateRange(startDate, endDate)
+		meals, err := h.mealService.GetByDateRange(c.Request.Context(), userID, dateRange.From, dateRange.To)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 				Success: false,
@@ -152,12 +157,16 @@ func (h *MealHandler) GetMeals(c *gin.Context) {
 		limit = 20
 	}
 
-	meals, pagination, err := h.mealService.GetByUserID(c.Request.Context(), userID, page, limit)
+	var meals []*models.MealWithDish
+	var pagination interface{}
+	var err error
+	if req, ok := cursorRequest(c, limit); ok {
+		meals, pagination, err = h.mealService.ListByUserID(c.Request.Context(), userID, req)
+	} else {
+		meals, pagination, err = h.mealService.GetByUserID(c.Request.Context(), userID, page, limit)
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
+		writePaginationError(c, err)
 		return
 	}
 
@@ -183,13 +192,12 @@ func (h *MealHandler) GetMeal(c *gin.Context) {
 
 	idParam := c.Param("id")
 
-	// Try to parse as date first (YYYY-MM-DD format)
-	if date, err := time.Parse("2006-01-02", idParam); err == nil {
+	// Try to parse as date first (YYYY-MM-DD format, in the user's time zone)
+	if date, err := parseDay(idParam, userLocation(c)); err == nil {
 		// Handle as date - get meals for the specific date
-		startDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-		endDate := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+		day := models.NewDateRange(date, date)
 
-		meals, err := h.mealService.GetByDateRange(c.Request.Context(), userID, startDate, endDate)
+		meals, err := h.mealService.GetByDateRange(c.Request.Context(), userID, day.From, day.To)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 				Success: false,
@@ -266,6 +274,9 @@ func (h *MealHandler) UpdateMeal(c *gin.Context) {
 		return
 	}
 
+	// Dates sent without an offset are the user's local time
+	req.Date.Time = req.Date.In(userLocation(c))
+
 	meal, err := h.mealService.Update(c.Request.Context(), id, req)
 	if err != nil {
 		status := http.StatusInternalServerError
@@ -379,7 +390,7 @@ func (h *MealHandler) DeleteByDateAndDish(c *gin.Context) {
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", body.Date)
+	date, err := parseDay(body.Date, userLocation(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Success: false, Error: "Invalid date format"})
 		return
@@ -391,8 +402,8 @@ func (h *MealHandler) DeleteByDateAndDish(c *gin.Context) {
 		return
 	}
 
-	startDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endDate := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+	day := models.NewDateRange(date, date)
+	startDate, endDate := day.From, day.To
 
 	if err := h.mealService.DeleteByUserDateAndDish(c.Request.Context(), userID, startDate, endDate, dishObjID); err != nil {
 		h.logger.Error("Failed to delete meals by date and dish", "error", err)
@@ -421,7 +432,7 @@ func (h *MealHandler) UndoDeleteByDateAndDish(c *gin.Context) {
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", body.Date)
+	date, err := parseDay(body.Date, userLocation(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Success: false, Error: "Invalid date format"})
 		return
@@ -433,8 +444,8 @@ func (h *MealHandler) UndoDeleteByDateAndDish(c *gin.Context) {
 		return
 	}
 
-	startDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endDate := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
+	day := models.NewDateRange(date, date)
+	startDate, endDate := day.From, day.To
 
 	if err := h.mealService.UndoDeleteByUserDateAndDish(c.Request.Context(), userID, startDate, endDate, dishObjID); err != nil {
 		h.logger.Error("Failed to undo delete by date and dish", "error", err)
@@ -498,7 +509,8 @@ func (h *MealHandler) GetNutritionSummary(c *gin.Context) {
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", startDateStr)
+	loc := userLocation(c)
+	startDate, err := parseDay(startDateStr, loc)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
@@ -507,7 +519,7 @@ func (h *MealHandler) GetNutritionSummary(c *gin.Context) {
 		return
 	}
 
-	endDate, err := time.Parse("2006-01-02", endDateStr)
+	endDate, err := parseDay(endDateStr, loc)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
@@ -516,7 +528,8 @@ func (h *MealHandler) GetNutritionSummary(c *gin.Context) {
 		return
 	}
 
-	summary, err := h.mealService.GetNutritionSummary(c.Request.Context(), userID, startDate, endDate)
+	dateRange := models.NewDateRange(startDate, endDate)
+	summary, err := h.mealService.GetNutritionSummary(c.Request.Context(), userID, dateRange.From, dateRange.To)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -564,8 +577,8 @@ func (h *MealHandler) GetMealsByMonth(c *gin.Context) {
 		return
 	}
 
-	// Calculate start and end dates for the month
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	// Calculate start and end dates for the month in the user's time zone
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, userLocation(c))
 	endDate := startDate.AddDate(0, 1, 0).Add(-time.Nanosecond) // Last nanosecond of the month
 
 	// Get meals for the date range
@@ -57,6 +57,14 @@ func (m *MockMealService) GetByUserID(ctx context.Context, userID primitive.Obje
 	return args.Get(0).([]*models.MealWithDish), args.Get(1).(*models.PaginationResponse), args.Error(2)
 }
 
+func (m *MockMealService) ListByUserID(ctx context.Context, userID primitive.ObjectID, req models.CursorRequest) ([]*models.MealWithDish, *models.CursorPagination, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*models.MealWithDish), args.Get(1).(*models.CursorPagination), args.Error(2)
+}
+
 func (m *MockMealService) GetByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.MealWithDish, error) {
 	args := m.Called(ctx, userID, startDate, endDate)
 	if args.Get(0) == nil {
@@ -78,8 +86,46 @@ func (m *MockMealService) Delete(ctx context.Context, id primitive.ObjectID) err
 	return args.Error(0)
 }
 
-func (m *MockMealService) GetAnalytics(ctx context.Context, userID primitive.ObjectID, period int) (*models.AnalyticsResponse, error) {
-	args := m.Called(ctx, userID, period)
+func (m *MockMealService) DeleteMany(ctx context.Context, ids []primitive.ObjectID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockMealService) DeleteByUserDateAndDish(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, userID, startDate, endDate, dishID)
+	return args.Error(0)
+}
+
+func (m *MockMealService) UndoDeleteByUserDateAndDish(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, userID, startDate, endDate, dishID)
+	return args.Error(0)
+}
+
+func (m *MockMealService) SoftDeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockMealService) CreateUndoableSoftDelete(ctx context.Context, userID primitive.ObjectID, ids []primitive.ObjectID, ttl time.Duration) (string, error) {
+	args := m.Called(ctx, userID, ids, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMealService) UndoByToken(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockMealService) GetMicronutrientIntake(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.MicronutrientIntake, error) {
+	args := m.Called(ctx, userID, dateRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MicronutrientIntake), args.Error(1)
+}
+
+func (m *MockMealService) GetAnalytics(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions) (*models.AnalyticsResponse, error) {
+	args := m.Called(ctx, userID, dateRange, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -102,8 +148,8 @@ func (m *MockMealService) GetRecommendations(ctx context.Context, userID primiti
 	return args.Get(0).(*models.RecommendationsResponse), args.Error(1)
 }
 
-func (m *MockMealService) GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, period int) (*models.NutritionProgressResponse, error) {
-	args := m.Called(ctx, userID, period)
+func (m *MockMealService) GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions, goals models.GoalTimeline) (*models.NutritionProgressResponse, error) {
+	args := m.Called(ctx, userID, dateRange, opts, goals)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -318,7 +364,7 @@ func TestMealHandler_GetMeal_NotFound(t *testing.T) {
 	router.ServeHTTP(recorder, request)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
 	
 	mockService.AssertExpectations(t)
 }
@@ -342,10 +388,10 @@ func TestMealHandler_GetMeals_Success(t *testing.T) {
 		},
 	}
 
-	// Mock GetByDateRange which is what the handler actually calls for date queries
+	// Mock GetByDateRange which is what the handler calls for startDate/endDate queries
 	mockService.On("GetByDateRange", mock.Anything, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(meals, nil)
 
-	request := httptest.NewRequest(http.MethodGet, "/meals?date="+date, nil)
+	request := httptest.NewRequest(http.MethodGet, "/meals?startDate="+date+"&endDate="+date, nil)
 	recorder := httptest.NewRecorder()
 
 	// Act
@@ -410,7 +456,7 @@ func TestMealHandler_DeleteMeal_Success(t *testing.T) {
 	router.DELETE("/meals/:id", handler.DeleteMeal)
 
 	mealID := primitive.NewObjectID()
-	mockService.On("Delete", mock.Anything, mealID).Return(nil)
+	mockService.On("SoftDeleteByIDs", mock.Anything, []primitive.ObjectID{mealID}).Return(nil)
 
 	request := httptest.NewRequest(http.MethodDelete, "/meals/"+mealID.Hex(), nil)
 	recorder := httptest.NewRecorder()
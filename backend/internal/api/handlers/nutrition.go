@@ -3,9 +3,11 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"nourish-backend/internal/api/middleware"
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/service"
 	"nourish-backend/pkg/logger"
 
@@ -39,51 +41,57 @@ func (h *NutritionHandler) GetNutritionProgress(c *gin.Context) {
 		return
 	}
 
-	// Parse period parameter (default to 7 days)
-	periodStr := c.DefaultQuery("period", "7")
-	period, err := strconv.Atoi(periodStr)
-	if err != nil || period <= 0 {
+	now := time.Now().In(userLocation(c))
+	dateRange, err := parseDateRange(c, now)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
-			Error:   "Invalid period parameter",
+			Error:   err.Error(),
 		})
 		return
 	}
 
-	// Get user's nutrition goals first
-	user, err := h.userService.GetByID(c.Request.Context(), userID)
+	if dateRange == nil {
+		// Parse period parameter (default to 7 days)
+		periodStr := c.DefaultQuery("period", "7")
+		period, err := strconv.Atoi(periodStr)
+		if err != nil || period <= 0 || period > maxRangeDays {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid period parameter",
+			})
+			return
+		}
+		lastDays := models.LastNDays(period, now)
+		dateRange = &lastDays
+	}
+
+	opts, err := parseSeriesOptions(c)
 	if err != nil {
-		h.logger.Error("Failed to get user for nutrition progress", "error", err, "userID", userID.Hex())
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
-			Error:   "User not found",
+			Error:   err.Error(),
 		})
 		return
 	}
 
-	// Get the user's goals and set defaults for any that are 0
-	goals := user.Profile.NutritionGoals
-	if goals.DailyCalories == 0 {
-		goals.DailyCalories = 2000
-	}
-	if goals.Protein == 0 {
-		goals.Protein = 150
-	}
-	if goals.Carbs == 0 {
-		goals.Carbs = 250
-	}
-	if goals.Fat == 0 {
-		goals.Fat = 65
-	}
-	if goals.Fiber == 0 {
-		goals.Fiber = 25
-	}
-	if goals.Sodium == 0 {
-		goals.Sodium = 2300
+	// Get user's goal history first, so each day is graded against the goals in force then
+	timeline, err := h.userService.GetGoalTimeline(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get goal history for nutrition progress", "error", err, "userID", userID.Hex())
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
 	// Get nutrition progress from meal service
-	progress, err := h.mealService.GetNutritionProgress(c.Request.Context(), userID, period)
+	progress, err := h.mealService.GetNutritionProgress(c.Request.Context(), userID, *dateRange, opts, timeline)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
@@ -92,9 +100,6 @@ func (h *NutritionHandler) GetNutritionProgress(c *gin.Context) {
 		return
 	}
 
-	// Override the goals in the response with the actual user's goals
-	progress.Goals = goals
-
 	c.JSON(http.StatusOK, models.SuccessResponse{
 		Success: true,
 		Data:    progress,
@@ -123,30 +128,114 @@ func (h *NutritionHandler) GetNutritionGoals(c *gin.Context) {
 		return
 	}
 
-	// Get user's nutrition goals and set defaults for any that are 0
-	goals := user.Profile.NutritionGoals
-	if goals.DailyCalories == 0 {
-		goals.DailyCalories = 2000
+	// Get user's nutrition goals, with defaults for any that are unset
+	goals := nutrition.GoalsForProfile(user.Profile)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    goals,
+	})
+}
+
+// GetSuggestedGoals handles GET /api/nutrition/goals/suggested
+func (h *NutritionHandler) GetSuggestedGoals(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get user for suggested goals", "error", err, "userID", userID.Hex())
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "User not found",
+		})
+		return
+	}
+
+	response := models.GoalsResponse{
+		Goals:  nutrition.GoalsForProfile(user.Profile),
+		Source: user.Profile.ResolvedGoalsSource(),
 	}
-	if goals.Protein == 0 {
-		goals.Protein = 150
+
+	if user.Profile.BodyMetrics != nil {
+		suggestion, err := nutrition.Suggest(*user.Profile.BodyMetrics)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		response.Suggestion = suggestion
 	}
-	if goals.Carbs == 0 {
-		goals.Carbs = 250
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// AcceptSuggestedGoals handles POST /api/nutrition/goals/accept
+func (h *NutritionHandler) AcceptSuggestedGoals(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
 	}
-	if goals.Fat == 0 {
-		goals.Fat = 65
+
+	user, err := h.userService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get user for accepting goals", "error", err, "userID", userID.Hex())
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "User not found",
+		})
+		return
 	}
-	if goals.Fiber == 0 {
-		goals.Fiber = 25
+
+	if user.Profile.BodyMetrics == nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Body metrics are required to calculate goals",
+		})
+		return
 	}
-	if goals.Sodium == 0 {
-		goals.Sodium = 2300
+
+	suggestion, err := nutrition.Suggest(*user.Profile.BodyMetrics)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// Calculated goals follow later body metric updates until the user overrides them
+	if _, err := h.userService.UpdateNutritionGoals(c.Request.Context(), userID, suggestion.Goals, models.GoalsSourceCalculated, nil); err != nil {
+		h.logger.Error("Failed to save accepted nutrition goals", "error", err, "userID", userID.Hex())
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   "Failed to update nutrition goals",
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, models.SuccessResponse{
 		Success: true,
-		Data:    goals,
+		Data: models.GoalsResponse{
+			Goals:      suggestion.Goals,
+			Source:     models.GoalsSourceCalculated,
+			Suggestion: suggestion,
+		},
 	})
 }
 
@@ -170,15 +259,18 @@ func (h *NutritionHandler) UpdateNutritionGoals(c *gin.Context) {
 		return
 	}
 
-	// Get the current user to update their profile
-	user, err := h.userService.GetByID(c.Request.Context(), userID)
-	if err != nil {
-		h.logger.Error("Failed to get user for nutrition goals update", "error", err, "userID", userID.Hex())
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
-			Success: false,
-			Error:   "User not found",
-		})
-		return
+	// Optional backdated start of the new goals, in the user's time zone
+	var effectiveFrom *time.Time
+	if req.EffectiveFrom != "" {
+		day, err := parseDay(req.EffectiveFrom, userLocation(c))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid effectiveFrom date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+		effectiveFrom = &day
 	}
 
 	// Update the nutrition goals in the user's profile
@@ -189,18 +281,31 @@ func (h *NutritionHandler) UpdateNutritionGoals(c *gin.Context) {
 		Fat:           req.Fat,
 		Fiber:         req.Fiber,
 		Sodium:        req.Sodium,
+		Sugar:         req.Sugar,
+		Semantics:     req.Semantics,
+	}
+	if err := goals.Semantics.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
-	// Update the user's profile with new nutrition goals
-	user.Profile.NutritionGoals = goals
-
-	// Save the updated profile using the user service
-	err = h.userService.UpdateProfile(c.Request.Context(), userID, user.Profile)
+	// Save the new goals as a custom version, overriding any calculated ones
+	_, err := h.userService.UpdateNutritionGoals(c.Request.Context(), userID, goals, models.GoalsSourceCustom, effectiveFrom)
 	if err != nil {
-		h.logger.Error("Failed to update user profile with nutrition goals", "error", err, "userID", userID.Hex())
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		h.logger.Error("Failed to update nutrition goals", "error", err, "userID", userID.Hex())
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "user not found":
+			status = http.StatusNotFound
+		case "effective date must not be in the future":
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, models.ErrorResponse{
 			Success: false,
-			Error:   "Failed to update nutrition goals",
+			Error:   err.Error(),
 		})
 		return
 	}
@@ -212,3 +317,96 @@ func (h *NutritionHandler) UpdateNutritionGoals(c *gin.Context) {
 		Data:    goals,
 	})
 }
+
+// GetGoalHistory handles GET /api/nutrition/goals/history
+func (h *NutritionHandler) GetGoalHistory(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	timeline, err := h.userService.GetGoalTimeline(c.Request.Context(), userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "user not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    timeline.Changes(),
+	})
+}
+
+// GetDeficiencyReport handles GET /api/nutrition/deficiencies
+func (h *NutritionHandler) GetDeficiencyReport(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	now := time.Now().In(userLocation(c))
+	dateRange, err := parseDateRange(c, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if dateRange == nil {
+		// Parse period parameter (default to 30 days)
+		period, err := strconv.Atoi(c.DefaultQuery("period", "30"))
+		if err != nil || period <= 0 || period > maxRangeDays {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Error:   "Invalid period parameter",
+			})
+			return
+		}
+		lastDays := models.LastNDays(period, now)
+		dateRange = &lastDays
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to get user for deficiency report", "error", err, "userID", userID.Hex())
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Success: false,
+			Error:   "User not found",
+		})
+		return
+	}
+
+	intake, err := h.mealService.GetMicronutrientIntake(c.Request.Context(), userID, *dateRange)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	goals := nutrition.GoalsForProfile(user.Profile)
+	refs := nutrition.MicronutrientReferences(user.Profile.BodyMetrics, goals.DailyCalories)
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Data:    nutrition.BuildDeficiencyReport(*intake, refs),
+	})
+}
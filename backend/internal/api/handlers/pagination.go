@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"nourish-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cursorRequest reads cursor pagination parameters. Listings page with cursors when the
+// cursor parameter is present, empty for the first page; includeTotal=true adds the count.
+func cursorRequest(c *gin.Context, limit int) (models.CursorRequest, bool) {
+	cursor, ok := c.GetQuery("cursor")
+	if !ok {
+		return models.CursorRequest{}, false
+	}
+	return models.CursorRequest{
+		Cursor:       cursor,
+		Limit:        limit,
+		IncludeTotal: c.Query("includeTotal") == "true",
+	}, true
+}
+
+// writePaginationError responds to a failed listing, as a bad request for an invalid cursor
+func writePaginationError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, models.ErrInvalidCursor) {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, models.ErrorResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"nourish-backend/internal/api/middleware"
+	"nourish-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userLocation returns the authenticated user's time zone, or UTC for anonymous requests
+func userLocation(c *gin.Context) *time.Location {
+	if user, exists := middleware.GetUserFromContext(c); exists && user != nil {
+		return user.Profile.Location()
+	}
+	return time.UTC
+}
+
+// parseDay parses a YYYY-MM-DD calendar day as midnight in loc
+func parseDay(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, loc)
+}
+
+// maxRangeDays is the longest date range accepted by the analytics endpoints
+const maxRangeDays = 366
+
+// parseDateRange resolves an explicit from/to range or a week/month/quarter preset from the query.
+// It returns nil when neither is given so callers can fall back to the period parameter.
+func parseDateRange(c *gin.Context, now time.Time) (*models.DateRange, error) {
+	from := c.Query("from")
+	to := c.Query("to")
+
+	if from != "" || to != "" {
+		if from == "" || to == "" {
+			return nil, errors.New("from and to parameters must be provided together")
+		}
+		dateRange, err := models.ParseDateRange(from, to, now.Location())
+		if err != nil {
+			return nil, err
+		}
+		if dateRange.Days() > maxRangeDays {
+			return nil, errors.New("date range must not exceed 366 days")
+		}
+		return &dateRange, nil
+	}
+
+	if preset := c.Query("range"); preset != "" {
+		dateRange, err := models.PresetRange(preset, now)
+		if err != nil {
+			return nil, err
+		}
+		return &dateRange, nil
+	}
+
+	return nil, nil
+}
+
+// parseSeriesOptions reads the optional rollup (week|month) and movingAverage (true|false) parameters
+func parseSeriesOptions(c *gin.Context) (models.SeriesOptions, error) {
+	opts := models.SeriesOptions{
+		Rollup: c.Query("rollup"),
+	}
+
+	if movingAverage := c.Query("movingAverage"); movingAverage != "" {
+		enabled, err := strconv.ParseBool(movingAverage)
+		if err != nil {
+			return opts, errors.New("invalid movingAverage parameter")
+		}
+		opts.MovingAverage = enabled
+	}
+
+	return opts, opts.Validate()
+}
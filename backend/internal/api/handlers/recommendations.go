@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"time"
 
 	"nourish-backend/internal/api/middleware"
 	"nourish-backend/internal/models"
@@ -52,7 +51,7 @@ func (h *RecommendationsHandler) GetRecommendations(c *gin.Context) {
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := parseDay(dateStr, userLocation(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"time"
 
 	"nourish-backend/internal/api/middleware"
 	"nourish-backend/internal/models"
@@ -49,7 +48,8 @@ func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 		return
 	}
 
-	startDate, err := time.Parse("2006-01-02", startDateStr)
+	loc := userLocation(c)
+	startDate, err := parseDay(startDateStr, loc)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
@@ -58,7 +58,7 @@ func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 		return
 	}
 
-	endDate, err := time.Parse("2006-01-02", endDateStr)
+	endDate, err := parseDay(endDateStr, loc)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
@@ -67,7 +67,8 @@ func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
 		return
 	}
 
-	shoppingList, err := h.mealService.GetShoppingList(c.Request.Context(), userID, startDate, endDate)
+	dateRange := models.NewDateRange(startDate, endDate)
+	shoppingList, err := h.mealService.GetShoppingList(c.Request.Context(), userID, dateRange.From, dateRange.To)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
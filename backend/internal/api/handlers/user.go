@@ -131,3 +131,136 @@ func (h *UserHandler) DeleteAccount(c *gin.Context) {
 		Message: "Account deleted successfully",
 	})
 }
+
+// CreateHousehold handles POST /api/user/household
+func (h *UserHandler) CreateHousehold(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	user, err := h.userService.CreateHousehold(c.Request.Context(), userID)
+	if err != nil {
+		writeHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse{
+		Success: true,
+		Message: "Household created; create an invite so others can join",
+		Data:    user.ToResponse(),
+	})
+}
+
+// CreateHouseholdInvite handles POST /api/user/household/invites
+func (h *UserHandler) CreateHouseholdInvite(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	invite, err := h.userService.CreateHouseholdInvite(c.Request.Context(), userID)
+	if err != nil {
+		writeHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse{
+		Success: true,
+		Message: "Invite created; share it with the person joining",
+		Data:    invite,
+	})
+}
+
+// JoinHousehold handles PUT /api/user/household
+func (h *UserHandler) JoinHousehold(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	var req models.HouseholdJoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Invalid request format",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.userService.JoinHousehold(c.Request.Context(), userID, req.InviteToken)
+	if err != nil {
+		writeHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: "Joined household",
+		Data:    user.ToResponse(),
+	})
+}
+
+// LeaveHousehold handles DELETE /api/user/household
+func (h *UserHandler) LeaveHousehold(c *gin.Context) {
+	userID, exists := middleware.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Error:   "Authentication required",
+		})
+		return
+	}
+
+	user, err := h.userService.LeaveHousehold(c.Request.Context(), userID)
+	if err != nil {
+		writeHouseholdError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{
+		Success: true,
+		Message: "Left household; your household dishes are now private",
+		Data:    user.ToResponse(),
+	})
+}
+
+// writeHouseholdError maps household errors to responses
+func writeHouseholdError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch err.Error() {
+	case "user not found":
+		status = http.StatusNotFound
+	case "invalid or expired invite":
+		status = http.StatusForbidden
+	case "not in a household":
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, models.ErrorResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
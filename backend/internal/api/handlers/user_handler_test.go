@@ -32,8 +32,8 @@ func (m *MockUserServiceForUserHandler) GetByID(ctx context.Context, userID prim
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceForUserHandler) UpdateProfile(ctx context.Context, userID primitive.ObjectID, profile models.UserProfile) error {
-	args := m.Called(ctx, userID, profile)
+func (m *MockUserServiceForUserHandler) UpdateProfile(ctx context.Context, userID primitive.ObjectID, patch models.ProfilePatch) error {
+	args := m.Called(ctx, userID, patch)
 	return args.Error(0)
 }
 
@@ -60,6 +60,51 @@ func (m *MockUserServiceForUserHandler) GetFavorites(ctx context.Context, userID
 	return args.Get(0).([]primitive.ObjectID), args.Error(1)
 }
 
+func (m *MockUserServiceForUserHandler) UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, goals models.NutritionGoals, source string, effectiveFrom *time.Time) (*models.User, error) {
+	args := m.Called(ctx, userID, goals, source, effectiveFrom)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForUserHandler) GetGoalTimeline(ctx context.Context, userID primitive.ObjectID) (models.GoalTimeline, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(models.GoalTimeline), args.Error(1)
+}
+
+func (m *MockUserServiceForUserHandler) CreateHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForUserHandler) CreateHouseholdInvite(ctx context.Context, userID primitive.ObjectID) (*models.HouseholdInvite, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.HouseholdInvite), args.Error(1)
+}
+
+func (m *MockUserServiceForUserHandler) JoinHousehold(ctx context.Context, userID primitive.ObjectID, inviteToken string) (*models.User, error) {
+	args := m.Called(ctx, userID, inviteToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserServiceForUserHandler) LeaveHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func (m *MockUserServiceForUserHandler) Delete(ctx context.Context, userID primitive.ObjectID) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
@@ -146,15 +191,20 @@ func TestUserHandler_UpdateProfile_Success(t *testing.T) {
 	handler, mockService, router := setupUserHandler()
 	router.PUT("/profile", handler.UpdateProfile)
 
+	preferences, spiceLevel := []string{"vegan"}, "hot"
 	req := models.ProfileUpdateRequest{
 		Name: "Updated Name",
-		Profile: models.UserProfile{
-			DietaryPreferences: []string{"vegan"},
-			SpiceLevel:         "hot",
+		Profile: models.ProfilePatch{
+			DietaryPreferences: &preferences,
+			SpiceLevel:         &spiceLevel,
 		},
 	}
 
 	mockService.On("UpdateUserProfile", mock.Anything, mock.AnythingOfType("primitive.ObjectID"), req).Return(nil)
+	mockService.On("GetByID", mock.Anything, mock.AnythingOfType("primitive.ObjectID")).Return(&models.User{
+		Name:    req.Name,
+		Profile: models.UserProfile{DietaryPreferences: preferences, SpiceLevel: spiceLevel},
+	}, nil)
 
 	requestBody, _ := json.Marshal(req)
 	request := httptest.NewRequest(http.MethodPut, "/profile", bytes.NewBuffer(requestBody))
@@ -203,11 +253,12 @@ func TestUserHandler_UpdateProfile_ServiceError(t *testing.T) {
 	handler, mockService, router := setupUserHandler()
 	router.PUT("/profile", handler.UpdateProfile)
 
+	preferences, spiceLevel := []string{"vegan"}, "hot"
 	req := models.ProfileUpdateRequest{
 		Name: "Updated Name",
-		Profile: models.UserProfile{
-			DietaryPreferences: []string{"vegan"},
-			SpiceLevel:         "hot",
+		Profile: models.ProfilePatch{
+			DietaryPreferences: &preferences,
+			SpiceLevel:         &spiceLevel,
 		},
 	}
 
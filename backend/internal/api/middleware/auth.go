@@ -85,6 +85,24 @@ func OptionalAuthMiddleware(authService service.AuthService) gin.HandlerFunc {
 	}
 }
 
+// AdminMiddleware rejects authenticated users without the admin role.
+// It must run after AuthMiddleware.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, exists := GetUserFromContext(c)
+		if !exists || !user.IsAdmin() {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Success: false,
+				Error:   "Admin access required",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // GetUserFromContext extracts user from gin context
 func GetUserFromContext(c *gin.Context) (*models.User, bool) {
 	user, exists := c.Get("user")
@@ -36,6 +36,8 @@ func NewRouter(services *service.Services, cfg *config.Config, log *logger.Logge
 	shoppingListHandler := handlers.NewShoppingListHandler(services.Meal, log)
 	recommendationsHandler := handlers.NewRecommendationsHandler(services.Dish, services.Meal, services.User, log)
 	nutritionHandler := handlers.NewNutritionHandler(services.Meal, services.User, log)
+	bodyMetricsHandler := handlers.NewBodyMetricsHandler(services.Body, log)
+	adminHandler := handlers.NewAdminHandler(services.DishValidation, services.Dish, log)
 
 	// Public routes
 	api := router.Group("/api")
@@ -63,14 +65,25 @@ func NewRouter(services *service.Services, cfg *config.Config, log *logger.Logge
 		dishes.Use(middleware.OptionalAuthMiddleware(services.Auth))
 		{
 			dishes.GET("", dishHandler.GetDishes)
-			dishes.POST("", dishHandler.CreateDish)      // Add new dish
 			dishes.GET("/search", dishHandler.GetDishes) // Alias for search functionality
+			dishes.GET("/autocomplete", dishHandler.Autocomplete)
+			dishes.GET("/compare", dishHandler.CompareDishes)
 			dishes.GET("/:id", dishHandler.GetDish)
+			dishes.GET("/:id/cook-mode", dishHandler.GetCookMode)
+			dishes.GET("/:id/scaled", dishHandler.ScaleDish)
+			dishes.GET("/:id/substitutions", dishHandler.GetSubstitutions)
+			dishes.GET("/:id/diet-rules", dishHandler.GetDietRules)
+			dishes.GET("/:id/similar", dishHandler.GetSimilar)
 
 			// Protected dish routes
 			protected := dishes.Group("")
 			protected.Use(middleware.AuthMiddleware(services.Auth))
 			{
+				protected.POST("", dishHandler.CreateDish)
+				protected.PUT("/:id", dishHandler.UpdateDish)
+				protected.PATCH("/:id", dishHandler.PatchDish)
+				protected.DELETE("/:id", dishHandler.DeleteDish) // ?policy=restrict|archive|cascade
+				protected.POST("/:id/submit", dishHandler.SubmitDish)
 				protected.GET("/favorites", dishHandler.GetFavorites)
 				protected.POST("/:id/favorite", dishHandler.AddToFavorites)
 				protected.DELETE("/:id/favorite", dishHandler.RemoveFromFavorites)
@@ -88,6 +101,10 @@ func NewRouter(services *service.Services, cfg *config.Config, log *logger.Logge
 			user.GET("/profile", userHandler.GetProfile)
 			user.PUT("/profile", userHandler.UpdateProfile)
 			user.DELETE("/account", userHandler.DeleteAccount)
+			user.POST("/household", userHandler.CreateHousehold)
+			user.POST("/household/invites", userHandler.CreateHouseholdInvite)
+			user.PUT("/household", userHandler.JoinHousehold)
+			user.DELETE("/household", userHandler.LeaveHousehold)
 		}
 
 		// Meals routes
@@ -129,6 +146,30 @@ func NewRouter(services *service.Services, cfg *config.Config, log *logger.Logge
 			nutrition.GET("/progress", nutritionHandler.GetNutritionProgress)
 			nutrition.GET("/goals", nutritionHandler.GetNutritionGoals)
 			nutrition.PUT("/goals", nutritionHandler.UpdateNutritionGoals)
+			nutrition.GET("/goals/suggested", nutritionHandler.GetSuggestedGoals)
+			nutrition.POST("/goals/accept", nutritionHandler.AcceptSuggestedGoals)
+			nutrition.GET("/goals/history", nutritionHandler.GetGoalHistory)
+			nutrition.GET("/deficiencies", nutritionHandler.GetDeficiencyReport)
+		}
+
+		// Body metrics routes
+		bodyMetrics := protected.Group("/body-metrics")
+		{
+			bodyMetrics.POST("", bodyMetricsHandler.CreateMeasurement)
+			bodyMetrics.GET("", bodyMetricsHandler.GetMeasurements)
+			bodyMetrics.GET("/trend", bodyMetricsHandler.GetTrend)
+			bodyMetrics.GET("/:id", bodyMetricsHandler.GetMeasurement)
+			bodyMetrics.PUT("/:id", bodyMetricsHandler.UpdateMeasurement)
+			bodyMetrics.DELETE("/:id", bodyMetricsHandler.DeleteMeasurement)
+		}
+
+		// Admin routes
+		admin := protected.Group("/admin")
+		admin.Use(middleware.AdminMiddleware())
+		{
+			admin.GET("/dishes/quality-report", adminHandler.GetDishQualityReport)
+			admin.GET("/dishes/reviews", adminHandler.GetDishReviews)
+			admin.POST("/dishes/:id/review", adminHandler.ReviewDish)
 		}
 	}
 
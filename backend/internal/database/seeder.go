@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/pkg/logger"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -27,7 +28,10 @@ func SeedDefaultDishes(db *mongo.Database, log *logger.Logger) error {
 
 	if count > 0 {
 		log.Info("Database already contains dishes, skipping seeding", "count", count)
-		return nil
+		if err := backfillRecipeSteps(ctx, collection, log); err != nil {
+			return err
+		}
+		return backfillIngredientFacts(ctx, collection, log)
 	}
 
 	// Default dishes data
@@ -35,7 +39,9 @@ func SeedDefaultDishes(db *mongo.Database, log *logger.Logger) error {
 
 	// Insert dishes
 	var docs []interface{}
+	now := time.Now()
 	for _, dish := range defaultDishes {
+		nutrition.ApplyIngredientFacts(nutrition.DefaultCatalog(), &dish, now)
 		docs = append(docs, dish)
 	}
 
@@ -48,6 +54,74 @@ func SeedDefaultDishes(db *mongo.Database, log *logger.Logger) error {
 	return nil
 }
 
+// backfillRecipeSteps adds the default recipe steps to seeded dishes saved before dishes had steps
+func backfillRecipeSteps(ctx context.Context, collection *mongo.Collection, log *logger.Logger) error {
+	var updated int64
+	for _, dish := range getDefaultDishes() {
+		if len(dish.Steps) == 0 {
+			continue
+		}
+
+		filter := bson.M{
+			"name":      dish.Name,
+			"createdBy": bson.M{"$exists": false},
+			"steps":     bson.M{"$exists": false},
+		}
+		result, err := collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"steps": dish.Steps}})
+		if err != nil {
+			return err
+		}
+		updated += result.ModifiedCount
+	}
+
+	if updated > 0 {
+		log.Info("Added recipe steps to seeded dishes", "count", updated)
+	}
+	return nil
+}
+
+// backfillIngredientFacts derives allergens, diet rules and glycemic load for dishes saved
+// before they were tracked. Each dish is marked once its facts are derived, so dishes without
+// a computable glycemic load are not revisited on every start.
+func backfillIngredientFacts(ctx context.Context, collection *mongo.Collection, log *logger.Logger) error {
+	cursor, err := collection.Find(ctx, bson.M{"factsDerivedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	now := time.Now()
+	var updated int
+	for cursor.Next(ctx) {
+		var dish models.Dish
+		if err := cursor.Decode(&dish); err != nil {
+			return err
+		}
+		nutrition.ApplyIngredientFacts(nutrition.DefaultCatalog(), &dish, now)
+		set := bson.M{
+			"allergens":      dish.Allergens,
+			"dietRules":      dish.DietRules,
+			"factsDerivedAt": now,
+		}
+		if dish.GlycemicLoad != nil {
+			set["glycemicLoad"] = *dish.GlycemicLoad
+		}
+		update := bson.M{"$set": set}
+		if _, err := collection.UpdateByID(ctx, dish.ID, update); err != nil {
+			return err
+		}
+		updated++
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		log.Info("Derived ingredient facts for existing dishes", "count", updated)
+	}
+	return nil
+}
+
 // getDefaultDishes returns a slice of default dishes
 func getDefaultDishes() []models.Dish {
 	dishesJSON := `[
@@ -64,7 +138,8 @@ func getDefaultDishes() []models.Dish {
 				"fat": 35,
 				"fiber": 2,
 				"sugar": 6,
-				"sodium": 875
+				"sodium": 875,
+				"micronutrients": {"iron": 1.5, "calcium": 60, "vitaminB12": 0.4, "vitaminD": 0.2, "folate": 20, "potassium": 400, "saturatedFat": 18}
 			},
 			"dietaryTags": ["high-protein"],
 			"spiceLevel": "medium",
@@ -72,7 +147,14 @@ func getDefaultDishes() []models.Dish {
 			"cookTime": 30,
 			"servings": 4,
 			"difficulty": "medium",
-			"description": "Rich and creamy North Indian curry with tender chicken in a spiced tomato-butter sauce."
+			"description": "Rich and creamy North Indian curry with tender chicken in a spiced tomato-butter sauce.",
+			"steps": [
+				{"instruction": "Marinate the chicken in yogurt, ginger-garlic paste, chilli powder and salt.", "kind": "rest", "duration": 30, "equipment": ["mixing bowl"], "notes": "Marinating overnight in the fridge gives more tender chicken."},
+				{"instruction": "Sear the chicken in butter until charred at the edges, then set aside.", "kind": "cook", "duration": 8, "heat": "high", "equipment": ["kadai"]},
+				{"instruction": "Cook the tomatoes, ginger and garlic until soft, then blend to a smooth sauce.", "kind": "cook", "duration": 12, "heat": "medium", "equipment": ["kadai", "blender"]},
+				{"instruction": "Simmer the sauce with butter and garam masala, then add the chicken.", "kind": "cook", "duration": 10, "heat": "low"},
+				{"instruction": "Stir in the cream and serve hot with naan.", "kind": "serve", "duration": 2, "heat": "low"}
+			]
 		},
 		{
 			"name": "Dal Tadka",
@@ -87,7 +169,8 @@ func getDefaultDishes() []models.Dish {
 				"fat": 6,
 				"fiber": 8,
 				"sugar": 4,
-				"sodium": 450
+				"sodium": 450,
+				"micronutrients": {"iron": 3.3, "calcium": 40, "vitaminB12": 0, "vitaminD": 0, "folate": 180, "potassium": 480, "saturatedFat": 2}
 			},
 			"dietaryTags": ["vegetarian", "vegan", "high-protein", "gluten-free"],
 			"spiceLevel": "medium",
@@ -95,7 +178,14 @@ func getDefaultDishes() []models.Dish {
 			"cookTime": 25,
 			"servings": 4,
 			"difficulty": "easy",
-			"description": "Comfort food at its best - yellow lentils cooked with aromatic spices and tempered with cumin."
+			"description": "Comfort food at its best - yellow lentils cooked with aromatic spices and tempered with cumin.",
+			"steps": [
+				{"instruction": "Rinse the lentils until the water runs clear.", "kind": "prep", "duration": 5, "equipment": ["strainer"]},
+				{"instruction": "Pressure cook the lentils with turmeric, salt and three cups of water.", "kind": "cook", "heat": "medium", "whistles": 3, "equipment": ["pressure cooker"]},
+				{"instruction": "Let the pressure release on its own, then whisk the lentils smooth.", "kind": "rest", "duration": 10, "notes": "Opening the cooker early makes the dal spit."},
+				{"instruction": "Saute the onion, tomato, ginger, garlic and green chilies, then add the lentils and simmer.", "kind": "cook", "duration": 8, "heat": "medium", "equipment": ["kadai"]},
+				{"instruction": "Heat ghee, crackle the cumin seeds and pour the tadka over the dal.", "kind": "tadka", "duration": 2, "heat": "high", "equipment": ["tadka pan"], "notes": "Add the tadka just before serving so it stays fragrant."}
+			]
 		},
 		{
 			"name": "Masala Dosa",
@@ -110,7 +200,8 @@ func getDefaultDishes() []models.Dish {
 				"fat": 8,
 				"fiber": 4,
 				"sugar": 3,
-				"sodium": 620
+				"sodium": 620,
+				"micronutrients": {"iron": 2, "calcium": 45, "vitaminB12": 0.1, "vitaminD": 0, "folate": 40, "potassium": 520, "saturatedFat": 2}
 			},
 			"dietaryTags": ["vegetarian", "vegan", "gluten-free"],
 			"spiceLevel": "mild",
@@ -118,7 +209,15 @@ func getDefaultDishes() []models.Dish {
 			"cookTime": 20,
 			"servings": 2,
 			"difficulty": "hard",
-			"description": "Crispy South Indian crepe made from fermented rice and lentil batter, filled with spiced potatoes."
+			"description": "Crispy South Indian crepe made from fermented rice and lentil batter, filled with spiced potatoes.",
+			"steps": [
+				{"instruction": "Soak the rice and urad dal separately.", "kind": "rest", "duration": 240, "equipment": ["mixing bowl"]},
+				{"instruction": "Grind into a smooth batter and mix in salt.", "kind": "prep", "duration": 15, "equipment": ["wet grinder"]},
+				{"instruction": "Leave the batter to ferment in a warm place until doubled.", "kind": "rest", "duration": 480, "notes": "In cold weather keep the batter in a switched-off oven with the light on."},
+				{"instruction": "Pressure cook the potatoes, then peel and mash roughly.", "kind": "cook", "heat": "medium", "whistles": 2, "equipment": ["pressure cooker"]},
+				{"instruction": "Crackle the mustard seeds and curry leaves in oil, add the onion and turmeric, then fold in the potato.", "kind": "tadka", "duration": 5, "heat": "medium", "equipment": ["kadai"]},
+				{"instruction": "Spread a ladle of batter thinly on the tawa, drizzle oil, fill with the potato masala and fold.", "kind": "cook", "duration": 3, "heat": "high", "equipment": ["tawa", "ladle"], "notes": "Sprinkle water on the tawa; it should sizzle before each dosa."}
+			]
 		},
 		{
 			"name": "Biryani",
@@ -133,7 +232,8 @@ func getDefaultDishes() []models.Dish {
 				"fat": 18,
 				"fiber": 3,
 				"sugar": 5,
-				"sodium": 780
+				"sodium": 780,
+				"micronutrients": {"iron": 2.5, "calcium": 70, "vitaminB12": 0.5, "vitaminD": 0.3, "folate": 35, "potassium": 450, "saturatedFat": 7}
 			},
 			"dietaryTags": ["high-protein"],
 			"spiceLevel": "medium",
@@ -141,7 +241,15 @@ func getDefaultDishes() []models.Dish {
 			"cookTime": 45,
 			"servings": 6,
 			"difficulty": "hard",
-			"description": "Fragrant and flavorful rice dish layered with marinated meat and aromatic spices."
+			"description": "Fragrant and flavorful rice dish layered with marinated meat and aromatic spices.",
+			"steps": [
+				{"instruction": "Marinate the chicken in yogurt, mint and half the whole spices.", "kind": "rest", "duration": 60, "equipment": ["mixing bowl"]},
+				{"instruction": "Soak the basmati rice.", "kind": "rest", "duration": 30, "notes": "Start soaking while the chicken marinates."},
+				{"instruction": "Parboil the rice with the remaining whole spices until about 70% cooked, then drain.", "kind": "cook", "duration": 8, "heat": "high", "equipment": ["large pot", "strainer"]},
+				{"instruction": "Cook the marinated chicken in ghee until the oil separates.", "kind": "cook", "duration": 15, "heat": "medium", "equipment": ["heavy-bottomed pot"]},
+				{"instruction": "Layer the rice over the chicken with fried onions, mint and saffron milk, seal the lid and cook on dum.", "kind": "cook", "duration": 25, "heat": "low", "equipment": ["heavy-bottomed pot", "tawa"], "notes": "Set the pot on a tawa so the bottom layer does not burn."},
+				{"instruction": "Rest before opening, then mix gently from the sides.", "kind": "rest", "duration": 5}
+			]
 		},
 		{
 			"name": "Palak Paneer",
@@ -156,7 +264,8 @@ func getDefaultDishes() []models.Dish {
 				"fat": 22,
 				"fiber": 4,
 				"sugar": 8,
-				"sodium": 520
+				"sodium": 520,
+				"micronutrients": {"iron": 3.5, "calcium": 380, "vitaminB12": 0.5, "vitaminD": 0.2, "folate": 150, "potassium": 600, "saturatedFat": 10}
 			},
 			"dietaryTags": ["vegetarian", "high-protein", "gluten-free"],
 			"spiceLevel": "mild",
@@ -164,7 +273,14 @@ func getDefaultDishes() []models.Dish {
 			"cookTime": 25,
 			"servings": 4,
 			"difficulty": "medium",
-			"description": "Creamy spinach curry with chunks of soft paneer cheese in aromatic spices."
+			"description": "Creamy spinach curry with chunks of soft paneer cheese in aromatic spices.",
+			"steps": [
+				{"instruction": "Blanch the spinach, then plunge it into ice water.", "kind": "cook", "duration": 3, "heat": "high", "equipment": ["pot"], "notes": "The ice water keeps the colour bright green."},
+				{"instruction": "Blend the spinach to a smooth puree.", "kind": "prep", "duration": 3, "equipment": ["blender"]},
+				{"instruction": "Saute the onion, ginger, garlic and tomato until soft.", "kind": "cook", "duration": 10, "heat": "medium", "equipment": ["kadai"]},
+				{"instruction": "Add the puree and garam masala, simmer, then add the paneer.", "kind": "cook", "duration": 8, "heat": "low"},
+				{"instruction": "Finish with cream and serve.", "kind": "serve", "duration": 1}
+			]
 		}
 	]`
 
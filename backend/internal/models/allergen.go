@@ -0,0 +1,36 @@
+package models
+
+// Allergens tracked for dishes and user profiles
+const (
+	AllergenPeanut    = "peanut"
+	AllergenTreeNuts  = "tree-nuts"
+	AllergenDairy     = "dairy"
+	AllergenGluten    = "gluten"
+	AllergenSesame    = "sesame"
+	AllergenShellfish = "shellfish"
+	AllergenSoy       = "soy"
+	AllergenEgg       = "egg"
+	AllergenMustard   = "mustard"
+)
+
+// GetAllergens returns the list of valid allergens
+func GetAllergens() []string {
+	return []string{
+		AllergenPeanut, AllergenTreeNuts, AllergenDairy, AllergenGluten, AllergenSesame,
+		AllergenShellfish, AllergenSoy, AllergenEgg, AllergenMustard,
+	}
+}
+
+// AllergensFor returns the dish's allergens that appear in a user's allergies
+func (d *Dish) AllergensFor(allergies []string) []string {
+	var flagged []string
+	for _, allergen := range d.Allergens {
+		for _, allergy := range allergies {
+			if allergen == allergy {
+				flagged = append(flagged, allergen)
+				break
+			}
+		}
+	}
+	return flagged
+}
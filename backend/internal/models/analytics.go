@@ -10,8 +10,17 @@ type AnalyticsResponse struct {
 	CuisineDistribution  map[string]int     `json:"cuisineDistribution"`
 	TopDishes            []DishPopularity   `json:"topDishes"`
 	NutritionSummary     NutritionAnalytics `json:"nutritionSummary"`
-	WeeklyTrend          []DailyMealCount   `json:"weeklyTrend"`
-	Period               int                `json:"period"`
+	WeeklyTrend          []DailyMealCount   `json:"weeklyTrend"` // one entry per day of Range, in order
+	Period               int                `json:"period"`      // number of days in Range
+	DaysLogged           int                `json:"daysLogged"`  // days with at least one meal
+	Range                DateRange          `json:"range"`
+
+	// Optional server-side aggregates of WeeklyTrend
+	TrendRollups        []MealCountRollup        `json:"trendRollups,omitempty"`
+	TrendMovingAverages []MealCountMovingAverage `json:"trendMovingAverages,omitempty"`
+
+	// Comparison against the previous equivalent period
+	Comparison *AnalyticsComparison `json:"comparison,omitempty"`
 }
 
 // DishPopularity represents a dish with its popularity count
@@ -41,6 +50,7 @@ type DailyMealCount struct {
 	Date      time.Time `json:"date"`
 	MealCount int       `json:"mealCount"`
 	Calories  int       `json:"calories"`
+	HasData   bool      `json:"hasData"` // false for days with no meals logged
 }
 
 // ShoppingListResponse represents a shopping list
@@ -79,22 +89,48 @@ type RecommendedDish struct {
 
 // NutritionProgressResponse represents nutrition progress
 type NutritionProgressResponse struct {
-	Period   int              `json:"period"`
-	Progress []DailyNutrition `json:"progress"`
+	Period   int              `json:"period"` // number of days in Range
+	Range    DateRange        `json:"range"`
+	Progress []DailyNutrition `json:"progress"` // one entry per day of Range, in order
 	Goals    NutritionGoals   `json:"goals"`
 	Summary  NutritionSummary `json:"summary"`
+
+	// Optional server-side aggregates of Progress
+	Rollups        []NutritionRollup        `json:"rollups,omitempty"`
+	MovingAverages []NutritionMovingAverage `json:"movingAverages,omitempty"`
+
+	// Comparison against the previous equivalent period
+	Comparison *NutritionSummaryComparison `json:"comparison,omitempty"`
+
+	// Limits set by the user's health conditions, nil when none are declared
+	ConditionLimits *ConditionLimits `json:"conditionLimits,omitempty"`
 }
 
 // DailyNutrition represents nutrition for a specific day
 type DailyNutrition struct {
-	Date      time.Time `json:"date"`
-	Calories  int       `json:"calories"`
-	Protein   int       `json:"protein"`
-	Carbs     int       `json:"carbs"`
-	Fat       int       `json:"fat"`
-	Fiber     int       `json:"fiber"`
-	Sodium    int       `json:"sodium"`
-	MealCount int       `json:"mealCount"`
+	Date     time.Time `json:"date"`
+	Calories int       `json:"calories"`
+	Protein  int       `json:"protein"`
+	Carbs    int       `json:"carbs"`
+	Fat      int       `json:"fat"`
+	Fiber    int       `json:"fiber"`
+	Sodium   int       `json:"sodium"`
+	Sugar    int       `json:"sugar"`
+
+	Micronutrients map[string]float64 `json:"micronutrients,omitempty"`
+	MealCount      int                `json:"mealCount"`
+	HasData        bool               `json:"hasData"` // false for days with no meals logged
+	Goals          NutritionGoals     `json:"goals"`   // goals in force on this day
+
+	// Glycemic load of the day's meals; meals whose dish has none count as zero
+	GlycemicLoad     float64           `json:"glycemicLoad"`
+	PeakGlycemicLoad *MealGlycemicLoad `json:"peakGlycemicLoad,omitempty"` // the meal with the highest load
+
+	// Per-nutrient evaluation against Goals, nil for days with no meals logged
+	Adherence *DayAdherence `json:"adherence,omitempty"`
+
+	// Limits set by the user's health conditions that the day broke
+	ConditionWarnings []ConditionWarning `json:"conditionWarnings,omitempty"`
 }
 
 // NutritionSummary represents aggregated nutrition data
@@ -104,18 +140,37 @@ type NutritionSummary struct {
 	AvgCarbs       float64 `json:"avgCarbs"`
 	AvgFat         float64 `json:"avgFat"`
 	AvgFiber       float64 `json:"avgFiber"`
+	AvgSodium      float64 `json:"avgSodium"`
+	AvgSugar       float64 `json:"avgSugar"`
 	TotalDays      int     `json:"totalDays"`
 	CalorieGoalMet int     `json:"calorieGoalMet"`
 	ProteinGoalMet int     `json:"proteinGoalMet"`
-	GoalPercentage float64 `json:"goalPercentage"`
+	GoalPercentage float64 `json:"goalPercentage"` // composite adherence score, 0-100
+
+	// Adherence and streaks per nutrient, keyed by nutrient name
+	Nutrients map[string]NutrientAdherence `json:"nutrients,omitempty"`
+
+	// Average micronutrient intake per logged day
+	AvgMicronutrients map[string]float64 `json:"avgMicronutrients,omitempty"`
+
+	// Average glycemic load per logged day
+	AvgGlycemicLoad float64 `json:"avgGlycemicLoad"`
+
+	// Logged days that broke a limit set by the user's health conditions
+	ConditionWarningDays int `json:"conditionWarningDays,omitempty"`
 }
 
-// NutritionGoalsRequest represents the request to update nutrition goals
+// NutritionGoalsRequest represents the request to update nutrition goals.
+// EffectiveFrom (YYYY-MM-DD) backdates the change; it defaults to today.
 type NutritionGoalsRequest struct {
-	DailyCalories int `json:"dailyCalories" validate:"min=0"`
-	Protein       int `json:"protein" validate:"min=0"`
-	Carbs         int `json:"carbs" validate:"min=0"`
-	Fat           int `json:"fat" validate:"min=0"`
-	Fiber         int `json:"fiber" validate:"min=0"`
-	Sodium        int `json:"sodium" validate:"min=0"`
+	DailyCalories int    `json:"dailyCalories" validate:"min=0"`
+	Protein       int    `json:"protein" validate:"min=0"`
+	Carbs         int    `json:"carbs" validate:"min=0"`
+	Fat           int    `json:"fat" validate:"min=0"`
+	Fiber         int    `json:"fiber" validate:"min=0"`
+	Sodium        int    `json:"sodium" validate:"min=0"`
+	Sugar         int    `json:"sugar" validate:"min=0"`
+	EffectiveFrom string `json:"effectiveFrom,omitempty"`
+
+	Semantics GoalSemantics `json:"semantics"`
 }
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BodyMeasurement is a dated weight, waist or body-fat entry
+type BodyMeasurement struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID `bson:"userId" json:"userId"`
+	Date           time.Time          `bson:"date" json:"date"`
+	WeightKg       *float64           `bson:"weightKg,omitempty" json:"weightKg,omitempty"`
+	WaistCm        *float64           `bson:"waistCm,omitempty" json:"waistCm,omitempty"`
+	BodyFatPercent *float64           `bson:"bodyFatPercent,omitempty" json:"bodyFatPercent,omitempty"`
+	Notes          string             `bson:"notes" json:"notes"`
+
+	// Metadata
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// BodyMeasurementRequest represents the request for creating/updating a body measurement.
+// At least one of weight, waist or body fat is required.
+type BodyMeasurementRequest struct {
+	Date           FlexibleDate `json:"date" validate:"required"`
+	WeightKg       *float64     `json:"weightKg" validate:"required_without_all=WaistCm BodyFatPercent,omitempty,min=25,max=300"`
+	WaistCm        *float64     `json:"waistCm" validate:"omitempty,min=30,max=250"`
+	BodyFatPercent *float64     `json:"bodyFatPercent" validate:"omitempty,min=2,max=75"`
+	Notes          string       `json:"notes" validate:"max=500"`
+}
+
+// WeightTrendPoint is a logged weight alongside its smoothed trend value
+type WeightTrendPoint struct {
+	Date     time.Time `json:"date"`
+	WeightKg float64   `json:"weightKg"`
+	TrendKg  float64   `json:"trendKg"`
+}
+
+// Energy balance outcomes of a weight goal
+const (
+	TrendLosing      = "losing"
+	TrendMaintaining = "maintaining"
+	TrendGaining     = "gaining"
+)
+
+// EnergyBalanceEstimate correlates the weight trend with logged intake to estimate real-world TDEE
+type EnergyBalanceEstimate struct {
+	DaysLogged        int      `json:"daysLogged"`        // days with logged meals in the range
+	AvgCalories       float64  `json:"avgCalories"`       // average intake on logged days
+	TrendChangeKg     float64  `json:"trendChangeKg"`     // change in trend weight over the range
+	WeeklyRateKg      float64  `json:"weeklyRateKg"`      // trend change per week
+	EstimatedTDEE     *int     `json:"estimatedTdee"`     // nil when there is not enough data
+	CalorieGoal       int      `json:"calorieGoal"`       // the user's current calorie goal
+	IntendedDirection string   `json:"intendedDirection"` // losing, maintaining or gaining
+	ActualDirection   string   `json:"actualDirection"`
+	OnTrack           *bool    `json:"onTrack"`           // nil when there is not enough data
+	SuggestedCalories *int     `json:"suggestedCalories"` // goal adjusted to the estimated TDEE
+	Notes             []string `json:"notes,omitempty"`
+}
+
+// BodyTrendResponse represents the weight trend and energy balance for a date range
+type BodyTrendResponse struct {
+	Range         DateRange             `json:"range"`
+	Measurements  []*BodyMeasurement    `json:"measurements"`
+	WeightTrend   []WeightTrendPoint    `json:"weightTrend"`
+	EnergyBalance EnergyBalanceEstimate `json:"energyBalance"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyMeasurementRequestValidation(t *testing.T) {
+	weight := 72.5
+	waist := 84.0
+	tooLight := 10.0
+	date := FlexibleDate{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name    string
+		req     BodyMeasurementRequest
+		wantErr bool
+	}{
+		{"weight only", BodyMeasurementRequest{Date: date, WeightKg: &weight}, false},
+		{"waist only", BodyMeasurementRequest{Date: date, WaistCm: &waist}, false},
+		{"no values", BodyMeasurementRequest{Date: date}, true},
+		{"weight out of range", BodyMeasurementRequest{Date: date, WeightKg: &tooLight}, true},
+	}
+
+	validate := validator.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
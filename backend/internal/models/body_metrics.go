@@ -0,0 +1,56 @@
+package models
+
+// Supported biological sex values for body metrics
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+)
+
+// Supported activity levels
+const (
+	ActivitySedentary  = "sedentary"   // little or no exercise
+	ActivityLight      = "light"       // light exercise 1-3 days a week
+	ActivityModerate   = "moderate"    // moderate exercise 3-5 days a week
+	ActivityActive     = "active"      // hard exercise 6-7 days a week
+	ActivityVeryActive = "very_active" // physical job or training twice a day
+)
+
+// Supported weight goals
+const (
+	WeightGoalLose     = "lose"
+	WeightGoalMaintain = "maintain"
+	WeightGoalGain     = "gain"
+)
+
+// Where a profile's nutrition goals came from
+const (
+	GoalsSourceDefault    = "default"    // built-in defaults, no body metrics yet
+	GoalsSourceCalculated = "calculated" // accepted from the calculator, kept in sync with body metrics
+	GoalsSourceCustom     = "custom"     // set manually by the user
+)
+
+// BodyMetrics holds the inputs used to calculate personalized nutrition goals
+type BodyMetrics struct {
+	Age           int     `bson:"age" json:"age" validate:"required,min=18,max=100"`
+	Sex           string  `bson:"sex" json:"sex" validate:"required,oneof=male female"`
+	HeightCm      float64 `bson:"heightCm" json:"heightCm" validate:"required,min=100,max=250"`
+	WeightKg      float64 `bson:"weightKg" json:"weightKg" validate:"required,min=25,max=300"`
+	ActivityLevel string  `bson:"activityLevel" json:"activityLevel" validate:"required,oneof=sedentary light moderate active very_active"`
+	Goal          string  `bson:"goal" json:"goal" validate:"required,oneof=lose maintain gain"`
+}
+
+// GoalSuggestion is the result of the goal calculator for a set of body metrics
+type GoalSuggestion struct {
+	BodyMetrics BodyMetrics    `json:"bodyMetrics"`
+	BMR         int            `json:"bmr"`  // basal metabolic rate, kcal/day
+	TDEE        int            `json:"tdee"` // total daily energy expenditure, kcal/day
+	Goals       NutritionGoals `json:"goals"`
+	Method      string         `json:"method"`
+}
+
+// GoalsResponse returns the active goals together with the calculator's suggestion, if any
+type GoalsResponse struct {
+	Goals      NutritionGoals  `json:"goals"`
+	Source     string          `json:"source"`
+	Suggestion *GoalSuggestion `json:"suggestion,omitempty"`
+}
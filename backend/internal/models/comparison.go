@@ -0,0 +1,120 @@
+package models
+
+// MetricDelta compares a metric between the current and the previous period
+type MetricDelta struct {
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	PercentChange *float64 `json:"percentChange"` // nil when the previous value is zero
+}
+
+// AnalyticsComparison holds period-over-period deltas for every AnalyticsResponse metric
+type AnalyticsComparison struct {
+	PreviousRange        DateRange                    `json:"previousRange"`
+	TotalMeals           MetricDelta                  `json:"totalMeals"`
+	DaysLogged           MetricDelta                  `json:"daysLogged"`
+	AvgCaloriesPerDay    MetricDelta                  `json:"avgCaloriesPerDay"`
+	MealTypeDistribution map[string]MetricDelta       `json:"mealTypeDistribution"`
+	CuisineDistribution  map[string]MetricDelta       `json:"cuisineDistribution"`
+	NutritionSummary     NutritionAnalyticsComparison `json:"nutritionSummary"`
+}
+
+// NutritionAnalyticsComparison holds deltas for every NutritionAnalytics metric
+type NutritionAnalyticsComparison struct {
+	TotalCalories MetricDelta `json:"totalCalories"`
+	AvgCalories   MetricDelta `json:"avgCalories"`
+	TotalProtein  MetricDelta `json:"totalProtein"`
+	TotalCarbs    MetricDelta `json:"totalCarbs"`
+	TotalFat      MetricDelta `json:"totalFat"`
+	TotalFiber    MetricDelta `json:"totalFiber"`
+	AvgProtein    MetricDelta `json:"avgProtein"`
+	AvgCarbs      MetricDelta `json:"avgCarbs"`
+	AvgFat        MetricDelta `json:"avgFat"`
+}
+
+// NutritionSummaryComparison holds deltas for every NutritionSummary metric
+type NutritionSummaryComparison struct {
+	PreviousRange  DateRange   `json:"previousRange"`
+	AvgCalories    MetricDelta `json:"avgCalories"`
+	AvgProtein     MetricDelta `json:"avgProtein"`
+	AvgCarbs       MetricDelta `json:"avgCarbs"`
+	AvgFat         MetricDelta `json:"avgFat"`
+	AvgFiber       MetricDelta `json:"avgFiber"`
+	AvgSodium      MetricDelta `json:"avgSodium"`
+	AvgSugar       MetricDelta `json:"avgSugar"`
+	TotalDays      MetricDelta `json:"totalDays"`
+	CalorieGoalMet MetricDelta `json:"calorieGoalMet"`
+	ProteinGoalMet MetricDelta `json:"proteinGoalMet"`
+	GoalPercentage MetricDelta `json:"goalPercentage"`
+}
+
+// NewMetricDelta builds a MetricDelta from the current and previous values
+func NewMetricDelta(current, previous float64) MetricDelta {
+	delta := MetricDelta{
+		Current:  current,
+		Previous: previous,
+		Change:   current - previous,
+	}
+	if previous != 0 {
+		percent := (current - previous) / previous * 100
+		delta.PercentChange = &percent
+	}
+	return delta
+}
+
+// CompareAnalytics builds the comparison block between two analytics responses
+func CompareAnalytics(current, previous *AnalyticsResponse) *AnalyticsComparison {
+	cur, prev := current.NutritionSummary, previous.NutritionSummary
+
+	return &AnalyticsComparison{
+		PreviousRange:        previous.Range,
+		TotalMeals:           NewMetricDelta(float64(current.TotalMeals), float64(previous.TotalMeals)),
+		DaysLogged:           NewMetricDelta(float64(current.DaysLogged), float64(previous.DaysLogged)),
+		AvgCaloriesPerDay:    NewMetricDelta(current.AvgCaloriesPerDay, previous.AvgCaloriesPerDay),
+		MealTypeDistribution: compareDistributions(current.MealTypeDistribution, previous.MealTypeDistribution),
+		CuisineDistribution:  compareDistributions(current.CuisineDistribution, previous.CuisineDistribution),
+		NutritionSummary: NutritionAnalyticsComparison{
+			TotalCalories: NewMetricDelta(float64(cur.TotalCalories), float64(prev.TotalCalories)),
+			AvgCalories:   NewMetricDelta(cur.AvgCalories, prev.AvgCalories),
+			TotalProtein:  NewMetricDelta(float64(cur.TotalProtein), float64(prev.TotalProtein)),
+			TotalCarbs:    NewMetricDelta(float64(cur.TotalCarbs), float64(prev.TotalCarbs)),
+			TotalFat:      NewMetricDelta(float64(cur.TotalFat), float64(prev.TotalFat)),
+			TotalFiber:    NewMetricDelta(float64(cur.TotalFiber), float64(prev.TotalFiber)),
+			AvgProtein:    NewMetricDelta(cur.AvgProtein, prev.AvgProtein),
+			AvgCarbs:      NewMetricDelta(cur.AvgCarbs, prev.AvgCarbs),
+			AvgFat:        NewMetricDelta(cur.AvgFat, prev.AvgFat),
+		},
+	}
+}
+
+// CompareNutritionSummary builds the comparison block between two nutrition summaries
+func CompareNutritionSummary(current, previous NutritionSummary, previousRange DateRange) *NutritionSummaryComparison {
+	return &NutritionSummaryComparison{
+		PreviousRange:  previousRange,
+		AvgCalories:    NewMetricDelta(current.AvgCalories, previous.AvgCalories),
+		AvgProtein:     NewMetricDelta(current.AvgProtein, previous.AvgProtein),
+		AvgCarbs:       NewMetricDelta(current.AvgCarbs, previous.AvgCarbs),
+		AvgFat:         NewMetricDelta(current.AvgFat, previous.AvgFat),
+		AvgFiber:       NewMetricDelta(current.AvgFiber, previous.AvgFiber),
+		AvgSodium:      NewMetricDelta(current.AvgSodium, previous.AvgSodium),
+		AvgSugar:       NewMetricDelta(current.AvgSugar, previous.AvgSugar),
+		TotalDays:      NewMetricDelta(float64(current.TotalDays), float64(previous.TotalDays)),
+		CalorieGoalMet: NewMetricDelta(float64(current.CalorieGoalMet), float64(previous.CalorieGoalMet)),
+		ProteinGoalMet: NewMetricDelta(float64(current.ProteinGoalMet), float64(previous.ProteinGoalMet)),
+		GoalPercentage: NewMetricDelta(current.GoalPercentage, previous.GoalPercentage),
+	}
+}
+
+// compareDistributions compares two count distributions over the union of their keys
+func compareDistributions(current, previous map[string]int) map[string]MetricDelta {
+	deltas := make(map[string]MetricDelta)
+	for key, count := range current {
+		deltas[key] = NewMetricDelta(float64(count), float64(previous[key]))
+	}
+	for key, count := range previous {
+		if _, exists := current[key]; !exists {
+			deltas[key] = NewMetricDelta(0, float64(count))
+		}
+	}
+	return deltas
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMetricDelta(t *testing.T) {
+	// Act
+	delta := NewMetricDelta(150, 100)
+
+	// Assert
+	assert.Equal(t, 150.0, delta.Current)
+	assert.Equal(t, 100.0, delta.Previous)
+	assert.Equal(t, 50.0, delta.Change)
+	require.NotNil(t, delta.PercentChange)
+	assert.InDelta(t, 50.0, *delta.PercentChange, 0.001)
+}
+
+func TestNewMetricDelta_ZeroPrevious(t *testing.T) {
+	delta := NewMetricDelta(10, 0)
+
+	assert.Equal(t, 10.0, delta.Change)
+	assert.Nil(t, delta.PercentChange)
+}
+
+func TestCompareAnalytics(t *testing.T) {
+	// Arrange
+	current := &AnalyticsResponse{
+		TotalMeals:           6,
+		DaysLogged:           3,
+		AvgCaloriesPerDay:    1500,
+		MealTypeDistribution: map[string]int{"lunch": 3, "dinner": 3},
+		CuisineDistribution:  map[string]int{"North Indian": 6},
+		NutritionSummary:     NutritionAnalytics{TotalCalories: 4500, TotalProtein: 180},
+	}
+	previous := &AnalyticsResponse{
+		TotalMeals:           4,
+		DaysLogged:           2,
+		AvgCaloriesPerDay:    1200,
+		MealTypeDistribution: map[string]int{"lunch": 2, "breakfast": 2},
+		CuisineDistribution:  map[string]int{"North Indian": 4},
+		NutritionSummary:     NutritionAnalytics{TotalCalories: 2400, TotalProtein: 120},
+	}
+
+	// Act
+	comparison := CompareAnalytics(current, previous)
+
+	// Assert
+	assert.Equal(t, 2.0, comparison.TotalMeals.Change)
+	assert.Equal(t, 1.0, comparison.DaysLogged.Change)
+	assert.Equal(t, 300.0, comparison.AvgCaloriesPerDay.Change)
+	assert.Equal(t, 2100.0, comparison.NutritionSummary.TotalCalories.Change)
+	assert.Equal(t, 60.0, comparison.NutritionSummary.TotalProtein.Change)
+
+	// Keys present in either period are compared
+	assert.Len(t, comparison.MealTypeDistribution, 3)
+	assert.Equal(t, 3.0, comparison.MealTypeDistribution["dinner"].Change)
+	assert.Equal(t, -2.0, comparison.MealTypeDistribution["breakfast"].Change)
+	assert.Equal(t, 1.0, comparison.MealTypeDistribution["lunch"].Change)
+}
+
+func TestCompareNutritionSummary(t *testing.T) {
+	// Arrange
+	current := NutritionSummary{AvgCalories: 1800, TotalDays: 7, CalorieGoalMet: 4, GoalPercentage: 57.1}
+	previous := NutritionSummary{AvgCalories: 2000, TotalDays: 5, CalorieGoalMet: 5, GoalPercentage: 100}
+	previousRange := DateRange{Preset: RangePresetWeek}
+
+	// Act
+	comparison := CompareNutritionSummary(current, previous, previousRange)
+
+	// Assert
+	assert.Equal(t, previousRange, comparison.PreviousRange)
+	assert.Equal(t, -200.0, comparison.AvgCalories.Change)
+	assert.Equal(t, 2.0, comparison.TotalDays.Change)
+	assert.Equal(t, -1.0, comparison.CalorieGoalMet.Change)
+	require.NotNil(t, comparison.GoalPercentage.PercentChange)
+	assert.InDelta(t, -42.9, *comparison.GoalPercentage.PercentChange, 0.001)
+}
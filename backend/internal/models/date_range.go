@@ -0,0 +1,133 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Supported date range presets
+const (
+	RangePresetWeek    = "week"
+	RangePresetMonth   = "month"
+	RangePresetQuarter = "quarter"
+)
+
+// DateRange represents an inclusive range of calendar days
+type DateRange struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Preset string    `json:"preset,omitempty"`
+}
+
+// NewDateRange creates a range covering every calendar day from start to end (inclusive)
+func NewDateRange(start, end time.Time) DateRange {
+	return DateRange{
+		From: startOfDay(start),
+		To:   endOfDay(end),
+	}
+}
+
+// LastNDays returns the range ending today (in now's location) that spans period days
+func LastNDays(period int, now time.Time) DateRange {
+	if period < 1 {
+		period = 1
+	}
+	return NewDateRange(now.AddDate(0, 0, -(period-1)), now)
+}
+
+// PresetRange returns the calendar week, month or quarter containing now, up to and including today.
+// Weeks start on Monday.
+func PresetRange(preset string, now time.Time) (DateRange, error) {
+	var start time.Time
+	switch preset {
+	case RangePresetWeek:
+		offset := (int(now.Weekday()) + 6) % 7
+		start = now.AddDate(0, 0, -offset)
+	case RangePresetMonth:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	case RangePresetQuarter:
+		firstMonth := time.Month((int(now.Month())-1)/3*3 + 1)
+		start = time.Date(now.Year(), firstMonth, 1, 0, 0, 0, 0, now.Location())
+	default:
+		return DateRange{}, fmt.Errorf("invalid range preset '%s', supported presets: week, month, quarter", preset)
+	}
+
+	r := NewDateRange(start, now)
+	r.Preset = preset
+	return r, nil
+}
+
+// ParseDateRange parses YYYY-MM-DD from/to bounds in the given location
+func ParseDateRange(from, to string, loc *time.Location) (DateRange, error) {
+	start, err := time.ParseInLocation("2006-01-02", from, loc)
+	if err != nil {
+		return DateRange{}, errors.New("invalid from date format. Use YYYY-MM-DD")
+	}
+	end, err := time.ParseInLocation("2006-01-02", to, loc)
+	if err != nil {
+		return DateRange{}, errors.New("invalid to date format. Use YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return DateRange{}, errors.New("to date must not be before from date")
+	}
+	return NewDateRange(start, end), nil
+}
+
+// Days returns the number of calendar days covered by the range
+func (r DateRange) Days() int {
+	from := time.Date(r.From.Year(), r.From.Month(), r.From.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(r.To.Year(), r.To.Month(), r.To.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours()/24) + 1
+}
+
+// Previous returns the equivalent range immediately before this one.
+// Presets shift back by one calendar unit so that, for example, the first 18 days
+// of October compare against the first 18 days of September. Custom ranges
+// compare against the same number of days ending the day before From.
+func (r DateRange) Previous() DateRange {
+	var prev DateRange
+	switch r.Preset {
+	case RangePresetWeek:
+		prev = NewDateRange(r.From.AddDate(0, 0, -7), r.To.AddDate(0, 0, -7))
+	case RangePresetMonth:
+		prev = NewDateRange(r.From.AddDate(0, -1, 0), clampToMonth(r.From.AddDate(0, -1, 0), r.Days()))
+	case RangePresetQuarter:
+		prev = NewDateRange(r.From.AddDate(0, -3, 0), clampToQuarter(r.From.AddDate(0, -3, 0), r.Days()))
+	default:
+		end := r.From.AddDate(0, 0, -1)
+		prev = NewDateRange(end.AddDate(0, 0, -(r.Days()-1)), end)
+	}
+	prev.Preset = r.Preset
+	return prev
+}
+
+// clampToMonth returns the date days-1 after start, without running past the end of start's month
+func clampToMonth(start time.Time, days int) time.Time {
+	end := start.AddDate(0, 0, days-1)
+	lastOfMonth := time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, start.Location())
+	if end.After(lastOfMonth) {
+		return lastOfMonth
+	}
+	return end
+}
+
+// clampToQuarter returns the date days-1 after start, without running past the end of start's quarter
+func clampToQuarter(start time.Time, days int) time.Time {
+	end := start.AddDate(0, 0, days-1)
+	lastOfQuarter := time.Date(start.Year(), start.Month()+3, 0, 0, 0, 0, 0, start.Location())
+	if end.After(lastOfQuarter) {
+		return lastOfQuarter
+	}
+	return end
+}
+
+// startOfDay returns midnight at the start of t's calendar day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// endOfDay returns the last nanosecond of t's calendar day
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+}
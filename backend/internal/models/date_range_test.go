@@ -0,0 +1,149 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastNDays(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 3, 10, 15, 4, 5, 0, time.UTC)
+
+	// Act
+	r := LastNDays(7, now)
+
+	// Assert
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), r.From)
+	assert.Equal(t, time.Date(2024, 3, 10, 23, 59, 59, 999999999, time.UTC), r.To)
+	assert.Equal(t, 7, r.Days())
+}
+
+func TestPresetRange(t *testing.T) {
+	// Thursday, 15 August 2024
+	now := time.Date(2024, 8, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		preset   string
+		from     time.Time
+		days     int
+		prevFrom time.Time
+		prevTo   time.Time
+	}{
+		{
+			name:     "week starts on Monday",
+			preset:   RangePresetWeek,
+			from:     time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC),
+			days:     4,
+			prevFrom: time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC),
+			prevTo:   time.Date(2024, 8, 8, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "month to date",
+			preset:   RangePresetMonth,
+			from:     time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+			days:     15,
+			prevFrom: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			prevTo:   time.Date(2024, 7, 15, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "quarter to date",
+			preset:   RangePresetQuarter,
+			from:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			days:     46,
+			prevFrom: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			prevTo:   time.Date(2024, 5, 16, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := PresetRange(tt.preset, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.from, r.From)
+			assert.Equal(t, tt.days, r.Days())
+			assert.Equal(t, tt.preset, r.Preset)
+
+			prev := r.Previous()
+			assert.Equal(t, tt.prevFrom, prev.From)
+			assert.Equal(t, tt.prevTo, prev.To)
+		})
+	}
+}
+
+func TestPresetRange_Invalid(t *testing.T) {
+	_, err := PresetRange("year", time.Now())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid range preset")
+}
+
+func TestDateRange_Previous_ClampsToShorterMonth(t *testing.T) {
+	// Arrange - 31 days of March compare against all of February
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	r, err := PresetRange(RangePresetMonth, now)
+	require.NoError(t, err)
+
+	// Act
+	prev := r.Previous()
+
+	// Assert
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), prev.From)
+	assert.Equal(t, time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.UTC), prev.To)
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		days     int
+		prevFrom time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "single day",
+			from:     "2024-01-10",
+			to:       "2024-01-10",
+			days:     1,
+			prevFrom: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "two weeks",
+			from:     "2024-01-01",
+			to:       "2024-01-14",
+			days:     14,
+			prevFrom: time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "to before from",
+			from:    "2024-01-14",
+			to:      "2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			from:    "01/01/2024",
+			to:      "2024-01-14",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseDateRange(tt.from, tt.to, time.UTC)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.days, r.Days())
+			assert.Equal(t, tt.days, r.Previous().Days())
+			assert.Equal(t, tt.prevFrom, r.Previous().From)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Religious and traditional diet rule sets
+const (
+	DietRuleJain     = "jain"     // no meat, eggs, honey or root vegetables, including onion and garlic
+	DietRuleSatvik   = "satvik"   // no meat, eggs, onion or garlic
+	DietRuleNavratri = "navratri" // vrat ingredients only
+	DietRuleEkadashi = "ekadashi" // no grains or pulses
+	DietRuleHalal    = "halal"    // no pork or alcohol; meat must be halal
+)
+
+// GetDietRules returns the list of valid diet rule sets
+func GetDietRules() []string {
+	return []string{DietRuleJain, DietRuleSatvik, DietRuleNavratri, DietRuleEkadashi, DietRuleHalal}
+}
+
+// DietRuleResult is the outcome of checking a dish's ingredients against one rule set
+type DietRuleResult struct {
+	RuleSet    string   `json:"ruleSet"`
+	Compliant  bool     `json:"compliant"`
+	Violations []string `json:"violations"`         // ingredients the rule set does not allow
+	Cautions   []string `json:"cautions,omitempty"` // allowed ingredients that need checking, e.g. halal meat
+}
+
+// FastingPeriod temporarily adds a rule set to a user's constraints, e.g. during Navratri
+type FastingPeriod struct {
+	RuleSet   string    `bson:"ruleSet" json:"ruleSet" validate:"required,oneof=jain satvik navratri ekadashi halal"`
+	Name      string    `bson:"name,omitempty" json:"name,omitempty" validate:"max=100"`
+	StartDate time.Time `bson:"startDate" json:"startDate" validate:"required"`
+	EndDate   time.Time `bson:"endDate" json:"endDate" validate:"required,gtefield=StartDate"` // inclusive
+}
+
+// Covers reports whether the period includes the date, compared by calendar day
+func (p FastingPeriod) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return day >= p.StartDate.Format("2006-01-02") && day <= p.EndDate.Format("2006-01-02")
+}
+
+// ActiveDietRules returns the profile's standing diet rules plus those of any fasting
+// period that covers the date
+func (p UserProfile) ActiveDietRules(date time.Time) []string {
+	rules := append([]string{}, p.DietRules...)
+	for _, period := range p.FastingPeriods {
+		if !period.Covers(date) {
+			continue
+		}
+		found := false
+		for _, rule := range rules {
+			if rule == period.RuleSet {
+				found = true
+				break
+			}
+		}
+		if !found {
+			rules = append(rules, period.RuleSet)
+		}
+	}
+	return rules
+}
+
+// CompliesWith reports whether the dish's ingredients comply with every one of the rule sets
+func (d *Dish) CompliesWith(ruleSets []string) bool {
+	for _, ruleSet := range ruleSets {
+		if !contains(d.DietRules, ruleSet) {
+			return false
+		}
+	}
+	return true
+}
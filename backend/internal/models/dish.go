@@ -10,17 +10,32 @@ import (
 type Dish struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Name        string             `bson:"name" json:"name" validate:"required"`
+	Aliases     []string           `bson:"aliases,omitempty" json:"aliases,omitempty"` // other names and spellings, used by search
 	Type        string             `bson:"type" json:"type" validate:"required,oneof=Veg Non-Veg"`
 	Cuisine     string             `bson:"cuisine" json:"cuisine" validate:"required"`
 	Image       string             `bson:"image" json:"image"`
 	Ingredients []string           `bson:"ingredients" json:"ingredients" validate:"required,min=1"`
 	Calories    int                `bson:"calories" json:"calories" validate:"min=0"`
 
+	// Structured quantities for the whole recipe, used to calculate nutrition
+	IngredientQuantities []IngredientQuantity `bson:"ingredientQuantities,omitempty" json:"ingredientQuantities,omitempty"`
+
 	// Nutritional information
-	Nutrition Nutrition `bson:"nutrition" json:"nutrition"`
+	Nutrition       Nutrition       `bson:"nutrition" json:"nutrition"`
+	NutritionSource string          `bson:"nutritionSource,omitempty" json:"nutritionSource,omitempty"` // manual or calculated
+	NutritionCheck  *NutritionCheck `bson:"nutritionCheck,omitempty" json:"nutritionCheck,omitempty"`
+	GlycemicLoad    *float64        `bson:"glycemicLoad,omitempty" json:"glycemicLoad,omitempty"` // per serving, from the ingredient quantities
+
+	// Usage from meal logs, refreshed periodically; nil until the dish is logged
+	Stats *DishStats `bson:"stats,omitempty" json:"stats,omitempty"`
+
+	// Validation warnings from the last create or update, not stored
+	Warnings []DishIssue `bson:"-" json:"warnings,omitempty"`
 
 	// Tags and metadata
 	DietaryTags []string `bson:"dietaryTags" json:"dietaryTags"`
+	Allergens   []string `bson:"allergens" json:"allergens"` // derived from the ingredients on save
+	DietRules   []string `bson:"dietRules" json:"dietRules"` // rule sets the ingredients comply with, derived on save
 	SpiceLevel  string   `bson:"spiceLevel" json:"spiceLevel" validate:"oneof=mild medium hot extra-hot"`
 
 	// Cooking information
@@ -32,9 +47,38 @@ type Dish struct {
 	// Additional information
 	Description string `bson:"description" json:"description"`
 
+	// Method, in cooking order
+	Steps []RecipeStep `bson:"steps,omitempty" json:"steps,omitempty" validate:"omitempty,dive"`
+
+	// Sharing and moderation
+	Visibility  string              `bson:"visibility,omitempty" json:"visibility,omitempty"`   // private, household or public; empty for seeded dishes
+	HouseholdID *primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"` // owner's household, for household dishes
+	Review      *DishReview         `bson:"review,omitempty" json:"review,omitempty"`           // submission to the public catalog
+
 	// Metadata
-	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
-	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+	CreatedBy  *primitive.ObjectID `bson:"createdBy,omitempty" json:"createdBy,omitempty"`   // nil for seeded dishes
+	ArchivedAt *time.Time          `bson:"archivedAt,omitempty" json:"archivedAt,omitempty"` // archived dishes are hidden from listings
+	CreatedAt  time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time           `bson:"updatedAt" json:"updatedAt"`
+
+	FactsDerivedAt *time.Time `bson:"factsDerivedAt,omitempty" json:"-"` // when allergens, diet rules and glycemic load were derived
+}
+
+// DishStats summarizes how often a dish is logged and how it is rated
+type DishStats struct {
+	TimesLogged int       `bson:"timesLogged" json:"timesLogged"`
+	RatingCount int       `bson:"ratingCount" json:"ratingCount"`
+	AvgRating   float64   `bson:"avgRating" json:"avgRating"` // 0 without ratings
+	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// CanBeModifiedBy reports whether the user may update or delete the dish:
+// its creator or an admin. Dishes without a creator can only be changed by admins.
+func (d *Dish) CanBeModifiedBy(user *User) bool {
+	if user == nil {
+		return false
+	}
+	return user.IsAdmin() || (d.CreatedBy != nil && *d.CreatedBy == user.ID)
 }
 
 // Nutrition represents nutritional information for a dish
@@ -45,12 +89,80 @@ type Nutrition struct {
 	Fiber   int `bson:"fiber" json:"fiber" validate:"min=0"`     // grams
 	Sugar   int `bson:"sugar" json:"sugar" validate:"min=0"`     // grams
 	Sodium  int `bson:"sodium" json:"sodium" validate:"min=0"`   // milligrams
+
+	// Micronutrients per serving keyed by name, e.g. "iron" (mg) or "vitaminB12" (µg).
+	// See GetMicronutrients for the tracked keys and units.
+	Micronutrients map[string]float64 `bson:"micronutrients,omitempty" json:"micronutrients,omitempty" validate:"omitempty,dive,keys,required,endkeys,min=0"`
+}
+
+// IsZero reports whether no nutrition values have been entered
+func (n Nutrition) IsZero() bool {
+	return n.Protein == 0 && n.Carbs == 0 && n.Fat == 0 && n.Fiber == 0 &&
+		n.Sugar == 0 && n.Sodium == 0 && len(n.Micronutrients) == 0
+}
+
+// Nutrition sources for a dish
+const (
+	NutritionSourceManual     = "manual"     // entered by hand
+	NutritionSourceCalculated = "calculated" // derived from ingredient quantities
+)
+
+// Ingredient quantity units. Volumes are converted to grams using the ingredient's density.
+// Katori and glass are the household bowl and tumbler.
+const (
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMillilitre = "ml"
+	UnitLitre      = "l"
+	UnitTeaspoon   = "tsp"
+	UnitTablespoon = "tbsp"
+	UnitCup        = "cup"
+	UnitPiece      = "piece"
+	UnitPinch      = "pinch"
+	UnitKatori     = "katori"
+	UnitGlass      = "glass"
+)
+
+// IngredientQuantity is the amount of one ingredient used in a recipe
+type IngredientQuantity struct {
+	Name          string  `bson:"name" json:"name" validate:"required"`
+	IngredientKey string  `bson:"ingredientKey,omitempty" json:"ingredientKey,omitempty"` // catalog key, set when the name is recognised
+	Quantity      float64 `bson:"quantity" json:"quantity" validate:"gt=0"`
+	Unit          string  `bson:"unit" json:"unit" validate:"required,oneof=g kg ml l tsp tbsp cup piece pinch katori glass"`
+}
+
+// Nutrition check statuses
+const (
+	NutritionCheckCalculated = "calculated" // nutrition was filled in from the ingredients
+	NutritionCheckConsistent = "consistent" // entered values agree with the ingredients
+	NutritionCheckMismatch   = "mismatch"   // entered values differ from the ingredients
+	NutritionCheckUnverified = "unverified" // no ingredient could be found in the composition table
+)
+
+// NutritionCheck compares a dish's nutrition with the values calculated from its ingredients
+type NutritionCheck struct {
+	Status     string             `bson:"status" json:"status"`
+	Calories   int                `bson:"calories" json:"calories"`   // calculated per serving
+	Nutrition  Nutrition          `bson:"nutrition" json:"nutrition"` // calculated per serving
+	Coverage   float64            `bson:"coverage" json:"coverage"`   // percentage of ingredients found in the composition table
+	Unmatched  []string           `bson:"unmatched,omitempty" json:"unmatched,omitempty"`
+	Mismatches []NutrientMismatch `bson:"mismatches,omitempty" json:"mismatches,omitempty"`
+	CheckedAt  time.Time          `bson:"checkedAt" json:"checkedAt"`
+}
+
+// NutrientMismatch is an entered value that differs from the calculated one beyond tolerance
+type NutrientMismatch struct {
+	Nutrient         string  `bson:"nutrient" json:"nutrient"`
+	Entered          float64 `bson:"entered" json:"entered"`
+	Calculated       float64 `bson:"calculated" json:"calculated"`
+	DeviationPercent float64 `bson:"deviationPercent" json:"deviationPercent"`
 }
 
 // DishResponse represents the dish data returned in API responses with favorites info
 type DishResponse struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
+	Aliases     []string  `json:"aliases,omitempty"`
 	Type        string    `json:"type"`
 	Cuisine     string    `json:"cuisine"`
 	Image       string    `json:"image"`
@@ -58,6 +170,8 @@ type DishResponse struct {
 	Calories    int       `json:"calories"`
 	Nutrition   Nutrition `json:"nutrition"`
 	DietaryTags []string  `json:"dietaryTags"`
+	Allergens   []string  `json:"allergens"`
+	DietRules   []string  `json:"dietRules"`
 	SpiceLevel  string    `json:"spiceLevel"`
 	PrepTime    int       `json:"prepTime"`
 	CookTime    int       `json:"cookTime"`
@@ -65,15 +179,31 @@ type DishResponse struct {
 	Difficulty  string    `json:"difficulty"`
 	Description string    `json:"description"`
 	IsFavorite  bool      `json:"isFavorite,omitempty"`
+
+	IngredientQuantities []IngredientQuantity `json:"ingredientQuantities,omitempty"`
+	NutritionSource      string               `json:"nutritionSource,omitempty"`
+	NutritionCheck       *NutritionCheck      `json:"nutritionCheck,omitempty"`
+	GlycemicLoad         *float64             `json:"glycemicLoad,omitempty"`      // per serving, nil without ingredient quantities
+	GlycemicLoadLevel    string               `json:"glycemicLoadLevel,omitempty"` // low, medium or high
+	Stats                *DishStats           `json:"stats,omitempty"`
+	Warnings             []DishIssue          `json:"warnings,omitempty"`
+	Steps                []RecipeStep         `json:"steps,omitempty"`
+	Equipment            []string             `json:"equipment,omitempty"`
+	CreatedBy            string               `json:"createdBy,omitempty"`
+	Archived             bool                 `json:"archived,omitempty"`
+	Visibility           string               `json:"visibility"`
+	Review               *DishReview          `json:"review,omitempty"`
+	SuggestedSwaps       []DishSwapSuggestion `json:"suggestedSwaps,omitempty"` // swaps to fit the viewer's dietary preferences
 }
 
 // DishCreateRequest represents the request for creating a dish
 type DishCreateRequest struct {
 	Name        string    `json:"name" validate:"required,min=2,max=100"`
+	Aliases     []string  `json:"aliases" validate:"omitempty,max=10,dive,min=2,max=100"`
 	Type        string    `json:"type" validate:"required,oneof=Veg Non-Veg"`
 	Cuisine     string    `json:"cuisine" validate:"required"`
 	Image       string    `json:"image"`
-	Ingredients []string  `json:"ingredients" validate:"required,min=1"`
+	Ingredients []string  `json:"ingredients" validate:"required_without=IngredientQuantities,omitempty,min=1"`
 	Calories    int       `json:"calories" validate:"omitempty,min=0"`
 	Nutrition   Nutrition `json:"nutrition"`
 	DietaryTags []string  `json:"dietaryTags"`
@@ -83,13 +213,180 @@ type DishCreateRequest struct {
 	Servings    int       `json:"servings" validate:"omitempty,min=1"`
 	Difficulty  string    `json:"difficulty" validate:"omitempty,oneof=easy medium hard"`
 	Description string    `json:"description"`
+
+	// Quantities for the whole recipe. Nutrition is calculated from them when
+	// AutoFillNutrition is set or no nutrition is entered, and checked otherwise.
+	IngredientQuantities []IngredientQuantity `json:"ingredientQuantities" validate:"omitempty,dive"`
+	AutoFillNutrition    bool                 `json:"autoFillNutrition"`
+
+	// Who can see the dish. Public dishes by users are submitted for review first.
+	Visibility string `json:"visibility" validate:"omitempty,oneof=private household public"`
+
+	Steps []RecipeStep `json:"steps" validate:"omitempty,dive"`
+}
+
+// ToDish converts the request to a dish, applying defaults. Nutrition is calculated from
+// the ingredient quantities when requested or when no nutrition was entered. The requested
+// visibility is carried on the dish for the service to apply.
+func (r DishCreateRequest) ToDish() *Dish {
+	dish := &Dish{
+		Name:        r.Name,
+		Aliases:     r.Aliases,
+		Type:        r.Type,
+		Cuisine:     r.Cuisine,
+		Image:       r.Image,
+		Ingredients: r.Ingredients,
+		Calories:    r.Calories,
+		Nutrition:   r.Nutrition,
+		DietaryTags: r.DietaryTags,
+		SpiceLevel:  r.SpiceLevel,
+		PrepTime:    r.PrepTime,
+		CookTime:    r.CookTime,
+		Servings:    r.Servings,
+		Difficulty:  r.Difficulty,
+		Description: r.Description,
+		Steps:       r.Steps,
+
+		IngredientQuantities: r.IngredientQuantities,
+		NutritionSource:      NutritionSourceManual,
+		Visibility:           r.Visibility,
+	}
+
+	if len(r.IngredientQuantities) > 0 && (r.AutoFillNutrition || (r.Calories == 0 && r.Nutrition.IsZero())) {
+		dish.NutritionSource = NutritionSourceCalculated
+	}
+
+	// Set defaults if not provided
+	if dish.SpiceLevel == "" {
+		dish.SpiceLevel = "medium"
+	}
+	if dish.Difficulty == "" {
+		dish.Difficulty = "medium"
+	}
+	if dish.Servings == 0 {
+		dish.Servings = 2
+	}
+	return dish
+}
+
+// DishPatchRequest represents a partial dish update; only the fields present are changed
+type DishPatchRequest struct {
+	Name        *string    `json:"name" validate:"omitempty,min=2,max=100"`
+	Aliases     *[]string  `json:"aliases" validate:"omitempty,max=10,dive,min=2,max=100"`
+	Type        *string    `json:"type" validate:"omitempty,oneof=Veg Non-Veg"`
+	Cuisine     *string    `json:"cuisine" validate:"omitempty,min=1"`
+	Image       *string    `json:"image"`
+	Ingredients *[]string  `json:"ingredients" validate:"omitempty,min=1"`
+	Calories    *int       `json:"calories" validate:"omitempty,min=0"`
+	Nutrition   *Nutrition `json:"nutrition"`
+	DietaryTags *[]string  `json:"dietaryTags"`
+	SpiceLevel  *string    `json:"spiceLevel" validate:"omitempty,oneof=mild medium hot extra-hot"`
+	PrepTime    *int       `json:"prepTime" validate:"omitempty,min=0"`
+	CookTime    *int       `json:"cookTime" validate:"omitempty,min=0"`
+	Servings    *int       `json:"servings" validate:"omitempty,min=1"`
+	Difficulty  *string    `json:"difficulty" validate:"omitempty,oneof=easy medium hard"`
+	Description *string    `json:"description"`
+
+	IngredientQuantities *[]IngredientQuantity `json:"ingredientQuantities" validate:"omitempty,dive"`
+	AutoFillNutrition    *bool                 `json:"autoFillNutrition"`
+	Visibility           *string               `json:"visibility" validate:"omitempty,oneof=private household public"`
+	Steps                *[]RecipeStep         `json:"steps" validate:"omitempty,dive"`
+}
+
+// Apply copies the fields present in the patch onto the dish. Entering calories or
+// nutrition switches the dish to manual nutrition unless auto-fill is requested.
+// Visibility is left to the service, which applies it with SetVisibility.
+func (p DishPatchRequest) Apply(d *Dish) {
+	setString := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setInt := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+
+	setString(&d.Name, p.Name)
+	setString(&d.Type, p.Type)
+	setString(&d.Cuisine, p.Cuisine)
+	setString(&d.Image, p.Image)
+	setString(&d.SpiceLevel, p.SpiceLevel)
+	setString(&d.Difficulty, p.Difficulty)
+	setString(&d.Description, p.Description)
+	setInt(&d.Calories, p.Calories)
+	setInt(&d.PrepTime, p.PrepTime)
+	setInt(&d.CookTime, p.CookTime)
+	setInt(&d.Servings, p.Servings)
+	if p.Aliases != nil {
+		d.Aliases = *p.Aliases
+	}
+	if p.Ingredients != nil {
+		d.Ingredients = *p.Ingredients
+	}
+	if p.DietaryTags != nil {
+		d.DietaryTags = *p.DietaryTags
+	}
+	if p.Nutrition != nil {
+		d.Nutrition = *p.Nutrition
+	}
+	if p.IngredientQuantities != nil {
+		d.IngredientQuantities = *p.IngredientQuantities
+	}
+	if p.Steps != nil {
+		d.Steps = *p.Steps
+	}
+
+	switch {
+	case p.AutoFillNutrition != nil && *p.AutoFillNutrition:
+		d.NutritionSource = NutritionSourceCalculated
+	case p.AutoFillNutrition != nil || p.Calories != nil || p.Nutrition != nil:
+		d.NutritionSource = NutritionSourceManual
+	}
+}
+
+// Dish delete policies for dishes that meals, meal plans or favorites refer to
+const (
+	DeletePolicyRestrict = "restrict" // refuse to delete a referenced dish
+	DeletePolicyArchive  = "archive"  // hide the dish from listings but keep it for existing references
+	DeletePolicyCascade  = "cascade"  // delete the meals and remove the dish from plans and favorites (admins only)
+)
+
+// DishReferences counts the records that refer to a dish
+type DishReferences struct {
+	Meals     int64 `json:"meals"`
+	MealPlans int64 `json:"mealPlans"`
+	Favorites int64 `json:"favorites"`
+}
+
+// Total returns the number of referring records
+func (r DishReferences) Total() int64 {
+	return r.Meals + r.MealPlans + r.Favorites
+}
+
+// DishDeleteResult describes what deleting a dish did
+type DishDeleteResult struct {
+	Policy     string         `json:"policy"`
+	Archived   bool           `json:"archived"` // the dish was archived rather than deleted
+	References DishReferences `json:"references"`
+}
+
+// DishInUseError is returned when the restrict policy refuses to delete a referenced dish
+type DishInUseError struct {
+	References DishReferences
+}
+
+func (e *DishInUseError) Error() string {
+	return "dish is referenced by meals, meal plans or favorites"
 }
 
 // ToResponse converts Dish model to DishResponse
 func (d *Dish) ToResponse() DishResponse {
-	return DishResponse{
+	response := DishResponse{
 		ID:          d.ID.Hex(),
 		Name:        d.Name,
+		Aliases:     d.Aliases,
 		Type:        d.Type,
 		Cuisine:     d.Cuisine,
 		Image:       d.Image,
@@ -97,6 +394,8 @@ func (d *Dish) ToResponse() DishResponse {
 		Calories:    d.Calories,
 		Nutrition:   d.Nutrition,
 		DietaryTags: d.DietaryTags,
+		Allergens:   d.Allergens,
+		DietRules:   d.DietRules,
 		SpiceLevel:  d.SpiceLevel,
 		PrepTime:    d.PrepTime,
 		CookTime:    d.CookTime,
@@ -104,7 +403,28 @@ func (d *Dish) ToResponse() DishResponse {
 		Difficulty:  d.Difficulty,
 		Description: d.Description,
 		IsFavorite:  false, // Will be set by service layer
+
+		IngredientQuantities: d.IngredientQuantities,
+		NutritionSource:      d.NutritionSource,
+		NutritionCheck:       d.NutritionCheck,
+		Warnings:             d.Warnings,
+		Steps:                d.Steps,
+		Stats:                d.Stats,
+	}
+	if d.CreatedBy != nil {
+		response.CreatedBy = d.CreatedBy.Hex()
+	}
+	response.Archived = d.ArchivedAt != nil
+	response.Visibility = d.ResolvedVisibility()
+	response.Review = d.Review
+	if d.GlycemicLoad != nil {
+		response.GlycemicLoad = d.GlycemicLoad
+		response.GlycemicLoadLevel = GlycemicLoadLevel(*d.GlycemicLoad)
+	}
+	if len(d.Steps) > 0 {
+		response.Equipment = d.Equipment()
 	}
+	return response
 }
 
 // GetValidDietaryTags returns the list of valid dietary tags
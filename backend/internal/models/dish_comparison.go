@@ -0,0 +1,53 @@
+package models
+
+// MaxComparedDishes is the most dishes compared side by side
+const MaxComparedDishes = 5
+
+// MacroDensity is grams of each macro per 100 kcal
+type MacroDensity struct {
+	Protein float64 `json:"protein"`
+	Carbs   float64 `json:"carbs"`
+	Fat     float64 `json:"fat"`
+	Fiber   float64 `json:"fiber"`
+}
+
+// ComparedDish is one column of a dish comparison, with values per serving
+type ComparedDish struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	Type         string       `json:"type"`
+	Cuisine      string       `json:"cuisine"`
+	Image        string       `json:"image"`
+	Calories     int          `json:"calories"`
+	Nutrition    Nutrition    `json:"nutrition"`
+	MacroDensity MacroDensity `json:"macroDensity"`
+	GlycemicLoad *float64     `json:"glycemicLoad"` // nil when unknown
+	PrepTime     int          `json:"prepTime"`     // minutes
+	CookTime     int          `json:"cookTime"`     // minutes
+	TotalTime    int          `json:"totalTime"`    // minutes
+	Allergens    []string     `json:"allergens"`
+	DietaryTags  []string     `json:"dietaryTags"`
+
+	// The serving judged against one meal's share of the nutrition goals
+	GoalFit DayAdherence `json:"goalFit"`
+}
+
+// DishComparison lines dishes up side by side, in the order asked for
+type DishComparison struct {
+	Dishes []ComparedDish `json:"dishes"`
+
+	// Rows of the allergen and dietary tag tables: everything any compared dish has
+	Allergens   []string `json:"allergens"`
+	DietaryTags []string `json:"dietaryTags"`
+
+	Verdict ComparisonVerdict `json:"verdict"`
+}
+
+// ComparisonVerdict names the dish that best fits the nutrition goals, if any clearly does
+type ComparisonVerdict struct {
+	DishID        string   `json:"dishId,omitempty"` // empty when no dish is clearly healthier
+	Name          string   `json:"name,omitempty"`
+	BetterOn      []string `json:"betterOn"` // nutrients the chosen dish fits best of all
+	Reason        string   `json:"reason"`
+	PersonalGoals bool     `json:"personalGoals"` // false when judged against the default goals
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Dish visibility. Dishes saved before visibility was tracked are public.
+const (
+	VisibilityPrivate   = "private"   // only the owner
+	VisibilityHousehold = "household" // the owner and members of their household
+	VisibilityPublic    = "public"    // everyone; user dishes need an admin's approval
+)
+
+// Review statuses for dishes submitted to the public catalog
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// Review decisions an admin can make on a submitted dish
+const (
+	ReviewDecisionApprove = "approve"
+	ReviewDecisionReject  = "reject"
+)
+
+// DishReview records a dish's submission to the public catalog and the admin's decision
+type DishReview struct {
+	Status      string              `bson:"status" json:"status"`
+	Note        string              `bson:"note,omitempty" json:"note,omitempty"` // reviewer's note, required when rejecting
+	SubmittedAt time.Time           `bson:"submittedAt" json:"submittedAt"`
+	ReviewedBy  *primitive.ObjectID `bson:"reviewedBy,omitempty" json:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time          `bson:"reviewedAt,omitempty" json:"reviewedAt,omitempty"`
+}
+
+// DishReviewRequest represents an admin's decision on a submitted dish
+type DishReviewRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approve reject"`
+	Note     string `json:"note" validate:"max=500"`
+}
+
+// ResolvedVisibility returns who can see the dish, treating dishes saved before
+// visibility was tracked as public
+func (d *Dish) ResolvedVisibility() string {
+	if d.Visibility == "" {
+		return VisibilityPublic
+	}
+	return d.Visibility
+}
+
+// VisibleTo reports whether the user may see the dish. Public dishes are visible to
+// everyone, including anonymous users; admins can see every dish.
+func (d *Dish) VisibleTo(user *User) bool {
+	switch {
+	case d.ResolvedVisibility() == VisibilityPublic:
+		return true
+	case user == nil:
+		return false
+	case user.IsAdmin(), d.CreatedBy != nil && *d.CreatedBy == user.ID:
+		return true
+	}
+	return d.Visibility == VisibilityHousehold && d.HouseholdID != nil &&
+		user.HouseholdID != nil && *d.HouseholdID == *user.HouseholdID
+}
+
+// SetVisibility changes who can see the dish on behalf of the actor. Admins publish
+// directly; for anyone else choosing public submits the dish for review and keeps its
+// current visibility until an admin approves it. An empty visibility changes nothing.
+func (d *Dish) SetVisibility(visibility string, actor *User, now time.Time) error {
+	switch visibility {
+	case "":
+		return nil
+	case VisibilityPublic:
+		if actor != nil && actor.IsAdmin() {
+			d.Visibility = VisibilityPublic
+			d.HouseholdID = nil
+			d.Review = &DishReview{Status: ReviewApproved, SubmittedAt: now, ReviewedBy: &actor.ID, ReviewedAt: &now}
+			return nil
+		}
+		if d.ResolvedVisibility() == VisibilityPublic {
+			return nil
+		}
+		return d.Submit(now)
+	case VisibilityHousehold:
+		if actor == nil || actor.HouseholdID == nil {
+			return errors.New("join a household before sharing dishes with it")
+		}
+		d.Visibility = VisibilityHousehold
+		d.HouseholdID = actor.HouseholdID
+	case VisibilityPrivate:
+		d.Visibility = VisibilityPrivate
+		d.HouseholdID = nil
+	default:
+		return errors.New("invalid visibility")
+	}
+
+	// Choosing a narrower visibility withdraws a pending submission
+	if d.Review != nil && d.Review.Status == ReviewPending {
+		d.Review = nil
+	}
+	return nil
+}
+
+// Submit asks for the dish to be added to the public catalog. It stays at its
+// current visibility until an admin approves it.
+func (d *Dish) Submit(now time.Time) error {
+	if d.ResolvedVisibility() == VisibilityPublic {
+		return errors.New("dish is already public")
+	}
+	d.Review = &DishReview{Status: ReviewPending, SubmittedAt: now}
+	return nil
+}
+
+// ApplyReview records an admin's decision on a pending submission.
+// Approved dishes become public; rejections must explain why.
+func (d *Dish) ApplyReview(req DishReviewRequest, reviewer primitive.ObjectID, now time.Time) error {
+	if d.Review == nil || d.Review.Status != ReviewPending {
+		return errors.New("dish is not awaiting review")
+	}
+
+	note := strings.TrimSpace(req.Note)
+	switch req.Decision {
+	case ReviewDecisionApprove:
+		d.Visibility = VisibilityPublic
+		d.HouseholdID = nil
+		d.Review.Status = ReviewApproved
+	case ReviewDecisionReject:
+		if note == "" {
+			return errors.New("a note is required when rejecting a dish")
+		}
+		d.Review.Status = ReviewRejected
+	default:
+		return errors.New("invalid review decision")
+	}
+
+	d.Review.Note = note
+	d.Review.ReviewedBy = &reviewer
+	d.Review.ReviewedAt = &now
+	return nil
+}
+
+// ReviewAfterEdit takes a user's published dish out of the public catalog after they
+// change it and submits the new version for review. Edits by admins stay published.
+func (d *Dish) ReviewAfterEdit(editor *User, now time.Time) {
+	if editor == nil || editor.IsAdmin() || d.CreatedBy == nil || d.ResolvedVisibility() != VisibilityPublic {
+		return
+	}
+	d.Visibility = VisibilityPrivate
+	d.HouseholdID = nil
+	d.Review = &DishReview{Status: ReviewPending, SubmittedAt: now}
+}
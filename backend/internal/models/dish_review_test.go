@@ -0,0 +1,224 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDishVisibleTo(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	householdID := primitive.NewObjectID()
+	owner := &User{ID: ownerID, HouseholdID: &householdID}
+	member := &User{ID: primitive.NewObjectID(), HouseholdID: &householdID}
+	stranger := &User{ID: primitive.NewObjectID()}
+	admin := &User{ID: primitive.NewObjectID(), Role: RoleAdmin}
+
+	tests := []struct {
+		name     string
+		dish     Dish
+		user     *User
+		expected bool
+	}{
+		{"seeded dish is public", Dish{}, nil, true},
+		{"public dish to anonymous user", Dish{Visibility: VisibilityPublic, CreatedBy: &ownerID}, nil, true},
+		{"private dish to anonymous user", Dish{Visibility: VisibilityPrivate, CreatedBy: &ownerID}, nil, false},
+		{"private dish to owner", Dish{Visibility: VisibilityPrivate, CreatedBy: &ownerID}, owner, true},
+		{"private dish to household member", Dish{Visibility: VisibilityPrivate, CreatedBy: &ownerID}, member, false},
+		{"private dish to admin", Dish{Visibility: VisibilityPrivate, CreatedBy: &ownerID}, admin, true},
+		{"household dish to member", Dish{Visibility: VisibilityHousehold, CreatedBy: &ownerID, HouseholdID: &householdID}, member, true},
+		{"household dish to stranger", Dish{Visibility: VisibilityHousehold, CreatedBy: &ownerID, HouseholdID: &householdID}, stranger, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			visible := tt.dish.VisibleTo(tt.user)
+
+			// Assert
+			assert.Equal(t, tt.expected, visible)
+		})
+	}
+}
+
+func TestDishSetVisibility(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	householdID := primitive.NewObjectID()
+
+	t.Run("user choosing public submits the dish for review", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{Visibility: VisibilityPrivate}
+
+		// Act
+		err := dish.SetVisibility(VisibilityPublic, &User{ID: primitive.NewObjectID()}, now)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, VisibilityPrivate, dish.Visibility)
+		require.NotNil(t, dish.Review)
+		assert.Equal(t, ReviewPending, dish.Review.Status)
+		assert.Equal(t, now, dish.Review.SubmittedAt)
+	})
+
+	t.Run("admin choosing public publishes the dish", func(t *testing.T) {
+		// Arrange
+		admin := &User{ID: primitive.NewObjectID(), Role: RoleAdmin}
+		dish := &Dish{Visibility: VisibilityPrivate}
+
+		// Act
+		err := dish.SetVisibility(VisibilityPublic, admin, now)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, VisibilityPublic, dish.Visibility)
+		assert.Equal(t, ReviewApproved, dish.Review.Status)
+		assert.Equal(t, &admin.ID, dish.Review.ReviewedBy)
+	})
+
+	t.Run("household visibility uses the actor's household", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{Visibility: VisibilityPrivate, Review: &DishReview{Status: ReviewPending}}
+
+		// Act
+		err := dish.SetVisibility(VisibilityHousehold, &User{ID: primitive.NewObjectID(), HouseholdID: &householdID}, now)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, VisibilityHousehold, dish.Visibility)
+		assert.Equal(t, &householdID, dish.HouseholdID)
+		assert.Nil(t, dish.Review, "a pending submission is withdrawn")
+	})
+
+	t.Run("household visibility requires a household", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{Visibility: VisibilityPrivate}
+
+		// Act
+		err := dish.SetVisibility(VisibilityHousehold, &User{ID: primitive.NewObjectID()}, now)
+
+		// Assert
+		assert.EqualError(t, err, "join a household before sharing dishes with it")
+	})
+
+	t.Run("unknown visibility is rejected", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{}
+
+		// Act
+		err := dish.SetVisibility("friends", &User{}, now)
+
+		// Assert
+		assert.EqualError(t, err, "invalid visibility")
+	})
+}
+
+func TestDishApplyReview(t *testing.T) {
+	now := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	reviewer := primitive.NewObjectID()
+
+	tests := []struct {
+		name           string
+		review         *DishReview
+		req            DishReviewRequest
+		expectedErr    string
+		expectedStatus string
+		expectedVis    string
+	}{
+		{
+			name:           "approve publishes the dish",
+			review:         &DishReview{Status: ReviewPending},
+			req:            DishReviewRequest{Decision: ReviewDecisionApprove},
+			expectedStatus: ReviewApproved,
+			expectedVis:    VisibilityPublic,
+		},
+		{
+			name:           "reject keeps the dish private",
+			review:         &DishReview{Status: ReviewPending},
+			req:            DishReviewRequest{Decision: ReviewDecisionReject, Note: "Add the cooking steps"},
+			expectedStatus: ReviewRejected,
+			expectedVis:    VisibilityPrivate,
+		},
+		{
+			name:        "reject needs a note",
+			review:      &DishReview{Status: ReviewPending},
+			req:         DishReviewRequest{Decision: ReviewDecisionReject, Note: "  "},
+			expectedErr: "a note is required when rejecting a dish",
+		},
+		{
+			name:        "dish must be pending",
+			review:      &DishReview{Status: ReviewRejected},
+			req:         DishReviewRequest{Decision: ReviewDecisionApprove},
+			expectedErr: "dish is not awaiting review",
+		},
+		{
+			name:        "dish never submitted",
+			req:         DishReviewRequest{Decision: ReviewDecisionApprove},
+			expectedErr: "dish is not awaiting review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dish := &Dish{Visibility: VisibilityPrivate, Review: tt.review}
+
+			// Act
+			err := dish.ApplyReview(tt.req, reviewer, now)
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, dish.Review.Status)
+			assert.Equal(t, tt.expectedVis, dish.Visibility)
+			assert.Equal(t, &reviewer, dish.Review.ReviewedBy)
+			assert.Equal(t, tt.req.Note, dish.Review.Note)
+		})
+	}
+}
+
+func TestDishReviewAfterEdit(t *testing.T) {
+	now := time.Date(2024, 3, 3, 9, 0, 0, 0, time.UTC)
+	ownerID := primitive.NewObjectID()
+
+	t.Run("owner's edit sends a published dish back for review", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{CreatedBy: &ownerID, Visibility: VisibilityPublic, Review: &DishReview{Status: ReviewApproved}}
+
+		// Act
+		dish.ReviewAfterEdit(&User{ID: ownerID}, now)
+
+		// Assert
+		assert.Equal(t, VisibilityPrivate, dish.Visibility)
+		assert.Equal(t, ReviewPending, dish.Review.Status)
+	})
+
+	t.Run("admin's edit stays published", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{CreatedBy: &ownerID, Visibility: VisibilityPublic}
+
+		// Act
+		dish.ReviewAfterEdit(&User{ID: primitive.NewObjectID(), Role: RoleAdmin}, now)
+
+		// Assert
+		assert.Equal(t, VisibilityPublic, dish.Visibility)
+		assert.Nil(t, dish.Review)
+	})
+
+	t.Run("private dish is unaffected", func(t *testing.T) {
+		// Arrange
+		dish := &Dish{CreatedBy: &ownerID, Visibility: VisibilityPrivate}
+
+		// Act
+		dish.ReviewAfterEdit(&User{ID: ownerID}, now)
+
+		// Assert
+		assert.Equal(t, VisibilityPrivate, dish.Visibility)
+		assert.Nil(t, dish.Review)
+	})
+}
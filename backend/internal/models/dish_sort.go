@@ -0,0 +1,91 @@
+package models
+
+import "sort"
+
+// Dish listing sort orders
+const (
+	DishSortName           = "name"            // A to Z, the default for listings
+	DishSortRelevance      = "relevance"       // best match first, the default for searches
+	DishSortCalories       = "calories"        // fewest calories first
+	DishSortProteinDensity = "protein-density" // most protein per calorie first
+	DishSortPrepTime       = "prep-time"       // quickest to prepare first
+	DishSortPopularity     = "popularity"      // most often logged first
+	DishSortRating         = "rating"          // best rated first
+	DishSortRecent         = "recent"          // most recently added first
+)
+
+// GetDishSorts returns the list of valid dish sort orders
+func GetDishSorts() []string {
+	return []string{
+		DishSortName, DishSortRelevance, DishSortCalories, DishSortProteinDensity,
+		DishSortPrepTime, DishSortPopularity, DishSortRating, DishSortRecent,
+	}
+}
+
+// ProteinDensity returns grams of protein per 100 kcal, or 0 for a dish without calories
+func (d *Dish) ProteinDensity() float64 {
+	if d.Calories <= 0 {
+		return 0
+	}
+	return float64(d.Nutrition.Protein) * 100 / float64(d.Calories)
+}
+
+// SortDishes orders dishes in place. Ties fall back to name order; relevance and unknown
+// sorts keep the current order.
+func SortDishes(dishes []*Dish, sortBy string) {
+	compare := dishComparisons[sortBy]
+	if compare == nil {
+		return
+	}
+	sort.SliceStable(dishes, func(i, j int) bool {
+		if c := compare(dishes[i], dishes[j]); c != 0 {
+			return c < 0
+		}
+		return dishes[i].Name < dishes[j].Name
+	})
+}
+
+// dishComparisons compare two dishes for each sort, negative when a comes first
+var dishComparisons = map[string]func(a, b *Dish) int{
+	DishSortName: func(a, b *Dish) int { return 0 },
+	DishSortCalories: func(a, b *Dish) int {
+		return compareFloats(float64(a.Calories), float64(b.Calories))
+	},
+	DishSortProteinDensity: func(a, b *Dish) int {
+		return compareFloats(b.ProteinDensity(), a.ProteinDensity())
+	},
+	DishSortPrepTime: func(a, b *Dish) int {
+		return compareFloats(float64(a.PrepTime), float64(b.PrepTime))
+	},
+	DishSortPopularity: func(a, b *Dish) int {
+		return compareFloats(float64(b.statsOrZero().TimesLogged), float64(a.statsOrZero().TimesLogged))
+	},
+	DishSortRating: func(a, b *Dish) int {
+		if c := compareFloats(b.statsOrZero().AvgRating, a.statsOrZero().AvgRating); c != 0 {
+			return c
+		}
+		return compareFloats(float64(b.statsOrZero().RatingCount), float64(a.statsOrZero().RatingCount))
+	},
+	DishSortRecent: func(a, b *Dish) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	},
+}
+
+// statsOrZero returns the dish's stats, or zero stats for a dish never logged
+func (d *Dish) statsOrZero() DishStats {
+	if d.Stats == nil {
+		return DishStats{}
+	}
+	return *d.Stats
+}
+
+// compareFloats returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDish_ProteinDensity(t *testing.T) {
+	// Arrange
+	dish := &Dish{Calories: 250, Nutrition: Nutrition{Protein: 15}}
+
+	// Act & Assert
+	assert.Equal(t, 6.0, dish.ProteinDensity())
+	assert.Equal(t, 0.0, (&Dish{Nutrition: Nutrition{Protein: 5}}).ProteinDensity())
+}
+
+func TestSortDishes(t *testing.T) {
+	now := time.Now()
+	dishes := func() []*Dish {
+		return []*Dish{
+			{Name: "Rajma", Calories: 300, PrepTime: 20, Nutrition: Nutrition{Protein: 15}, CreatedAt: now.Add(-48 * time.Hour),
+				Stats: &DishStats{TimesLogged: 12, AvgRating: 4.2, RatingCount: 5}},
+			{Name: "Poha", Calories: 250, PrepTime: 10, Nutrition: Nutrition{Protein: 5}, CreatedAt: now},
+			{Name: "Paneer Tikka", Calories: 300, PrepTime: 30, Nutrition: Nutrition{Protein: 20}, CreatedAt: now.Add(-time.Hour),
+				Stats: &DishStats{TimesLogged: 3, AvgRating: 4.8, RatingCount: 2}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sortBy   string
+		expected []string
+	}{
+		{"name", DishSortName, []string{"Paneer Tikka", "Poha", "Rajma"}},
+		{"calories, ties by name", DishSortCalories, []string{"Poha", "Paneer Tikka", "Rajma"}},
+		{"protein density", DishSortProteinDensity, []string{"Paneer Tikka", "Rajma", "Poha"}},
+		{"prep time", DishSortPrepTime, []string{"Poha", "Rajma", "Paneer Tikka"}},
+		{"popularity, unlogged last", DishSortPopularity, []string{"Rajma", "Paneer Tikka", "Poha"}},
+		{"rating", DishSortRating, []string{"Paneer Tikka", "Rajma", "Poha"}},
+		{"recent", DishSortRecent, []string{"Poha", "Paneer Tikka", "Rajma"}},
+		{"relevance keeps order", DishSortRelevance, []string{"Rajma", "Poha", "Paneer Tikka"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			sorted := dishes()
+
+			// Act
+			SortDishes(sorted, tt.sortBy)
+
+			// Assert
+			names := make([]string, len(sorted))
+			for i, dish := range sorted {
+				names[i] = dish.Name
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -249,4 +249,94 @@ func TestDishResponse(t *testing.T) {
 	assert.Equal(t, 300, response.Calories)
 	assert.Equal(t, 120, response.PrepTime)
 	assert.Equal(t, 15, response.CookTime)
-}
\ No newline at end of file
+}
+func TestDish_CanBeModifiedBy(t *testing.T) {
+	creator := &User{ID: primitive.NewObjectID()}
+	other := &User{ID: primitive.NewObjectID()}
+	admin := &User{ID: primitive.NewObjectID(), Role: RoleAdmin}
+
+	owned := &Dish{CreatedBy: &creator.ID}
+	seeded := &Dish{}
+
+	assert.True(t, owned.CanBeModifiedBy(creator))
+	assert.False(t, owned.CanBeModifiedBy(other))
+	assert.True(t, owned.CanBeModifiedBy(admin))
+	assert.False(t, owned.CanBeModifiedBy(nil))
+	assert.False(t, seeded.CanBeModifiedBy(creator))
+	assert.True(t, seeded.CanBeModifiedBy(admin))
+}
+
+func TestDishCreateRequest_ToDish(t *testing.T) {
+	quantities := []IngredientQuantity{{Name: "paneer", Quantity: 200, Unit: UnitGram}}
+
+	tests := []struct {
+		name     string
+		req      DishCreateRequest
+		expected string
+	}{
+		{"manual nutrition", DishCreateRequest{Calories: 300}, NutritionSourceManual},
+		{"quantities without nutrition", DishCreateRequest{IngredientQuantities: quantities}, NutritionSourceCalculated},
+		{"quantities with nutrition", DishCreateRequest{IngredientQuantities: quantities, Calories: 300}, NutritionSourceManual},
+		{"auto-fill requested", DishCreateRequest{IngredientQuantities: quantities, Calories: 300, AutoFillNutrition: true}, NutritionSourceCalculated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			dish := tt.req.ToDish()
+
+			// Assert
+			assert.Equal(t, tt.expected, dish.NutritionSource)
+			assert.Equal(t, "medium", dish.SpiceLevel)
+			assert.Equal(t, "medium", dish.Difficulty)
+			assert.Equal(t, 2, dish.Servings)
+		})
+	}
+}
+
+func TestDishPatchRequest_Apply(t *testing.T) {
+	name := "Paneer Tikka"
+	calories := 320
+	autoFill := true
+	tags := []string{}
+
+	tests := []struct {
+		name           string
+		patch          DishPatchRequest
+		expectName     string
+		expectCalories int
+		expectTags     []string
+		expectSource   string
+	}{
+		{"empty patch", DishPatchRequest{}, "Paneer Butter Masala", 400, []string{"vegetarian"}, NutritionSourceCalculated},
+		{"name only", DishPatchRequest{Name: &name}, name, 400, []string{"vegetarian"}, NutritionSourceCalculated},
+		{"clear tags", DishPatchRequest{DietaryTags: &tags}, "Paneer Butter Masala", 400, []string{}, NutritionSourceCalculated},
+		{"calories switch to manual", DishPatchRequest{Calories: &calories}, "Paneer Butter Masala", 320, []string{"vegetarian"}, NutritionSourceManual},
+		{"auto-fill wins", DishPatchRequest{Calories: &calories, AutoFillNutrition: &autoFill}, "Paneer Butter Masala", 320, []string{"vegetarian"}, NutritionSourceCalculated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dish := &Dish{Name: "Paneer Butter Masala", Calories: 400, DietaryTags: []string{"vegetarian"}, NutritionSource: NutritionSourceCalculated}
+
+			// Act
+			tt.patch.Apply(dish)
+
+			// Assert
+			assert.Equal(t, tt.expectName, dish.Name)
+			assert.Equal(t, tt.expectCalories, dish.Calories)
+			assert.Equal(t, tt.expectTags, dish.DietaryTags)
+			assert.Equal(t, tt.expectSource, dish.NutritionSource)
+		})
+	}
+}
+
+func TestDishAllergensFor(t *testing.T) {
+	// Arrange
+	dish := &Dish{Allergens: []string{AllergenDairy, AllergenTreeNuts}}
+
+	// Act & Assert
+	assert.Equal(t, []string{AllergenDairy}, dish.AllergensFor([]string{AllergenPeanut, AllergenDairy}))
+	assert.Empty(t, dish.AllergensFor(nil))
+}
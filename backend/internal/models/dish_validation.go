@@ -0,0 +1,335 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Validation issue severities. Errors reject a dish; warnings are returned with it.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Dish validation checks
+const (
+	CheckInvalidValue       = "invalid-value"
+	CheckEnergyMismatch     = "energy-mismatch"
+	CheckMissingNutrition   = "missing-nutrition"
+	CheckMissingImage       = "missing-image"
+	CheckInvalidCuisine     = "invalid-cuisine"
+	CheckInvalidTag         = "invalid-tag"
+	CheckOutlier            = "outlier"
+	CheckDuplicateName      = "duplicate-name"
+	CheckIngredientMismatch = "ingredient-mismatch"
+)
+
+// Energy from macros, Atwater factors in kcal/g
+const (
+	kcalPerGramProtein = 4
+	kcalPerGramCarbs   = 4
+	kcalPerGramFat     = 9
+)
+
+// Limits for dish validation
+const (
+	energyWarnTolerance  = 0.20 // calories differ from macro energy by more than 20%
+	energyErrorTolerance = 0.50
+	energyToleranceKcal  = 25   // differences below this are always accepted
+	maxServingCalories   = 2000 // per serving
+	maxServingSodiumMg   = 5000
+	maxTotalMinutes      = 24 * 60
+	outlierFenceIQR      = 3 // Tukey's far-out fence
+	minOutlierSample     = 8 // dishes needed before outliers are judged
+	minSpreadOfMedian    = 0.1
+)
+
+// DishIssue is a problem found when validating a dish
+type DishIssue struct {
+	Field    string `bson:"field" json:"field"`
+	Check    string `bson:"check" json:"check"`
+	Severity string `bson:"severity" json:"severity"`
+	Message  string `bson:"message" json:"message"`
+}
+
+// DishValidation holds the outcome of validating a dish
+type DishValidation struct {
+	Errors   []DishIssue `json:"errors,omitempty"`
+	Warnings []DishIssue `json:"warnings,omitempty"`
+}
+
+// Valid reports whether the dish has no errors
+func (v DishValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+func (v *DishValidation) add(severity, field, check, format string, args ...interface{}) {
+	issue := DishIssue{Field: field, Check: check, Severity: severity, Message: fmt.Sprintf(format, args...)}
+	if severity == SeverityError {
+		v.Errors = append(v.Errors, issue)
+	} else {
+		v.Warnings = append(v.Warnings, issue)
+	}
+}
+
+// DishValidationError is returned when a dish fails validation
+type DishValidationError struct {
+	Issues []DishIssue
+}
+
+func (e *DishValidationError) Error() string {
+	return "dish validation failed"
+}
+
+// MacroCalories returns the energy implied by the dish's macros
+func (n Nutrition) MacroCalories() int {
+	return n.Protein*kcalPerGramProtein + n.Carbs*kcalPerGramCarbs + n.Fat*kcalPerGramFat
+}
+
+// ValidateDish checks a dish's values and nutrition for consistency
+func ValidateDish(d *Dish) DishValidation {
+	var v DishValidation
+
+	if strings.TrimSpace(d.Name) == "" {
+		v.add(SeverityError, "name", CheckInvalidValue, "name is required")
+	}
+	if d.Type != "Veg" && d.Type != "Non-Veg" {
+		v.add(SeverityError, "type", CheckInvalidValue, "type must be Veg or Non-Veg")
+	}
+	if len(d.Ingredients) == 0 {
+		v.add(SeverityError, "ingredients", CheckInvalidValue, "at least one ingredient is required")
+	}
+	if d.Servings < 1 {
+		v.add(SeverityError, "servings", CheckInvalidValue, "servings must be at least 1")
+	}
+	if d.PrepTime < 0 || d.CookTime < 0 {
+		v.add(SeverityError, "prepTime", CheckInvalidValue, "prep and cook times must not be negative")
+	} else if d.PrepTime+d.CookTime > maxTotalMinutes {
+		v.add(SeverityWarning, "prepTime", CheckOutlier, "prep and cook time add up to more than a day")
+	}
+
+	if !contains(GetValidCuisines(), d.Cuisine) {
+		v.add(SeverityWarning, "cuisine", CheckInvalidCuisine, "unknown cuisine %q", d.Cuisine)
+	}
+	validTags := GetValidDietaryTags()
+	for _, tag := range d.DietaryTags {
+		if !contains(validTags, tag) {
+			v.add(SeverityWarning, "dietaryTags", CheckInvalidTag, "unknown dietary tag %q", tag)
+		}
+	}
+	if strings.TrimSpace(d.Image) == "" {
+		v.add(SeverityWarning, "image", CheckMissingImage, "dish has no image")
+	}
+
+	validateNutrition(d, &v)
+	return v
+}
+
+// validateNutrition checks nutrition values are positive and agree with each other
+func validateNutrition(d *Dish, v *DishValidation) {
+	n := d.Nutrition
+	if d.Calories < 0 {
+		v.add(SeverityError, "calories", CheckInvalidValue, "calories must not be negative")
+	}
+	values := map[string]int{
+		"nutrition.protein": n.Protein, "nutrition.carbs": n.Carbs, "nutrition.fat": n.Fat,
+		"nutrition.fiber": n.Fiber, "nutrition.sugar": n.Sugar, "nutrition.sodium": n.Sodium,
+	}
+	for _, field := range sortedKeys(values) {
+		if values[field] < 0 {
+			v.add(SeverityError, field, CheckInvalidValue, "%s must not be negative", field)
+		}
+	}
+	for _, key := range sortedKeys(n.Micronutrients) {
+		if n.Micronutrients[key] < 0 {
+			v.add(SeverityError, "nutrition.micronutrients."+key, CheckInvalidValue, "%s must not be negative", key)
+		}
+	}
+
+	if d.Calories == 0 {
+		v.add(SeverityError, "calories", CheckMissingNutrition, "calories must be greater than zero")
+	}
+	if n.MacroCalories() == 0 {
+		v.add(SeverityWarning, "nutrition", CheckMissingNutrition, "protein, carbs and fat are all missing")
+	} else if d.Calories > 0 {
+		macro := n.MacroCalories()
+		difference := math.Abs(float64(d.Calories - macro))
+		deviation := difference / float64(d.Calories)
+		switch {
+		case difference <= energyToleranceKcal || deviation <= energyWarnTolerance:
+		case deviation > energyErrorTolerance:
+			v.add(SeverityError, "calories", CheckEnergyMismatch,
+				"calories (%d) differ from 4·protein + 4·carbs + 9·fat (%d) by %.0f%%", d.Calories, macro, deviation*100)
+		default:
+			v.add(SeverityWarning, "calories", CheckEnergyMismatch,
+				"calories (%d) differ from 4·protein + 4·carbs + 9·fat (%d) by %.0f%%", d.Calories, macro, deviation*100)
+		}
+	}
+
+	if n.Sugar > n.Carbs {
+		v.add(SeverityError, "nutrition.sugar", CheckInvalidValue, "sugar must not exceed carbs")
+	}
+	if satFat := n.Micronutrients[MicronutrientSaturatedFat]; satFat > float64(n.Fat) {
+		v.add(SeverityError, "nutrition.micronutrients."+MicronutrientSaturatedFat, CheckInvalidValue, "saturated fat must not exceed fat")
+	}
+	if d.Calories > maxServingCalories {
+		v.add(SeverityWarning, "calories", CheckOutlier, "more than %d kcal per serving", maxServingCalories)
+	}
+	if n.Sodium > maxServingSodiumMg {
+		v.add(SeverityWarning, "nutrition.sodium", CheckOutlier, "more than %d mg sodium per serving", maxServingSodiumMg)
+	}
+	if d.NutritionCheck != nil && d.NutritionCheck.Status == NutritionCheckMismatch {
+		v.add(SeverityWarning, "nutrition", CheckIngredientMismatch, "entered nutrition differs from the ingredient quantities")
+	}
+}
+
+// CatalogIssue is a dish issue found by the catalog data-quality report
+type CatalogIssue struct {
+	DishID   string `json:"dishId"`
+	DishName string `json:"dishName"`
+	DishIssue
+}
+
+// DuplicateNameGroup lists dishes that share a name
+type DuplicateNameGroup struct {
+	Name    string   `json:"name"`
+	DishIDs []string `json:"dishIds"`
+}
+
+// CatalogReport summarises the data quality of the dishes collection
+type CatalogReport struct {
+	GeneratedAt      time.Time            `json:"generatedAt"`
+	TotalDishes      int                  `json:"totalDishes"`
+	DishesWithErrors int                  `json:"dishesWithErrors"`
+	DishesWithIssues int                  `json:"dishesWithIssues"`
+	Counts           map[string]int       `json:"counts"` // issues per check
+	Duplicates       []DuplicateNameGroup `json:"duplicates,omitempty"`
+	Issues           []CatalogIssue       `json:"issues"`
+}
+
+// BuildCatalogReport validates every dish and looks for duplicate names and
+// statistical outliers in calories, sodium and total time across the catalog
+func BuildCatalogReport(dishes []*Dish, now time.Time) CatalogReport {
+	report := CatalogReport{
+		GeneratedAt: now,
+		TotalDishes: len(dishes),
+		Counts:      map[string]int{},
+		Issues:      []CatalogIssue{},
+	}
+
+	issues := make([][]DishIssue, len(dishes))
+	hasError := make([]bool, len(dishes))
+	for i, dish := range dishes {
+		validation := ValidateDish(dish)
+		issues[i] = append(validation.Errors, validation.Warnings...)
+		hasError[i] = !validation.Valid()
+	}
+
+	// Duplicate names, ignoring case and spacing
+	byName := map[string][]int{}
+	for i, dish := range dishes {
+		key := strings.Join(strings.Fields(strings.ToLower(dish.Name)), " ")
+		if key != "" {
+			byName[key] = append(byName[key], i)
+		}
+	}
+	for _, key := range sortedKeys(byName) {
+		group := byName[key]
+		if len(group) < 2 {
+			continue
+		}
+		duplicate := DuplicateNameGroup{Name: dishes[group[0]].Name}
+		for _, i := range group {
+			duplicate.DishIDs = append(duplicate.DishIDs, dishes[i].ID.Hex())
+			issues[i] = append(issues[i], DishIssue{
+				Field: "name", Check: CheckDuplicateName, Severity: SeverityWarning,
+				Message: fmt.Sprintf("%d dishes are named %q", len(group), duplicate.Name),
+			})
+		}
+		report.Duplicates = append(report.Duplicates, duplicate)
+	}
+
+	// Outliers relative to the rest of the catalog
+	metrics := []struct {
+		field string
+		label string
+		value func(*Dish) float64
+	}{
+		{"calories", "calories per serving", func(d *Dish) float64 { return float64(d.Calories) }},
+		{"nutrition.sodium", "sodium per serving", func(d *Dish) float64 { return float64(d.Nutrition.Sodium) }},
+		{"prepTime", "total time", func(d *Dish) float64 { return float64(d.PrepTime + d.CookTime) }},
+	}
+	if len(dishes) >= minOutlierSample {
+		for _, metric := range metrics {
+			values := make([]float64, len(dishes))
+			for i, dish := range dishes {
+				values[i] = metric.value(dish)
+			}
+			low, high := outlierFences(values)
+			for i, value := range values {
+				if value < low || value > high {
+					issues[i] = append(issues[i], DishIssue{
+						Field: metric.field, Check: CheckOutlier, Severity: SeverityWarning,
+						Message: fmt.Sprintf("%s of %.0f is outside the catalog range %.0f-%.0f", metric.label, value, math.Max(low, 0), high),
+					})
+				}
+			}
+		}
+	}
+
+	for i, dish := range dishes {
+		if len(issues[i]) == 0 {
+			continue
+		}
+		report.DishesWithIssues++
+		if hasError[i] {
+			report.DishesWithErrors++
+		}
+		for _, issue := range issues[i] {
+			report.Counts[issue.Check]++
+			report.Issues = append(report.Issues, CatalogIssue{DishID: dish.ID.Hex(), DishName: dish.Name, DishIssue: issue})
+		}
+	}
+	return report
+}
+
+// outlierFences returns Tukey's far-out fences for the values. The interquartile
+// range is at least a tenth of the median so near-identical values do not flag noise.
+func outlierFences(values []float64) (float64, float64) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	iqr := math.Max(q3-q1, quantile(sorted, 0.5)*minSpreadOfMedian)
+	return q1 - outlierFenceIQR*iqr, q3 + outlierFenceIQR*iqr
+}
+
+// quantile interpolates the q-th quantile of sorted values
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+// contains reports whether values includes value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
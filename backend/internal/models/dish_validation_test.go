@@ -0,0 +1,167 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// validDish returns a dish that passes validation without warnings
+func validDish() *Dish {
+	return &Dish{
+		ID:          primitive.NewObjectID(),
+		Name:        "Dal Tadka",
+		Type:        "Veg",
+		Cuisine:     "North Indian",
+		Image:       "dal.jpg",
+		Ingredients: []string{"toor dal", "ghee"},
+		Calories:    250,
+		Nutrition:   Nutrition{Protein: 12, Carbs: 33, Fat: 8, Fiber: 6, Sugar: 4, Sodium: 590},
+		DietaryTags: []string{"vegetarian"},
+		PrepTime:    10,
+		CookTime:    30,
+		Servings:    4,
+	}
+}
+
+func checksOf(issues []DishIssue) []string {
+	var checks []string
+	for _, issue := range issues {
+		checks = append(checks, issue.Check)
+	}
+	return checks
+}
+
+func TestValidateDish(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(d *Dish)
+		errors   []string
+		warnings []string
+	}{
+		{
+			name:   "valid dish",
+			modify: func(d *Dish) {},
+		},
+		{
+			name:   "zero calories",
+			modify: func(d *Dish) { d.Calories = 0 },
+			errors: []string{CheckMissingNutrition},
+		},
+		{
+			name:   "negative values",
+			modify: func(d *Dish) { d.Nutrition.Fiber = -1; d.PrepTime = -5 },
+			errors: []string{CheckInvalidValue, CheckInvalidValue},
+		},
+		{
+			name:     "calories slightly off the macros",
+			modify:   func(d *Dish) { d.Calories = 320 }, // macros give 252 kcal
+			warnings: []string{CheckEnergyMismatch},
+		},
+		{
+			name:   "calories far off the macros",
+			modify: func(d *Dish) { d.Calories = 600 },
+			errors: []string{CheckEnergyMismatch},
+		},
+		{
+			name:     "small absolute difference is accepted",
+			modify:   func(d *Dish) { d.Calories = 40; d.Nutrition = Nutrition{Protein: 1, Carbs: 4, Sugar: 1} },
+			warnings: nil,
+		},
+		{
+			name:     "no macros",
+			modify:   func(d *Dish) { d.Nutrition = Nutrition{} },
+			warnings: []string{CheckMissingNutrition},
+		},
+		{
+			name:   "sugar above carbs",
+			modify: func(d *Dish) { d.Nutrition.Sugar = 40 },
+			errors: []string{CheckInvalidValue},
+		},
+		{
+			name: "unknown cuisine, tag and missing image",
+			modify: func(d *Dish) {
+				d.Cuisine = "Martian"
+				d.DietaryTags = []string{"vegetarian", "carnivore"}
+				d.Image = ""
+			},
+			warnings: []string{CheckInvalidCuisine, CheckInvalidTag, CheckMissingImage},
+		},
+		{
+			name:     "ingredient mismatch",
+			modify:   func(d *Dish) { d.NutritionCheck = &NutritionCheck{Status: NutritionCheckMismatch} },
+			warnings: []string{CheckIngredientMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dish := validDish()
+			tt.modify(dish)
+
+			// Act
+			validation := ValidateDish(dish)
+
+			// Assert
+			assert.Equal(t, tt.errors, checksOf(validation.Errors))
+			assert.Equal(t, tt.warnings, checksOf(validation.Warnings))
+			assert.Equal(t, len(tt.errors) == 0, validation.Valid())
+		})
+	}
+}
+
+func TestBuildCatalogReport(t *testing.T) {
+	// Arrange - ten ordinary dishes, one duplicate name, one calorie outlier and one invalid dish
+	var dishes []*Dish
+	for i := 0; i < 10; i++ {
+		dish := validDish()
+		dish.Name = fmt.Sprintf("Dish %d", i)
+		dish.Calories = 240 + i*2
+		dishes = append(dishes, dish)
+	}
+	dishes[1].Name = " dish  0 "
+
+	outlier := validDish()
+	outlier.Name = "Party Platter"
+	outlier.Calories = 1800
+	outlier.Nutrition = Nutrition{Protein: 90, Carbs: 200, Fat: 70, Sugar: 20, Sodium: 600}
+	invalid := validDish()
+	invalid.Name = "Broken"
+	invalid.Calories = 0
+	dishes = append(dishes, outlier, invalid)
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	// Act
+	report := BuildCatalogReport(dishes, now)
+
+	// Assert
+	assert.Equal(t, now, report.GeneratedAt)
+	assert.Equal(t, 12, report.TotalDishes)
+	assert.Equal(t, 1, report.DishesWithErrors)
+	assert.Equal(t, 4, report.DishesWithIssues)
+	require.Len(t, report.Duplicates, 1)
+	assert.Equal(t, []string{dishes[0].ID.Hex(), dishes[1].ID.Hex()}, report.Duplicates[0].DishIDs)
+	assert.Equal(t, 2, report.Counts[CheckDuplicateName])
+	assert.Equal(t, 2, report.Counts[CheckOutlier]) // the platter and the zero-calorie dish
+	assert.Equal(t, 1, report.Counts[CheckMissingNutrition])
+}
+
+func TestBuildCatalogReport_SmallCatalogHasNoOutliers(t *testing.T) {
+	// Arrange
+	outlier := validDish()
+	outlier.Name = "Party Platter"
+	outlier.Calories = 1800
+	outlier.Nutrition = Nutrition{Protein: 90, Carbs: 200, Fat: 70, Sugar: 20}
+
+	// Act
+	report := BuildCatalogReport([]*Dish{validDish(), outlier}, time.Now())
+
+	// Assert
+	assert.Zero(t, report.Counts[CheckOutlier])
+	assert.Empty(t, report.Issues)
+}
@@ -0,0 +1,117 @@
+package models
+
+import "sort"
+
+// RangeBucket is a named range of a numeric dish field, e.g. calories under 200.
+// Min is inclusive, Max exclusive; a zero Max leaves the range open.
+type RangeBucket struct {
+	Key   string
+	Label string
+	Min   int
+	Max   int
+}
+
+// Contains reports whether the value falls in the bucket
+func (b RangeBucket) Contains(value int) bool {
+	return value >= b.Min && (b.Max == 0 || value < b.Max)
+}
+
+// GetCalorieBuckets returns the calorie ranges dishes are grouped into, per serving
+func GetCalorieBuckets() []RangeBucket {
+	return []RangeBucket{
+		{Key: "under-200", Label: "Under 200 kcal", Min: 0, Max: 200},
+		{Key: "200-400", Label: "200-400 kcal", Min: 200, Max: 400},
+		{Key: "400-600", Label: "400-600 kcal", Min: 400, Max: 600},
+		{Key: "over-600", Label: "600 kcal and over", Min: 600},
+	}
+}
+
+// GetPrepTimeBuckets returns the preparation time ranges dishes are grouped into, in minutes
+func GetPrepTimeBuckets() []RangeBucket {
+	return []RangeBucket{
+		{Key: "under-15", Label: "Under 15 min", Min: 0, Max: 15},
+		{Key: "15-30", Label: "15-30 min", Min: 15, Max: 30},
+		{Key: "30-60", Label: "30-60 min", Min: 30, Max: 60},
+		{Key: "over-60", Label: "1 hour and over", Min: 60},
+	}
+}
+
+// FindBucket returns the bucket with the key
+func FindBucket(buckets []RangeBucket, key string) (RangeBucket, bool) {
+	for _, bucket := range buckets {
+		if bucket.Key == key {
+			return bucket, true
+		}
+	}
+	return RangeBucket{}, false
+}
+
+// FacetCount is the number of dishes with one value of a facet
+type FacetCount struct {
+	Value    string `json:"value"`
+	Label    string `json:"label,omitempty"`
+	Count    int    `json:"count"`
+	Selected bool   `json:"selected"`
+}
+
+// DishFacets counts the dishes matching a query by each filterable field. Each facet is
+// counted with the selections in the other facets applied but not its own, so the counts
+// show what selecting another value would add.
+type DishFacets struct {
+	Type        []FacetCount `json:"type"`
+	Cuisine     []FacetCount `json:"cuisine"`
+	DietaryTags []FacetCount `json:"dietaryTags"`
+	SpiceLevel  []FacetCount `json:"spiceLevel"`
+	Difficulty  []FacetCount `json:"difficulty"`
+	Calories    []FacetCount `json:"calories"`
+	PrepTime    []FacetCount `json:"prepTime"`
+}
+
+// ValueFacetCounts lists value counts, most common first. Selected values are always
+// listed, with a zero count when no dish has them, so they can be deselected.
+func ValueFacetCounts(counts map[string]int, selected []string) []FacetCount {
+	facet := make([]FacetCount, 0, len(counts)+len(selected))
+	for value, count := range counts {
+		if value == "" {
+			continue
+		}
+		facet = append(facet, FacetCount{Value: value, Count: count, Selected: contains(selected, value)})
+	}
+	for _, value := range selected {
+		if _, ok := counts[value]; !ok && value != "" && !facetHasValue(facet, value) {
+			facet = append(facet, FacetCount{Value: value, Selected: true})
+		}
+	}
+
+	sort.Slice(facet, func(i, j int) bool {
+		if facet[i].Count != facet[j].Count {
+			return facet[i].Count > facet[j].Count
+		}
+		return facet[i].Value < facet[j].Value
+	})
+	return facet
+}
+
+// BucketFacetCounts lists every bucket in order with its count
+func BucketFacetCounts(buckets []RangeBucket, counts map[string]int, selected []string) []FacetCount {
+	facet := make([]FacetCount, len(buckets))
+	for i, bucket := range buckets {
+		facet[i] = FacetCount{
+			Value:    bucket.Key,
+			Label:    bucket.Label,
+			Count:    counts[bucket.Key],
+			Selected: contains(selected, bucket.Key),
+		}
+	}
+	return facet
+}
+
+// facetHasValue reports whether the facet already lists value
+func facetHasValue(facet []FacetCount, value string) bool {
+	for _, count := range facet {
+		if count.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeBucket_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		bucket   string
+		value    int
+		expected bool
+	}{
+		{"lower bound is inclusive", "200-400", 200, true},
+		{"upper bound is exclusive", "200-400", 400, false},
+		{"open bucket has no upper bound", "over-600", 1500, true},
+		{"first bucket starts at zero", "under-200", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			bucket, found := FindBucket(GetCalorieBuckets(), tt.bucket)
+
+			// Act & Assert
+			assert.True(t, found)
+			assert.Equal(t, tt.expected, bucket.Contains(tt.value))
+		})
+	}
+}
+
+func TestFindBucket_Unknown(t *testing.T) {
+	// Act
+	_, found := FindBucket(GetPrepTimeBuckets(), "under-5")
+
+	// Assert
+	assert.False(t, found)
+}
+
+func TestValueFacetCounts(t *testing.T) {
+	// Arrange
+	counts := map[string]int{"Punjabi": 4, "Gujarati": 7, "Bengali": 4, "": 2}
+
+	// Act
+	facet := ValueFacetCounts(counts, []string{"Bengali", "Goan"})
+
+	// Assert
+	assert.Equal(t, []FacetCount{
+		{Value: "Gujarati", Count: 7},
+		{Value: "Bengali", Count: 4, Selected: true},
+		{Value: "Punjabi", Count: 4},
+		{Value: "Goan", Count: 0, Selected: true},
+	}, facet)
+}
+
+func TestBucketFacetCounts(t *testing.T) {
+	// Act
+	facet := BucketFacetCounts(GetPrepTimeBuckets(), map[string]int{"15-30": 3, "": 1}, []string{"under-15"})
+
+	// Assert
+	assert.Len(t, facet, 4)
+	assert.Equal(t, FacetCount{Value: "under-15", Label: "Under 15 min", Count: 0, Selected: true}, facet[0])
+	assert.Equal(t, 3, facet[1].Count)
+	assert.Equal(t, "over-60", facet[3].Value)
+}
@@ -10,6 +10,9 @@ import (
 // FlexibleDate is a custom time type that can parse multiple date formats
 type FlexibleDate struct {
 	time.Time
+
+	// zoneless is set when the parsed value carried no UTC offset
+	zoneless bool
 }
 
 // UnmarshalJSON implements json.Unmarshaler interface
@@ -18,18 +21,22 @@ func (fd *FlexibleDate) UnmarshalJSON(data []byte) error {
 	str := strings.Trim(string(data), "\"")
 
 	// List of supported date formats
-	formats := []string{
-		"2006-01-02T15:04:05Z07:00", // RFC3339 with timezone
-		"2006-01-02T15:04:05Z",      // RFC3339 UTC
-		"2006-01-02T15:04:05",       // ISO8601 without timezone
-		"2006-01-02 15:04:05",       // SQL datetime format
-		"2006-01-02",                // Date only (YYYY-MM-DD)
+	formats := []struct {
+		layout   string
+		zoneless bool
+	}{
+		{"2006-01-02T15:04:05Z07:00", false}, // RFC3339 with timezone
+		{"2006-01-02T15:04:05Z", false},      // RFC3339 UTC
+		{"2006-01-02T15:04:05", true},        // ISO8601 without timezone
+		{"2006-01-02 15:04:05", true},        // SQL datetime format
+		{"2006-01-02", true},                 // Date only (YYYY-MM-DD)
 	}
 
 	var err error
 	for _, format := range formats {
-		fd.Time, err = time.Parse(format, str)
+		fd.Time, err = time.Parse(format.layout, str)
 		if err == nil {
+			fd.zoneless = format.zoneless
 			return nil
 		}
 	}
@@ -37,6 +44,16 @@ func (fd *FlexibleDate) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("unable to parse date '%s', supported formats: YYYY-MM-DD, YYYY-MM-DDTHH:MM:SS, or RFC3339", str)
 }
 
+// In returns the date as an instant in loc. Values sent without a UTC offset
+// are read as wall-clock time in loc; values with an offset are left unchanged.
+func (fd FlexibleDate) In(loc *time.Location) time.Time {
+	if !fd.zoneless || loc == nil {
+		return fd.Time
+	}
+	t := fd.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 // MarshalJSON implements json.Marshaler interface
 func (fd FlexibleDate) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fd.Time.Format(time.RFC3339))
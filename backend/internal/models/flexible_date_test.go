@@ -207,4 +207,44 @@ func TestFlexibleDate_ErrorMessage(t *testing.T) {
 	assert.Contains(t, err.Error(), "unable to parse date")
 	assert.Contains(t, err.Error(), "not-a-date")
 	assert.Contains(t, err.Error(), "supported formats")
-}
\ No newline at end of file
+}
+func TestFlexibleDate_In(t *testing.T) {
+	ist, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{
+			name:     "date only is local midnight",
+			input:    `"2023-10-15"`,
+			expected: time.Date(2023, 10, 15, 0, 0, 0, 0, ist),
+		},
+		{
+			name:     "late snack without offset stays on the local day",
+			input:    `"2023-10-15T23:00:00"`,
+			expected: time.Date(2023, 10, 15, 23, 0, 0, 0, ist),
+		},
+		{
+			name:     "explicit offset is kept",
+			input:    `"2023-10-15T23:00:00Z"`,
+			expected: time.Date(2023, 10, 15, 23, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var fd FlexibleDate
+			require.NoError(t, fd.UnmarshalJSON([]byte(tt.input)))
+
+			// Act
+			result := fd.In(ist)
+
+			// Assert
+			assert.True(t, tt.expected.Equal(result), "expected %v, got %v", tt.expected, result)
+		})
+	}
+}
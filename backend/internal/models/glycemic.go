@@ -0,0 +1,26 @@
+package models
+
+// Glycemic load levels per serving
+const (
+	GlycemicLoadLow    = "low"    // 10 or less
+	GlycemicLoadMedium = "medium" // 11 to 19
+	GlycemicLoadHigh   = "high"   // 20 or more
+)
+
+// GlycemicLoadLevel classifies the glycemic load of a serving
+func GlycemicLoadLevel(glycemicLoad float64) string {
+	switch {
+	case glycemicLoad <= 10:
+		return GlycemicLoadLow
+	case glycemicLoad < 20:
+		return GlycemicLoadMedium
+	default:
+		return GlycemicLoadHigh
+	}
+}
+
+// MealGlycemicLoad is the glycemic load of one meal of a day
+type MealGlycemicLoad struct {
+	MealType     string  `json:"mealType"`
+	GlycemicLoad float64 `json:"glycemicLoad"`
+}
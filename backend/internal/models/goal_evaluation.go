@@ -0,0 +1,277 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// Goal kinds describe how an intake is judged against its target
+const (
+	GoalKindMin   = "min"   // at least the target
+	GoalKindMax   = "max"   // no more than the target
+	GoalKindRange = "range" // within the tolerance either side of the target
+)
+
+// Nutrient names used in adherence reports
+const (
+	NutrientCalories = "calories"
+	NutrientProtein  = "protein"
+	NutrientCarbs    = "carbs"
+	NutrientFat      = "fat"
+	NutrientFiber    = "fiber"
+	NutrientSodium   = "sodium"
+	NutrientSugar    = "sugar"
+)
+
+// Per-day adherence outcomes for a nutrient
+const (
+	AdherenceMet   = "met"
+	AdherenceUnder = "under"
+	AdherenceOver  = "over"
+)
+
+// GoalRule sets how one nutrient target is judged. Tolerance is a fraction of the
+// target, e.g. 0.1 accepts a calorie range of 90-110% or protein down to 90%.
+type GoalRule struct {
+	Kind      string  `bson:"kind,omitempty" json:"kind,omitempty" validate:"omitempty,oneof=min max range"`
+	Tolerance float64 `bson:"tolerance,omitempty" json:"tolerance,omitempty" validate:"min=0,max=1"`
+}
+
+// GoalSemantics holds the rule for each nutrient target
+type GoalSemantics struct {
+	Calories GoalRule `bson:"calories" json:"calories"`
+	Protein  GoalRule `bson:"protein" json:"protein"`
+	Carbs    GoalRule `bson:"carbs" json:"carbs"`
+	Fat      GoalRule `bson:"fat" json:"fat"`
+	Fiber    GoalRule `bson:"fiber" json:"fiber"`
+	Sodium   GoalRule `bson:"sodium" json:"sodium"`
+	Sugar    GoalRule `bson:"sugar" json:"sugar"`
+}
+
+// DefaultGoalSemantics returns the rules used for nutrients without an explicit rule:
+// energy and carbs are ranges, protein and fiber are floors, fat, sodium and sugar are ceilings
+func DefaultGoalSemantics() GoalSemantics {
+	return GoalSemantics{
+		Calories: GoalRule{Kind: GoalKindRange, Tolerance: 0.1},
+		Protein:  GoalRule{Kind: GoalKindMin, Tolerance: 0.1},
+		Carbs:    GoalRule{Kind: GoalKindRange, Tolerance: 0.15},
+		Fat:      GoalRule{Kind: GoalKindMax, Tolerance: 0.1},
+		Fiber:    GoalRule{Kind: GoalKindMin, Tolerance: 0.1},
+		Sodium:   GoalRule{Kind: GoalKindMax},
+		Sugar:    GoalRule{Kind: GoalKindMax},
+	}
+}
+
+// NutrientRule is a resolved rule with its target
+type NutrientRule struct {
+	Nutrient  string
+	Kind      string
+	Target    float64
+	Tolerance float64
+}
+
+// Rules returns the resolved rule for every nutrient with a target set
+func (g NutritionGoals) Rules() []NutrientRule {
+	defaults := DefaultGoalSemantics()
+	entries := []struct {
+		nutrient string
+		target   int
+		rule     GoalRule
+		fallback GoalRule
+	}{
+		{NutrientCalories, g.DailyCalories, g.Semantics.Calories, defaults.Calories},
+		{NutrientProtein, g.Protein, g.Semantics.Protein, defaults.Protein},
+		{NutrientCarbs, g.Carbs, g.Semantics.Carbs, defaults.Carbs},
+		{NutrientFat, g.Fat, g.Semantics.Fat, defaults.Fat},
+		{NutrientFiber, g.Fiber, g.Semantics.Fiber, defaults.Fiber},
+		{NutrientSodium, g.Sodium, g.Semantics.Sodium, defaults.Sodium},
+		{NutrientSugar, g.Sugar, g.Semantics.Sugar, defaults.Sugar},
+	}
+
+	rules := make([]NutrientRule, 0, len(entries))
+	for _, e := range entries {
+		if e.target <= 0 {
+			continue
+		}
+		rule := e.rule
+		if rule.Kind == "" {
+			rule = e.fallback
+		}
+		rules = append(rules, NutrientRule{
+			Nutrient:  e.nutrient,
+			Kind:      rule.Kind,
+			Target:    float64(e.target),
+			Tolerance: rule.Tolerance,
+		})
+	}
+	return rules
+}
+
+// Bounds returns the accepted intake range; zero or +Inf mark an open side
+func (r NutrientRule) Bounds() (lower, upper float64) {
+	lower, upper = 0, math.Inf(1)
+	switch r.Kind {
+	case GoalKindMin:
+		lower = r.Target * (1 - r.Tolerance)
+	case GoalKindMax:
+		upper = r.Target * (1 + r.Tolerance)
+	default:
+		lower = r.Target * (1 - r.Tolerance)
+		upper = r.Target * (1 + r.Tolerance)
+	}
+	return lower, upper
+}
+
+// Evaluate judges an intake against the rule. The score is 1 when the goal is met and
+// falls linearly with the distance outside the accepted range, down to 0.
+func (r NutrientRule) Evaluate(actual float64) NutrientResult {
+	lower, upper := r.Bounds()
+	result := NutrientResult{
+		Kind:   r.Kind,
+		Target: r.Target,
+		Actual: actual,
+		Status: AdherenceMet,
+		Score:  1,
+	}
+
+	switch {
+	case actual < lower:
+		result.Status = AdherenceUnder
+		result.Score = actual / lower
+	case actual > upper:
+		result.Status = AdherenceOver
+		result.Score = math.Max(0, 1-(actual-upper)/upper)
+	}
+	result.Score = math.Round(result.Score*1000) / 1000
+	return result
+}
+
+// Validate checks that every explicit rule is supported
+func (s GoalSemantics) Validate() error {
+	rules := map[string]GoalRule{
+		NutrientCalories: s.Calories,
+		NutrientProtein:  s.Protein,
+		NutrientCarbs:    s.Carbs,
+		NutrientFat:      s.Fat,
+		NutrientFiber:    s.Fiber,
+		NutrientSodium:   s.Sodium,
+		NutrientSugar:    s.Sugar,
+	}
+	for nutrient, rule := range rules {
+		switch rule.Kind {
+		case "", GoalKindMin, GoalKindMax, GoalKindRange:
+		default:
+			return fmt.Errorf("invalid goal kind '%s' for %s, supported kinds: min, max, range", rule.Kind, nutrient)
+		}
+		if rule.Tolerance < 0 || rule.Tolerance > 1 {
+			return fmt.Errorf("tolerance for %s must be between 0 and 1", nutrient)
+		}
+	}
+	return nil
+}
+
+// NutrientResult is the evaluation of one nutrient on one day
+type NutrientResult struct {
+	Kind   string  `json:"kind"`
+	Target float64 `json:"target"`
+	Actual float64 `json:"actual"`
+	Status string  `json:"status"` // met, under or over
+	Score  float64 `json:"score"`  // 0-1
+}
+
+// DayAdherence is the evaluation of one day against its goals
+type DayAdherence struct {
+	Score     float64                   `json:"score"` // composite adherence, 0-100
+	Nutrients map[string]NutrientResult `json:"nutrients"`
+}
+
+// NutrientAdherence summarizes how often one nutrient goal was met over a range
+type NutrientAdherence struct {
+	Kind          string  `json:"kind"`
+	DaysEvaluated int     `json:"daysEvaluated"`
+	DaysMet       int     `json:"daysMet"`
+	Percentage    float64 `json:"percentage"` // share of evaluated days met
+	AvgScore      float64 `json:"avgScore"`   // 0-1
+	CurrentStreak int     `json:"currentStreak"`
+	LongestStreak int     `json:"longestStreak"`
+}
+
+// EvaluateDay judges a day's intake against its goals
+func EvaluateDay(day DailyNutrition, goals NutritionGoals) DayAdherence {
+	actuals := map[string]float64{
+		NutrientCalories: float64(day.Calories),
+		NutrientProtein:  float64(day.Protein),
+		NutrientCarbs:    float64(day.Carbs),
+		NutrientFat:      float64(day.Fat),
+		NutrientFiber:    float64(day.Fiber),
+		NutrientSodium:   float64(day.Sodium),
+		NutrientSugar:    float64(day.Sugar),
+	}
+
+	adherence := DayAdherence{Nutrients: map[string]NutrientResult{}}
+	total := 0.0
+	for _, rule := range goals.Rules() {
+		result := rule.Evaluate(actuals[rule.Nutrient])
+		adherence.Nutrients[rule.Nutrient] = result
+		total += result.Score
+	}
+	if len(adherence.Nutrients) > 0 {
+		adherence.Score = math.Round(total/float64(len(adherence.Nutrients))*1000) / 10
+	}
+	return adherence
+}
+
+// SummarizeAdherence aggregates per-nutrient adherence and streaks over a dense daily series
+// and returns the composite score: the average day score over logged days.
+// A day without logged meals breaks a streak, except for the last day of the series,
+// which may still be in progress.
+func SummarizeAdherence(series []DailyNutrition) (map[string]NutrientAdherence, float64) {
+	summary := map[string]NutrientAdherence{}
+	running := map[string]int{}
+	totalScore, logged := 0.0, 0
+
+	for i, day := range series {
+		if day.Adherence == nil {
+			if i < len(series)-1 {
+				running = map[string]int{}
+			}
+			continue
+		}
+		logged++
+		totalScore += day.Adherence.Score
+
+		for nutrient := range running {
+			if _, evaluated := day.Adherence.Nutrients[nutrient]; !evaluated {
+				delete(running, nutrient)
+			}
+		}
+		for nutrient, result := range day.Adherence.Nutrients {
+			s := summary[nutrient]
+			s.Kind = result.Kind
+			s.DaysEvaluated++
+			s.AvgScore += result.Score
+			if result.Status == AdherenceMet {
+				s.DaysMet++
+				running[nutrient]++
+				if running[nutrient] > s.LongestStreak {
+					s.LongestStreak = running[nutrient]
+				}
+			} else {
+				running[nutrient] = 0
+			}
+			summary[nutrient] = s
+		}
+	}
+
+	for nutrient, s := range summary {
+		s.CurrentStreak = running[nutrient]
+		s.Percentage = math.Round(float64(s.DaysMet)/float64(s.DaysEvaluated)*1000) / 10
+		s.AvgScore = math.Round(s.AvgScore/float64(s.DaysEvaluated)*1000) / 1000
+		summary[nutrient] = s
+	}
+
+	if logged == 0 {
+		return summary, 0
+	}
+	return summary, math.Round(totalScore/float64(logged)*10) / 10
+}
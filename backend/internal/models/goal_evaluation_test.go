@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNutrientRule_Evaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		rule           NutrientRule
+		actual         float64
+		expectedStatus string
+		expectedScore  float64
+	}{
+		{"range met", NutrientRule{Kind: GoalKindRange, Target: 2000, Tolerance: 0.1}, 2150, AdherenceMet, 1},
+		{"range over", NutrientRule{Kind: GoalKindRange, Target: 2000, Tolerance: 0.1}, 2640, AdherenceOver, 0.8},
+		{"range under", NutrientRule{Kind: GoalKindRange, Target: 2000, Tolerance: 0.1}, 900, AdherenceUnder, 0.5},
+		{"min exceeded is met", NutrientRule{Kind: GoalKindMin, Target: 60}, 120, AdherenceMet, 1},
+		{"min under", NutrientRule{Kind: GoalKindMin, Target: 60}, 45, AdherenceUnder, 0.75},
+		{"max under is met", NutrientRule{Kind: GoalKindMax, Target: 2000}, 0, AdherenceMet, 1},
+		{"max far over", NutrientRule{Kind: GoalKindMax, Target: 2000}, 5000, AdherenceOver, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.Evaluate(tt.actual)
+
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.InDelta(t, tt.expectedScore, result.Score, 0.001)
+		})
+	}
+}
+
+func TestNutritionGoals_Rules(t *testing.T) {
+	// Arrange - sodium switched to a floor, sugar unset
+	goals := NutritionGoals{
+		DailyCalories: 2000,
+		Protein:       60,
+		Sodium:        1500,
+		Semantics:     GoalSemantics{Sodium: GoalRule{Kind: GoalKindMin}},
+	}
+
+	// Act
+	rules := goals.Rules()
+
+	// Assert
+	require.Len(t, rules, 3)
+	assert.Equal(t, NutrientCalories, rules[0].Nutrient)
+	assert.Equal(t, GoalKindRange, rules[0].Kind)
+	assert.Equal(t, GoalKindMin, rules[1].Kind)
+	assert.Equal(t, NutrientSodium, rules[2].Nutrient)
+	assert.Equal(t, GoalKindMin, rules[2].Kind)
+}
+
+func TestEvaluateDay_OvereatingIsNotMet(t *testing.T) {
+	goals := NutritionGoals{DailyCalories: 2000, Protein: 60, Sodium: 2000}
+	day := DailyNutrition{Calories: 3200, Protein: 90, Sodium: 3000, MealCount: 4}
+
+	adherence := EvaluateDay(day, goals)
+
+	assert.Equal(t, AdherenceOver, adherence.Nutrients[NutrientCalories].Status)
+	assert.Equal(t, AdherenceMet, adherence.Nutrients[NutrientProtein].Status)
+	assert.Equal(t, AdherenceOver, adherence.Nutrients[NutrientSodium].Status)
+	assert.Less(t, adherence.Score, 100.0)
+}
+
+func TestSummarizeAdherence(t *testing.T) {
+	// Arrange - calories met on days 1, 2, 4 and 5; day 3 is not logged; day 6 is today and not logged yet
+	goals := NutritionGoals{DailyCalories: 2000}
+	calories := []int{2000, 1950, 0, 2100, 2000, 0}
+	series := make([]DailyNutrition, len(calories))
+	for i, c := range calories {
+		series[i] = DailyNutrition{Date: janDay(i + 1), Calories: c, Goals: goals}
+		if c > 0 {
+			series[i].MealCount = 1
+			adherence := EvaluateDay(series[i], goals)
+			series[i].Adherence = &adherence
+		}
+	}
+
+	// Act
+	nutrients, score := SummarizeAdherence(series)
+
+	// Assert
+	cal := nutrients[NutrientCalories]
+	assert.Equal(t, 4, cal.DaysEvaluated)
+	assert.Equal(t, 4, cal.DaysMet)
+	assert.Equal(t, 100.0, cal.Percentage)
+	assert.Equal(t, 2, cal.LongestStreak)
+	assert.Equal(t, 2, cal.CurrentStreak)
+	assert.Equal(t, 100.0, score)
+}
+
+func TestGoalSemantics_Validate(t *testing.T) {
+	assert.NoError(t, GoalSemantics{}.Validate())
+	assert.NoError(t, DefaultGoalSemantics().Validate())
+	assert.Error(t, GoalSemantics{Fat: GoalRule{Kind: "exact"}}.Validate())
+	assert.Error(t, GoalSemantics{Fat: GoalRule{Kind: GoalKindMax, Tolerance: 2}}.Validate())
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GoalVersion is a set of nutrition goals in force from EffectiveFrom until the next version
+type GoalVersion struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"userId" json:"userId"`
+	Goals         NutritionGoals     `bson:"goals" json:"goals"`
+	Source        string             `bson:"source" json:"source"`
+	EffectiveFrom time.Time          `bson:"effectiveFrom" json:"effectiveFrom"` // start of the first day the goals apply
+	EffectiveTo   *time.Time         `bson:"-" json:"effectiveTo,omitempty"`     // end of the last day, nil for the current version
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// GoalTimeline resolves which goals were in force on a given day
+type GoalTimeline struct {
+	Versions []GoalVersion `json:"versions"` // oldest first
+}
+
+// NewGoalTimeline orders goal versions and fills in when each one ended.
+// current is used as the only version when there is no history yet.
+func NewGoalTimeline(versions []GoalVersion, current NutritionGoals) GoalTimeline {
+	if len(versions) == 0 {
+		return GoalTimeline{Versions: []GoalVersion{{Goals: current}}}
+	}
+
+	ordered := make([]GoalVersion, len(versions))
+	copy(ordered, versions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].EffectiveFrom.Before(ordered[j].EffectiveFrom)
+	})
+
+	for i := 0; i < len(ordered)-1; i++ {
+		end := ordered[i+1].EffectiveFrom.Add(-time.Nanosecond)
+		ordered[i].EffectiveTo = &end
+	}
+	return GoalTimeline{Versions: ordered}
+}
+
+// At returns the goals in force at t. Days before the first recorded version
+// use the earliest known goals.
+func (tl GoalTimeline) At(t time.Time) NutritionGoals {
+	if len(tl.Versions) == 0 {
+		return NutritionGoals{}
+	}
+	goals := tl.Versions[0].Goals
+	for _, v := range tl.Versions {
+		if v.EffectiveFrom.After(t) {
+			break
+		}
+		goals = v.Goals
+	}
+	return goals
+}
+
+// NutritionGoalsChange is one entry of the goal change history
+type NutritionGoalsChange struct {
+	GoalVersion
+	Previous *NutritionGoals `json:"previous,omitempty"`
+}
+
+// Changes lists every goal version with the goals it replaced, oldest first
+func (tl GoalTimeline) Changes() []NutritionGoalsChange {
+	changes := make([]NutritionGoalsChange, 0, len(tl.Versions))
+	for i, v := range tl.Versions {
+		change := NutritionGoalsChange{GoalVersion: v}
+		if i > 0 {
+			previous := tl.Versions[i-1].Goals
+			change.Previous = &previous
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoalTimeline_At(t *testing.T) {
+	// Arrange - goals changed on 10 Jan and again on 20 Jan, supplied out of order
+	timeline := NewGoalTimeline([]GoalVersion{
+		{Goals: NutritionGoals{DailyCalories: 1800}, EffectiveFrom: janDay(20)},
+		{Goals: NutritionGoals{DailyCalories: 2000}, EffectiveFrom: janDay(1)},
+		{Goals: NutritionGoals{DailyCalories: 2200}, EffectiveFrom: janDay(10)},
+	}, NutritionGoals{DailyCalories: 1800})
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected int
+	}{
+		{"before history uses earliest goals", time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), 2000},
+		{"first version", janDay(5), 2000},
+		{"day a version takes effect", janDay(10), 2200},
+		{"last day of a version", janDay(19).Add(23 * time.Hour), 2200},
+		{"current version", janDay(25), 1800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, timeline.At(tt.at).DailyCalories)
+		})
+	}
+}
+
+func TestNewGoalTimeline_EffectiveTo(t *testing.T) {
+	timeline := NewGoalTimeline([]GoalVersion{
+		{Goals: NutritionGoals{DailyCalories: 2000}, EffectiveFrom: janDay(1)},
+		{Goals: NutritionGoals{DailyCalories: 2200}, EffectiveFrom: janDay(10)},
+	}, NutritionGoals{})
+
+	require.Len(t, timeline.Versions, 2)
+	require.NotNil(t, timeline.Versions[0].EffectiveTo)
+	assert.Equal(t, endOfDay(janDay(9)), *timeline.Versions[0].EffectiveTo)
+	assert.Nil(t, timeline.Versions[1].EffectiveTo)
+}
+
+func TestNewGoalTimeline_NoHistory(t *testing.T) {
+	timeline := NewGoalTimeline(nil, NutritionGoals{DailyCalories: 2100})
+
+	assert.Equal(t, 2100, timeline.At(janDay(1)).DailyCalories)
+}
+
+func TestGoalTimeline_Changes(t *testing.T) {
+	timeline := NewGoalTimeline([]GoalVersion{
+		{Goals: NutritionGoals{DailyCalories: 2000}, EffectiveFrom: janDay(1)},
+		{Goals: NutritionGoals{DailyCalories: 2200}, EffectiveFrom: janDay(10)},
+	}, NutritionGoals{})
+
+	changes := timeline.Changes()
+
+	require.Len(t, changes, 2)
+	assert.Nil(t, changes[0].Previous)
+	require.NotNil(t, changes[1].Previous)
+	assert.Equal(t, 2000, changes[1].Previous.DailyCalories)
+	assert.Equal(t, 2200, changes[1].Goals.DailyCalories)
+}
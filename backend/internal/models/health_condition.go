@@ -0,0 +1,61 @@
+package models
+
+// Health conditions a user profile can declare; each tightens goals and adds limits
+const (
+	HealthConditionDiabetes     = "diabetes"
+	HealthConditionHypertension = "hypertension"
+	HealthConditionPCOS         = "pcos"
+	HealthConditionRenal        = "renal" // chronic kidney disease, not on dialysis
+)
+
+// GetHealthConditions returns the list of valid health conditions
+func GetHealthConditions() []string {
+	return []string{HealthConditionDiabetes, HealthConditionHypertension, HealthConditionPCOS, HealthConditionRenal}
+}
+
+// ConditionLimits are the constraints health conditions place on a day's intake.
+// Zero values mean no limit.
+type ConditionLimits struct {
+	Conditions []string `json:"conditions"`
+
+	MaxSodium     int     `json:"maxSodium,omitempty"`     // mg per day
+	MaxPotassium  float64 `json:"maxPotassium,omitempty"`  // mg per day
+	MaxPhosphorus float64 `json:"maxPhosphorus,omitempty"` // mg per day
+	MaxSugar      int     `json:"maxSugar,omitempty"`      // g per day
+	MinFiber      int     `json:"minFiber,omitempty"`      // g per day
+
+	MaxCarbShare     float64 `json:"maxCarbShare,omitempty"`     // share of the day's calories from carbs
+	MaxMealCarbShare float64 `json:"maxMealCarbShare,omitempty"` // share of the day's carbs eaten in one meal
+
+	MaxDailyGlycemicLoad float64 `json:"maxDailyGlycemicLoad,omitempty"`
+	MaxMealGlycemicLoad  float64 `json:"maxMealGlycemicLoad,omitempty"`
+}
+
+// IsZero reports whether no limits apply
+func (l ConditionLimits) IsZero() bool {
+	return len(l.Conditions) == 0
+}
+
+// MealIntake is what was eaten at one meal of a day, used to check per-meal limits
+type MealIntake struct {
+	MealType     string
+	Carbs        int
+	GlycemicLoad float64
+}
+
+// Warning nutrients beyond the goal nutrients and micronutrient keys
+const (
+	ConditionCarbShare     = "carbShare"     // percentage of the day's calories from carbs
+	ConditionMealCarbShare = "mealCarbShare" // percentage of the day's carbs eaten in one meal
+	ConditionGlycemicLoad  = "glycemicLoad"
+)
+
+// ConditionWarning reports a limit a logged day broke
+type ConditionWarning struct {
+	Conditions []string `json:"conditions"` // conditions that set the limit
+	Nutrient   string   `json:"nutrient"`   // e.g. sodium, carbShare or glycemicLoad
+	MealType   string   `json:"mealType,omitempty"`
+	Limit      float64  `json:"limit"` // in the nutrient's unit; percentages for shares
+	Actual     float64  `json:"actual"`
+	Message    string   `json:"message"`
+}
@@ -34,6 +34,7 @@ type MealWithDish struct {
 	Notes     string       `json:"notes"`
 	Rating    int          `json:"rating"`
 	CreatedAt time.Time    `json:"createdAt"`
+	Warnings  []string     `json:"warnings,omitempty"` // e.g. the dish contains one of the user's allergens
 }
 
 // MealRequest represents the request for creating/updating a meal
@@ -0,0 +1,88 @@
+package models
+
+// Micronutrient keys used in Nutrition.Micronutrients. The map is open-ended;
+// these are the nutrients with reference intakes in the deficiency report.
+const (
+	MicronutrientIron         = "iron"         // mg
+	MicronutrientCalcium      = "calcium"      // mg
+	MicronutrientVitaminB12   = "vitaminB12"   // µg
+	MicronutrientVitaminD     = "vitaminD"     // µg
+	MicronutrientFolate       = "folate"       // µg
+	MicronutrientPotassium    = "potassium"    // mg
+	MicronutrientSaturatedFat = "saturatedFat" // g
+
+	// Limited for renal diets; entered with a dish's nutrition, not in the catalog or report
+	MicronutrientPhosphorus = "phosphorus" // mg
+)
+
+// MicronutrientInfo describes a tracked micronutrient
+type MicronutrientInfo struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Unit string `json:"unit"`
+	Kind string `json:"kind"` // min for nutrients to reach, max for nutrients to limit
+}
+
+// GetMicronutrients returns the micronutrients with reference intakes, in report order
+func GetMicronutrients() []MicronutrientInfo {
+	return []MicronutrientInfo{
+		{Key: MicronutrientIron, Name: "Iron", Unit: "mg", Kind: GoalKindMin},
+		{Key: MicronutrientCalcium, Name: "Calcium", Unit: "mg", Kind: GoalKindMin},
+		{Key: MicronutrientVitaminB12, Name: "Vitamin B12", Unit: "µg", Kind: GoalKindMin},
+		{Key: MicronutrientVitaminD, Name: "Vitamin D", Unit: "µg", Kind: GoalKindMin},
+		{Key: MicronutrientFolate, Name: "Folate", Unit: "µg", Kind: GoalKindMin},
+		{Key: MicronutrientPotassium, Name: "Potassium", Unit: "mg", Kind: GoalKindMin},
+		{Key: MicronutrientSaturatedFat, Name: "Saturated fat", Unit: "g", Kind: GoalKindMax},
+	}
+}
+
+// AddMicronutrients adds every value of src into dst, allocating dst when needed
+func AddMicronutrients(dst, src map[string]float64) map[string]float64 {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]float64, len(src))
+	}
+	for key, value := range src {
+		dst[key] += value
+	}
+	return dst
+}
+
+// MicronutrientStatus compares the average intake of one micronutrient with its reference intake
+type MicronutrientStatus struct {
+	MicronutrientInfo
+	AvgIntake    float64 `json:"avgIntake"`
+	Reference    float64 `json:"reference"`    // RDA for nutrients to reach, upper limit for nutrients to limit
+	PercentOfRef float64 `json:"percentOfRef"` // average intake as a percentage of Reference
+	Status       string  `json:"status"`       // deficient, low, adequate or excess
+}
+
+// Deficiency report statuses
+const (
+	MicronutrientDeficient = "deficient"
+	MicronutrientLow       = "low"
+	MicronutrientAdequate  = "adequate"
+	MicronutrientExcess    = "excess"
+)
+
+// MicronutrientIntake is the average daily micronutrient intake over a range
+type MicronutrientIntake struct {
+	Range         DateRange          `json:"range"`
+	DaysLogged    int                `json:"daysLogged"`
+	MealCount     int                `json:"mealCount"`
+	MealsWithData int                `json:"mealsWithData"` // meals whose dish has micronutrient values
+	Averages      map[string]float64 `json:"averages"`      // per logged day
+}
+
+// DeficiencyReport compares average micronutrient intake with ICMR-NIN reference intakes
+type DeficiencyReport struct {
+	Range        DateRange             `json:"range"`
+	DaysLogged   int                   `json:"daysLogged"`
+	DataCoverage float64               `json:"dataCoverage"` // percentage of logged meals with micronutrient data
+	Nutrients    []MicronutrientStatus `json:"nutrients"`
+	Deficient    []string              `json:"deficient"` // keys of nutrients below the low threshold
+	Source       string                `json:"source"`
+	Notes        []string              `json:"notes,omitempty"`
+}
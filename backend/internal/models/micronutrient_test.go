@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMicronutrients(t *testing.T) {
+	// Act
+	total := AddMicronutrients(nil, map[string]float64{MicronutrientIron: 2.5})
+	total = AddMicronutrients(total, map[string]float64{MicronutrientIron: 1, MicronutrientCalcium: 100})
+	total = AddMicronutrients(total, nil)
+
+	// Assert
+	assert.Equal(t, 3.5, total[MicronutrientIron])
+	assert.Equal(t, 100.0, total[MicronutrientCalcium])
+	assert.Nil(t, AddMicronutrients(nil, nil))
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidCursor is returned for a cursor that cannot be decoded or belongs to another listing
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageCursor marks the boundary of a page in a sorted listing. Clients see it only as an
+// opaque token.
+type PageCursor struct {
+	Sort     string `bson:"s"`
+	Values   bson.A `bson:"v,omitempty"` // sort key values of the boundary item, ending with its ID
+	Ranked   bool   `bson:"r,omitempty"` // a position in a ranking held in memory rather than sort values
+	Offset   int    `bson:"o,omitempty"` // the position, for a ranked cursor
+	Backward bool   `bson:"b,omitempty"` // the page before the boundary rather than after it
+}
+
+// Encode returns the cursor as a URL-safe token
+func (c PageCursor) Encode() string {
+	data, err := bson.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a token made by Encode. An empty token is the first page and decodes to nil.
+func DecodeCursor(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor PageCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil || cursor.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// CursorPage holds the cursors to the pages either side of a page, nil at either end
+type CursorPage struct {
+	Next *PageCursor
+	Prev *PageCursor
+}
+
+// CursorRequest asks for one page of a cursor-paginated listing
+type CursorRequest struct {
+	Cursor       string // token from a previous page; empty for the first page
+	Limit        int
+	IncludeTotal bool // counting every match is slow on large collections, so it is optional
+}
+
+// CursorPagination describes a page of a cursor-paginated listing
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+	HasNext    bool   `json:"hasNext"`
+	HasPrev    bool   `json:"hasPrev"`
+	Total      *int   `json:"total,omitempty"` // only when requested
+
+	TotalIsEstimate bool `json:"totalIsEstimate,omitempty"` // more dishes match than a search ranks
+}
+
+// NewCursorPagination describes a page from its cursors and, when counted, the total
+func NewCursorPagination(limit int, page CursorPage, total *int) *CursorPagination {
+	pagination := &CursorPagination{
+		Limit:   limit,
+		HasNext: page.Next != nil,
+		HasPrev: page.Prev != nil,
+		Total:   total,
+	}
+	if page.Next != nil {
+		pagination.NextCursor = page.Next.Encode()
+	}
+	if page.Prev != nil {
+		pagination.PrevCursor = page.Prev.Encode()
+	}
+	return pagination
+}
+
+// OffsetPage slices one page from n ranked items held in memory, returning its bounds
+// and the cursors either side
+func OffsetPage(sort string, n int, cursor *PageCursor, limit int) (start, end int, page CursorPage) {
+	if cursor != nil && cursor.Offset > 0 {
+		start = cursor.Offset
+	}
+	if start > n {
+		start = n
+	}
+	end = start + limit
+	if end > n {
+		end = n
+	}
+	if end < n {
+		page.Next = &PageCursor{Sort: sort, Ranked: true, Offset: end}
+	}
+	if start > 0 {
+		prev := start - limit
+		if prev < 0 {
+			prev = 0
+		}
+		page.Prev = &PageCursor{Sort: sort, Ranked: true, Offset: prev}
+	}
+	return start, end, page
+}
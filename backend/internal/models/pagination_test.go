@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPageCursor_EncodeDecode(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID()
+	date := primitive.NewDateTimeFromTime(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC))
+	cursor := PageCursor{Sort: "date", Values: bson.A{date, id}, Backward: true}
+
+	// Act
+	decoded, err := DecodeCursor(cursor.Encode())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "date", decoded.Sort)
+	assert.True(t, decoded.Backward)
+	assert.Equal(t, bson.A{date, id}, decoded.Values)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantNil bool
+		wantErr error
+	}{
+		{"empty token is the first page", "", true, nil},
+		{"not base64", "not a cursor!", true, ErrInvalidCursor},
+		{"not a cursor document", "aGVsbG8", true, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			cursor, err := DecodeCursor(tt.token)
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantNil, cursor == nil)
+		})
+	}
+}
+
+func TestOffsetPage(t *testing.T) {
+	tests := []struct {
+		name               string
+		cursor             *PageCursor
+		wantStart, wantEnd int
+		wantNext, wantPrev *int
+	}{
+		{"first page", nil, 0, 10, intPtr(10), nil},
+		{"middle page", &PageCursor{Offset: 10}, 10, 20, intPtr(20), intPtr(0)},
+		{"last page", &PageCursor{Offset: 20}, 20, 25, nil, intPtr(10)},
+		{"offset past the end", &PageCursor{Offset: 40}, 25, 25, nil, intPtr(15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			start, end, page := OffsetPage(DishSortRelevance, 25, tt.cursor, 10)
+
+			// Assert
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+			assertOffset(t, tt.wantNext, page.Next)
+			assertOffset(t, tt.wantPrev, page.Prev)
+		})
+	}
+}
+
+func TestNewCursorPagination(t *testing.T) {
+	// Arrange
+	total := 42
+	page := CursorPage{Next: &PageCursor{Sort: DishSortName, Values: bson.A{"Poha", primitive.NewObjectID()}}}
+
+	// Act
+	pagination := NewCursorPagination(20, page, &total)
+
+	// Assert
+	assert.True(t, pagination.HasNext)
+	assert.False(t, pagination.HasPrev)
+	assert.NotEmpty(t, pagination.NextCursor)
+	assert.Empty(t, pagination.PrevCursor)
+	assert.Equal(t, 42, *pagination.Total)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func assertOffset(t *testing.T, want *int, cursor *PageCursor) {
+	t.Helper()
+	if want == nil {
+		assert.Nil(t, cursor)
+		return
+	}
+	if assert.NotNil(t, cursor) {
+		assert.True(t, cursor.Ranked)
+		assert.Equal(t, *want, cursor.Offset)
+	}
+}
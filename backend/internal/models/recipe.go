@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Recipe step kinds
+const (
+	StepKindPrep  = "prep"  // chopping, grinding, measuring
+	StepKindCook  = "cook"  // on the stove or in the oven
+	StepKindTadka = "tadka" // tempering spices in hot oil or ghee
+	StepKindRest  = "rest"  // soaking, marinating, fermenting or resting; no attention needed
+	StepKindServe = "serve"
+)
+
+// Heat levels for stovetop steps
+const (
+	HeatLow    = "low"
+	HeatMedium = "medium"
+	HeatHigh   = "high"
+)
+
+// minutesPerWhistle estimates pressure cooker time when a step gives whistles but no duration
+const minutesPerWhistle = 3
+
+// RecipeStep is one step of a dish's method. Steps are kept in cooking order.
+type RecipeStep struct {
+	Instruction  string   `bson:"instruction" json:"instruction" validate:"required,max=1000"`
+	Kind         string   `bson:"kind,omitempty" json:"kind,omitempty" validate:"omitempty,oneof=prep cook tadka rest serve"`
+	Duration     int      `bson:"duration,omitempty" json:"duration,omitempty" validate:"min=0"`                   // minutes
+	Heat         string   `bson:"heat,omitempty" json:"heat,omitempty" validate:"omitempty,oneof=low medium high"` // stovetop flame
+	TemperatureC int      `bson:"temperatureC,omitempty" json:"temperatureC,omitempty" validate:"min=0,max=300"`   // oven or oil temperature
+	Whistles     int      `bson:"whistles,omitempty" json:"whistles,omitempty" validate:"min=0,max=20"`            // pressure cooker whistles
+	Equipment    []string `bson:"equipment,omitempty" json:"equipment,omitempty"`                                  // e.g. "pressure cooker", "tawa"
+	Notes        string   `bson:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// CookModeStep is a recipe step placed on the cooking timeline
+type CookModeStep struct {
+	Number int `json:"number"`
+	RecipeStep
+	Minutes   int  `json:"minutes"`             // time the step takes on the timeline
+	Estimated bool `json:"estimated,omitempty"` // minutes were estimated from pressure cooker whistles
+	StartsAt  int  `json:"startsAt"`            // minutes from the start of cooking
+	EndsAt    int  `json:"endsAt"`
+	Timer     bool `json:"timer"`   // the step has a duration worth timing
+	Passive   bool `json:"passive"` // the cook is free during the step
+}
+
+// CookMode is a dish's method laid out as a step-by-step timeline
+type CookMode struct {
+	DishID        string         `json:"dishId"`
+	Name          string         `json:"name"`
+	Servings      int            `json:"servings"`
+	Steps         []CookModeStep `json:"steps"`
+	TotalMinutes  int            `json:"totalMinutes"`
+	ActiveMinutes int            `json:"activeMinutes"` // total minus rest steps
+	StatedMinutes int            `json:"statedMinutes"` // the dish's prep time plus cook time
+	Equipment     []string       `json:"equipment"`
+}
+
+// Equipment returns the equipment the dish's steps use, in order of first use
+func (d *Dish) Equipment() []string {
+	equipment := []string{}
+	seen := map[string]bool{}
+	for _, step := range d.Steps {
+		for _, item := range step.Equipment {
+			key := strings.ToLower(strings.TrimSpace(item))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			equipment = append(equipment, strings.TrimSpace(item))
+		}
+	}
+	return equipment
+}
+
+// BuildCookMode lays a dish's steps out on a timeline with cumulative start and end
+// times. Steps run one after another; pressure cooker steps without a duration are
+// estimated from their whistles.
+func BuildCookMode(d *Dish) (*CookMode, error) {
+	if len(d.Steps) == 0 {
+		return nil, errors.New("dish has no recipe steps")
+	}
+
+	mode := &CookMode{
+		DishID:        d.ID.Hex(),
+		Name:          d.Name,
+		Servings:      d.Servings,
+		Steps:         make([]CookModeStep, 0, len(d.Steps)),
+		StatedMinutes: d.PrepTime + d.CookTime,
+		Equipment:     d.Equipment(),
+	}
+
+	elapsed := 0
+	for i, step := range d.Steps {
+		if step.Kind == "" {
+			step.Kind = StepKindCook
+		}
+
+		minutes, estimated := step.Duration, false
+		if minutes == 0 && step.Whistles > 0 {
+			minutes, estimated = step.Whistles*minutesPerWhistle, true
+		}
+		passive := step.Kind == StepKindRest
+
+		mode.Steps = append(mode.Steps, CookModeStep{
+			Number:     i + 1,
+			RecipeStep: step,
+			Minutes:    minutes,
+			Estimated:  estimated,
+			StartsAt:   elapsed,
+			EndsAt:     elapsed + minutes,
+			Timer:      minutes > 0,
+			Passive:    passive,
+		})
+
+		elapsed += minutes
+		if !passive {
+			mode.ActiveMinutes += minutes
+		}
+	}
+	mode.TotalMinutes = elapsed
+	return mode, nil
+}
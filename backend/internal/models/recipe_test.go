@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBuildCookMode(t *testing.T) {
+	// Arrange
+	dish := &Dish{
+		ID:       primitive.NewObjectID(),
+		Name:     "Dal Tadka",
+		Servings: 4,
+		PrepTime: 15,
+		CookTime: 25,
+		Steps: []RecipeStep{
+			{Instruction: "Rinse the lentils", Kind: StepKindPrep, Duration: 5, Equipment: []string{"strainer"}},
+			{Instruction: "Pressure cook the lentils", Whistles: 3, Heat: HeatMedium, Equipment: []string{"Pressure Cooker"}},
+			{Instruction: "Let the pressure release", Kind: StepKindRest, Duration: 10},
+			{Instruction: "Pour the tadka over the dal", Kind: StepKindTadka, Duration: 2, Equipment: []string{"tadka pan", "pressure cooker"}},
+			{Instruction: "Garnish with coriander", Kind: StepKindServe},
+		},
+	}
+
+	// Act
+	mode, err := BuildCookMode(dish)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, dish.ID.Hex(), mode.DishID)
+	assert.Equal(t, 26, mode.TotalMinutes)
+	assert.Equal(t, 16, mode.ActiveMinutes)
+	assert.Equal(t, 40, mode.StatedMinutes)
+	assert.Equal(t, []string{"strainer", "Pressure Cooker", "tadka pan"}, mode.Equipment)
+	require.Len(t, mode.Steps, 5)
+
+	cooker := mode.Steps[1]
+	assert.Equal(t, 2, cooker.Number)
+	assert.Equal(t, StepKindCook, cooker.Kind, "steps without a kind are cooking steps")
+	assert.Equal(t, 9, cooker.Minutes)
+	assert.True(t, cooker.Estimated)
+	assert.Equal(t, 5, cooker.StartsAt)
+	assert.Equal(t, 14, cooker.EndsAt)
+
+	rest := mode.Steps[2]
+	assert.True(t, rest.Passive)
+	assert.Equal(t, 24, rest.EndsAt)
+
+	serve := mode.Steps[4]
+	assert.Equal(t, 26, serve.StartsAt)
+	assert.False(t, serve.Timer)
+}
+
+func TestBuildCookMode_NoSteps(t *testing.T) {
+	// Arrange
+	dish := &Dish{Name: "Plain Rice"}
+
+	// Act
+	mode, err := BuildCookMode(dish)
+
+	// Assert
+	assert.Nil(t, mode)
+	assert.EqualError(t, err, "dish has no recipe steps")
+}
@@ -10,6 +10,8 @@ type PaginationResponse struct {
 	TotalPages int  `json:"totalPages"`
 	HasNext    bool `json:"hasNext"`
 	HasPrev    bool `json:"hasPrev"`
+
+	TotalIsEstimate bool `json:"totalIsEstimate,omitempty"` // more dishes match than a search ranks
 }
 
 // SuccessResponse represents a successful API response
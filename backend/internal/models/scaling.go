@@ -0,0 +1,35 @@
+package models
+
+// Unit systems for scaled recipes
+const (
+	UnitsMetric    = "metric"    // grams, kilograms, millilitres and litres
+	UnitsHousehold = "household" // katori, glass, cup, spoons, pinches and pieces
+)
+
+// ScaledIngredient is an ingredient quantity converted for a different number of servings
+type ScaledIngredient struct {
+	Name          string             `json:"name"`
+	IngredientKey string             `json:"ingredientKey,omitempty"`
+	Quantity      float64            `json:"quantity"`
+	Unit          string             `json:"unit"`
+	Display       string             `json:"display"`         // e.g. "1½ tsp"
+	Grams         float64            `json:"grams,omitempty"` // weight before rounding, when known
+	Adjusted      bool               `json:"adjusted"`        // scaled less than proportionally, as for salt and spices
+	Original      IngredientQuantity `json:"original"`
+}
+
+// ScaledDish is a dish's ingredients and nutrition for a chosen number of servings
+type ScaledDish struct {
+	DishID           string             `json:"dishId"`
+	Name             string             `json:"name"`
+	OriginalServings int                `json:"originalServings"`
+	Servings         int                `json:"servings"`
+	Factor           float64            `json:"factor"`
+	Units            string             `json:"units"`
+	Ingredients      []ScaledIngredient `json:"ingredients"`
+	Calories         int                `json:"calories"`  // per serving
+	Nutrition        Nutrition          `json:"nutrition"` // per serving
+	TotalCalories    int                `json:"totalCalories"`
+	TotalNutrition   Nutrition          `json:"totalNutrition"`
+	Notes            []string           `json:"notes,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// DishSuggestion is one type-ahead suggestion for the dish search box
+type DishSuggestion struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`
+	Cuisine string  `json:"cuisine"`
+	Image   string  `json:"image"`
+	Matched string  `json:"matched,omitempty"` // alias or ingredient that matched, when not the name
+	Score   float64 `json:"score"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// DishSimilarity breaks down how closely one dish resembles another, each part from 0 to 1
+type DishSimilarity struct {
+	Score       float64 `json:"score"`       // weighted sum of the parts
+	Ingredients float64 `json:"ingredients"` // Jaccard overlap of the canonical ingredients
+	Cuisine     float64 `json:"cuisine"`
+	Type        float64 `json:"type"`
+	Nutrition   float64 `json:"nutrition"`   // closeness of calories and macro shares
+	DietaryTags float64 `json:"dietaryTags"` // share of the dish's tags the other dish keeps
+}
+
+// SimilarDish is a dish suggested in place of another
+type SimilarDish struct {
+	Dish              *DishResponse  `json:"dish"`
+	Similarity        DishSimilarity `json:"similarity"`
+	SharedIngredients []string       `json:"sharedIngredients"`
+}
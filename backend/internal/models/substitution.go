@@ -0,0 +1,48 @@
+package models
+
+// IngredientSubstitution is a swap from one canonical ingredient to another
+type IngredientSubstitution struct {
+	From        string   `json:"from"` // catalog key
+	FromName    string   `json:"fromName"`
+	To          string   `json:"to"`
+	ToName      string   `json:"toName"`
+	Ratio       float64  `json:"ratio"`       // grams of the substitute per gram of the original
+	Goals       []string `json:"goals"`       // dietary goals the swap serves, e.g. "vegan"
+	AddsTags    []string `json:"addsTags"`    // tags the dish can gain once nothing else conflicts
+	RemovesTags []string `json:"removesTags"` // tags the dish loses, e.g. "nut-free" when adding cashews
+	Note        string   `json:"note,omitempty"`
+
+	// Change per 100 g of the original ingredient
+	CaloriesDelta  int       `json:"caloriesDelta"`
+	NutritionDelta Nutrition `json:"nutritionDelta"`
+}
+
+// SubstitutedIngredient is one ingredient of a transformed dish
+type SubstitutedIngredient struct {
+	Name         string                  `json:"name"`
+	Quantity     *IngredientQuantity     `json:"quantity,omitempty"`
+	Original     string                  `json:"original,omitempty"` // the ingredient it replaces
+	Substitution *IngredientSubstitution `json:"substitution,omitempty"`
+}
+
+// SubstitutedDish is a dish transformed to meet one or more dietary goals
+type SubstitutedDish struct {
+	DishID      string                  `json:"dishId"`
+	Name        string                  `json:"name"`
+	Goals       []string                `json:"goals"`
+	Ingredients []SubstitutedIngredient `json:"ingredients"`
+	Unresolved  []string                `json:"unresolved"` // conflicting ingredients with no known substitute
+	Type        string                  `json:"type"`
+	DietaryTags []string                `json:"dietaryTags"`
+	Calories    int                     `json:"calories"`  // per serving
+	Nutrition   Nutrition               `json:"nutrition"` // per serving
+	Notes       []string                `json:"notes,omitempty"`
+}
+
+// DishSwapSuggestion lists swaps that would make a dish fit one of the user's dietary preferences
+type DishSwapSuggestion struct {
+	Preference string                   `json:"preference"`
+	Conflicts  []string                 `json:"conflicts"`
+	Swaps      []IngredientSubstitution `json:"swaps"`
+	Resolvable bool                     `json:"resolvable"` // every conflict has a swap
+}
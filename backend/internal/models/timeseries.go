@@ -0,0 +1,294 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Supported roll-up granularities for time series
+const (
+	RollupWeek  = "week"
+	RollupMonth = "month"
+)
+
+// movingAverageWindow is the number of trailing days in a moving average
+const movingAverageWindow = 7
+
+// SeriesOptions controls the optional aggregates returned with a daily series
+type SeriesOptions struct {
+	Rollup        string // "", "week" or "month"
+	MovingAverage bool   // include 7-day moving averages
+}
+
+// Validate checks that the series options are supported
+func (o SeriesOptions) Validate() error {
+	switch o.Rollup {
+	case "", RollupWeek, RollupMonth:
+		return nil
+	default:
+		return fmt.Errorf("invalid rollup '%s', supported values: week, month", o.Rollup)
+	}
+}
+
+// NutritionRollup aggregates daily nutrition over a week or month
+type NutritionRollup struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	DaysLogged  int       `json:"daysLogged"`
+	MealCount   int       `json:"mealCount"`
+	Calories    int       `json:"calories"`
+	Protein     int       `json:"protein"`
+	Carbs       int       `json:"carbs"`
+	Fat         int       `json:"fat"`
+	Fiber       int       `json:"fiber"`
+	Sodium      int       `json:"sodium"`
+	Sugar       int       `json:"sugar"`
+
+	// Averages per logged day
+	AvgCalories float64 `json:"avgCalories"`
+	AvgProtein  float64 `json:"avgProtein"`
+	AvgCarbs    float64 `json:"avgCarbs"`
+	AvgFat      float64 `json:"avgFat"`
+	AvgFiber    float64 `json:"avgFiber"`
+	AvgSodium   float64 `json:"avgSodium"`
+	AvgSugar    float64 `json:"avgSugar"`
+}
+
+// NutritionMovingAverage is the trailing 7-day average of nutrition ending on Date.
+// Only days with logged meals count towards the average.
+type NutritionMovingAverage struct {
+	Date       time.Time `json:"date"`
+	DaysLogged int       `json:"daysLogged"`
+	Calories   float64   `json:"calories"`
+	Protein    float64   `json:"protein"`
+	Carbs      float64   `json:"carbs"`
+	Fat        float64   `json:"fat"`
+	Fiber      float64   `json:"fiber"`
+	Sodium     float64   `json:"sodium"`
+	Sugar      float64   `json:"sugar"`
+}
+
+// MealCountRollup aggregates daily meal counts over a week or month
+type MealCountRollup struct {
+	PeriodStart       time.Time `json:"periodStart"`
+	PeriodEnd         time.Time `json:"periodEnd"`
+	DaysLogged        int       `json:"daysLogged"`
+	MealCount         int       `json:"mealCount"`
+	Calories          int       `json:"calories"`
+	AvgCaloriesPerDay float64   `json:"avgCaloriesPerDay"`
+}
+
+// MealCountMovingAverage is the trailing 7-day average of meal counts ending on Date
+type MealCountMovingAverage struct {
+	Date       time.Time `json:"date"`
+	DaysLogged int       `json:"daysLogged"`
+	MealCount  float64   `json:"mealCount"`
+	Calories   float64   `json:"calories"`
+}
+
+// DayKey returns the YYYY-MM-DD calendar day of t in loc
+func DayKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02")
+}
+
+// FillNutritionSeries returns one entry per day of the range in chronological order.
+// Days without meals are included with zero values and HasData set to false.
+func FillNutritionSeries(r DateRange, days []DailyNutrition) []DailyNutrition {
+	loc := r.From.Location()
+	byDay := make(map[string]DailyNutrition, len(days))
+	for _, d := range days {
+		byDay[DayKey(d.Date, loc)] = d
+	}
+
+	series := make([]DailyNutrition, 0, r.Days())
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		entry, exists := byDay[DayKey(day, loc)]
+		if !exists {
+			entry = DailyNutrition{}
+		}
+		entry.Date = day
+		entry.HasData = entry.MealCount > 0
+		series = append(series, entry)
+	}
+	return series
+}
+
+// FillMealCountSeries returns one entry per day of the range in chronological order.
+// Days without meals are included with zero values and HasData set to false.
+func FillMealCountSeries(r DateRange, days []DailyMealCount) []DailyMealCount {
+	loc := r.From.Location()
+	byDay := make(map[string]DailyMealCount, len(days))
+	for _, d := range days {
+		byDay[DayKey(d.Date, loc)] = d
+	}
+
+	series := make([]DailyMealCount, 0, r.Days())
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		entry, exists := byDay[DayKey(day, loc)]
+		if !exists {
+			entry = DailyMealCount{}
+		}
+		entry.Date = day
+		entry.HasData = entry.MealCount > 0
+		series = append(series, entry)
+	}
+	return series
+}
+
+// RollupNutrition groups a dense daily series into weekly (Monday-based) or monthly buckets.
+// Buckets at the edges of the series only cover the days inside it.
+func RollupNutrition(series []DailyNutrition, granularity string) []NutritionRollup {
+	var rollups []NutritionRollup
+	var current *NutritionRollup
+	var currentBucket time.Time
+
+	for _, day := range series {
+		bucket := bucketStart(day.Date, granularity)
+		if current == nil || !bucket.Equal(currentBucket) {
+			if current != nil {
+				rollups = append(rollups, finishNutritionRollup(*current))
+			}
+			current = &NutritionRollup{PeriodStart: day.Date}
+			currentBucket = bucket
+		}
+
+		current.PeriodEnd = endOfDay(day.Date)
+		if !day.HasData {
+			continue
+		}
+		current.DaysLogged++
+		current.MealCount += day.MealCount
+		current.Calories += day.Calories
+		current.Protein += day.Protein
+		current.Carbs += day.Carbs
+		current.Fat += day.Fat
+		current.Fiber += day.Fiber
+		current.Sodium += day.Sodium
+		current.Sugar += day.Sugar
+	}
+
+	if current != nil {
+		rollups = append(rollups, finishNutritionRollup(*current))
+	}
+	return rollups
+}
+
+// finishNutritionRollup fills in the per-day averages of a rollup
+func finishNutritionRollup(r NutritionRollup) NutritionRollup {
+	if r.DaysLogged == 0 {
+		return r
+	}
+	days := float64(r.DaysLogged)
+	r.AvgCalories = float64(r.Calories) / days
+	r.AvgProtein = float64(r.Protein) / days
+	r.AvgCarbs = float64(r.Carbs) / days
+	r.AvgFat = float64(r.Fat) / days
+	r.AvgFiber = float64(r.Fiber) / days
+	r.AvgSodium = float64(r.Sodium) / days
+	r.AvgSugar = float64(r.Sugar) / days
+	return r
+}
+
+// NutritionMovingAverages computes trailing 7-day averages over a dense daily series.
+// The first days of the series use the shorter window available.
+func NutritionMovingAverages(series []DailyNutrition) []NutritionMovingAverage {
+	averages := make([]NutritionMovingAverage, len(series))
+	for i, day := range series {
+		avg := NutritionMovingAverage{Date: day.Date}
+		for j := i; j >= 0 && j > i-movingAverageWindow; j-- {
+			if !series[j].HasData {
+				continue
+			}
+			avg.DaysLogged++
+			avg.Calories += float64(series[j].Calories)
+			avg.Protein += float64(series[j].Protein)
+			avg.Carbs += float64(series[j].Carbs)
+			avg.Fat += float64(series[j].Fat)
+			avg.Fiber += float64(series[j].Fiber)
+			avg.Sodium += float64(series[j].Sodium)
+			avg.Sugar += float64(series[j].Sugar)
+		}
+		if avg.DaysLogged > 0 {
+			days := float64(avg.DaysLogged)
+			avg.Calories /= days
+			avg.Protein /= days
+			avg.Carbs /= days
+			avg.Fat /= days
+			avg.Fiber /= days
+			avg.Sodium /= days
+			avg.Sugar /= days
+		}
+		averages[i] = avg
+	}
+	return averages
+}
+
+// RollupMealCounts groups a dense daily meal count series into weekly or monthly buckets
+func RollupMealCounts(series []DailyMealCount, granularity string) []MealCountRollup {
+	var rollups []MealCountRollup
+	var current *MealCountRollup
+	var currentBucket time.Time
+
+	for _, day := range series {
+		bucket := bucketStart(day.Date, granularity)
+		if current == nil || !bucket.Equal(currentBucket) {
+			if current != nil {
+				rollups = append(rollups, finishMealCountRollup(*current))
+			}
+			current = &MealCountRollup{PeriodStart: day.Date}
+			currentBucket = bucket
+		}
+
+		current.PeriodEnd = endOfDay(day.Date)
+		if !day.HasData {
+			continue
+		}
+		current.DaysLogged++
+		current.MealCount += day.MealCount
+		current.Calories += day.Calories
+	}
+
+	if current != nil {
+		rollups = append(rollups, finishMealCountRollup(*current))
+	}
+	return rollups
+}
+
+// finishMealCountRollup fills in the per-day average of a rollup
+func finishMealCountRollup(r MealCountRollup) MealCountRollup {
+	if r.DaysLogged > 0 {
+		r.AvgCaloriesPerDay = float64(r.Calories) / float64(r.DaysLogged)
+	}
+	return r
+}
+
+// MealCountMovingAverages computes trailing 7-day averages over a dense daily meal count series
+func MealCountMovingAverages(series []DailyMealCount) []MealCountMovingAverage {
+	averages := make([]MealCountMovingAverage, len(series))
+	for i, day := range series {
+		avg := MealCountMovingAverage{Date: day.Date}
+		for j := i; j >= 0 && j > i-movingAverageWindow; j-- {
+			if !series[j].HasData {
+				continue
+			}
+			avg.DaysLogged++
+			avg.MealCount += float64(series[j].MealCount)
+			avg.Calories += float64(series[j].Calories)
+		}
+		if avg.DaysLogged > 0 {
+			avg.MealCount /= float64(avg.DaysLogged)
+			avg.Calories /= float64(avg.DaysLogged)
+		}
+		averages[i] = avg
+	}
+	return averages
+}
+
+// bucketStart returns the first day of the week (Monday) or month containing t
+func bucketStart(t time.Time, granularity string) time.Time {
+	if granularity == RollupMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t.AddDate(0, 0, -offset))
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func janDay(d int) time.Time {
+	return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFillNutritionSeries(t *testing.T) {
+	// Arrange - logged days out of order, with a gap
+	r := NewDateRange(janDay(1), janDay(5))
+	logged := []DailyNutrition{
+		{Date: janDay(4).Add(13 * time.Hour), Calories: 1800, MealCount: 3},
+		{Date: janDay(2).Add(8 * time.Hour), Calories: 2100, MealCount: 2},
+	}
+
+	// Act
+	series := FillNutritionSeries(r, logged)
+
+	// Assert
+	require.Len(t, series, 5)
+	for i, entry := range series {
+		assert.Equal(t, janDay(i+1), entry.Date)
+	}
+	assert.False(t, series[0].HasData)
+	assert.True(t, series[1].HasData)
+	assert.Equal(t, 2100, series[1].Calories)
+	assert.False(t, series[2].HasData)
+	assert.Equal(t, 0, series[2].Calories)
+	assert.True(t, series[3].HasData)
+	assert.Equal(t, 1800, series[3].Calories)
+}
+
+func TestFillMealCountSeries(t *testing.T) {
+	r := NewDateRange(janDay(1), janDay(3))
+	series := FillMealCountSeries(r, []DailyMealCount{{Date: janDay(3), MealCount: 2, Calories: 900}})
+
+	require.Len(t, series, 3)
+	assert.False(t, series[0].HasData)
+	assert.False(t, series[1].HasData)
+	assert.True(t, series[2].HasData)
+	assert.Equal(t, 2, series[2].MealCount)
+}
+
+func TestRollupNutrition_Weekly(t *testing.T) {
+	// Arrange - 1 Jan 2024 is a Monday; the range spans two weeks and one day
+	r := NewDateRange(janDay(1), janDay(15))
+	series := FillNutritionSeries(r, []DailyNutrition{
+		{Date: janDay(1), Calories: 2000, Protein: 60, MealCount: 3},
+		{Date: janDay(3), Calories: 1000, Protein: 40, MealCount: 1},
+		{Date: janDay(9), Calories: 1500, MealCount: 2},
+	})
+
+	// Act
+	rollups := RollupNutrition(series, RollupWeek)
+
+	// Assert
+	require.Len(t, rollups, 3)
+	assert.Equal(t, janDay(1), rollups[0].PeriodStart)
+	assert.Equal(t, time.Date(2024, 1, 7, 23, 59, 59, 999999999, time.UTC), rollups[0].PeriodEnd)
+	assert.Equal(t, 2, rollups[0].DaysLogged)
+	assert.Equal(t, 3000, rollups[0].Calories)
+	assert.Equal(t, 1500.0, rollups[0].AvgCalories)
+	assert.Equal(t, 50.0, rollups[0].AvgProtein)
+
+	assert.Equal(t, 1, rollups[1].DaysLogged)
+	assert.Equal(t, 1500.0, rollups[1].AvgCalories)
+
+	assert.Equal(t, janDay(15), rollups[2].PeriodStart)
+	assert.Equal(t, 0, rollups[2].DaysLogged)
+	assert.Equal(t, 0.0, rollups[2].AvgCalories)
+}
+
+func TestRollupNutrition_Monthly(t *testing.T) {
+	r := NewDateRange(time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC))
+	series := FillNutritionSeries(r, nil)
+
+	rollups := RollupNutrition(series, RollupMonth)
+
+	require.Len(t, rollups, 2)
+	assert.Equal(t, time.January, rollups[0].PeriodStart.Month())
+	assert.Equal(t, time.February, rollups[1].PeriodStart.Month())
+}
+
+func TestNutritionMovingAverages(t *testing.T) {
+	// Arrange - 10 days, logged every day except day 2
+	var logged []DailyNutrition
+	for d := 1; d <= 10; d++ {
+		if d == 2 {
+			continue
+		}
+		logged = append(logged, DailyNutrition{Date: janDay(d), Calories: d * 100, MealCount: 1})
+	}
+	series := FillNutritionSeries(NewDateRange(janDay(1), janDay(10)), logged)
+
+	// Act
+	averages := NutritionMovingAverages(series)
+
+	// Assert
+	require.Len(t, averages, 10)
+
+	// Day 1 only has itself in the window
+	assert.Equal(t, 1, averages[0].DaysLogged)
+	assert.Equal(t, 100.0, averages[0].Calories)
+
+	// Day 2 is unlogged, so it does not drag the average down
+	assert.Equal(t, 1, averages[1].DaysLogged)
+	assert.Equal(t, 100.0, averages[1].Calories)
+
+	// Day 7 window covers days 1-7 minus day 2
+	assert.Equal(t, 6, averages[6].DaysLogged)
+	assert.InDelta(t, (100.0+300+400+500+600+700)/6, averages[6].Calories, 0.001)
+
+	// Day 10 window covers days 4-10
+	assert.Equal(t, 7, averages[9].DaysLogged)
+	assert.InDelta(t, 700.0, averages[9].Calories, 0.001)
+}
+
+func TestMealCountMovingAverages(t *testing.T) {
+	series := FillMealCountSeries(NewDateRange(janDay(1), janDay(2)), []DailyMealCount{
+		{Date: janDay(1), MealCount: 2, Calories: 1000},
+		{Date: janDay(2), MealCount: 4, Calories: 2000},
+	})
+
+	averages := MealCountMovingAverages(series)
+
+	require.Len(t, averages, 2)
+	assert.Equal(t, 3.0, averages[1].MealCount)
+	assert.Equal(t, 1500.0, averages[1].Calories)
+}
+
+func TestRollupMealCounts(t *testing.T) {
+	series := FillMealCountSeries(NewDateRange(janDay(1), janDay(8)), []DailyMealCount{
+		{Date: janDay(1), MealCount: 2, Calories: 1000},
+		{Date: janDay(8), MealCount: 3, Calories: 1500},
+	})
+
+	rollups := RollupMealCounts(series, RollupWeek)
+
+	require.Len(t, rollups, 2)
+	assert.Equal(t, 2, rollups[0].MealCount)
+	assert.Equal(t, 1000.0, rollups[0].AvgCaloriesPerDay)
+	assert.Equal(t, 3, rollups[1].MealCount)
+}
+
+func TestSeriesOptions_Validate(t *testing.T) {
+	assert.NoError(t, SeriesOptions{}.Validate())
+	assert.NoError(t, SeriesOptions{Rollup: RollupWeek}.Validate())
+	assert.NoError(t, SeriesOptions{Rollup: RollupMonth}.Validate())
+	assert.Error(t, SeriesOptions{Rollup: "year"}.Validate())
+}
+
+func TestFillNutritionSeries_UserTimeZone(t *testing.T) {
+	// Arrange - a 23:00 IST snack is stored as 17:30 UTC and must stay on the IST day
+	ist, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	r := NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, ist), time.Date(2024, 1, 2, 0, 0, 0, 0, ist))
+	snack := time.Date(2024, 1, 1, 23, 0, 0, 0, ist).UTC()
+
+	// Act
+	series := FillNutritionSeries(r, []DailyNutrition{{Date: snack, Calories: 300, MealCount: 1}})
+
+	// Assert
+	require.Len(t, series, 2)
+	assert.Equal(t, "2024-01-01", DayKey(snack, ist))
+	assert.True(t, series[0].HasData)
+	assert.Equal(t, 300, series[0].Calories)
+	assert.False(t, series[1].HasData)
+}
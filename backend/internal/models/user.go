@@ -13,6 +13,10 @@ type User struct {
 	Email    string             `bson:"email" json:"email" validate:"required,email"`
 	Password string             `bson:"password" json:"-" validate:"required,min=6"`
 	Profile  UserProfile        `bson:"profile" json:"profile"`
+	Role     string             `bson:"role,omitempty" json:"role,omitempty"` // empty for regular users
+
+	// Members of a household can see each other's household dishes
+	HouseholdID *primitive.ObjectID `bson:"householdId,omitempty" json:"householdId,omitempty"`
 
 	// User metadata
 	Favorites   []primitive.ObjectID `bson:"favorites" json:"favorites"`
@@ -23,11 +27,45 @@ type User struct {
 
 // UserProfile contains user's dietary preferences and nutrition goals
 type UserProfile struct {
-	DietaryPreferences []string       `bson:"dietaryPreferences" json:"dietaryPreferences"`
-	SpiceLevel         string         `bson:"spiceLevel" json:"spiceLevel" validate:"omitempty,oneof=mild medium hot extra-hot"`
-	FavoriteRegions    []string       `bson:"favoriteRegions" json:"favoriteRegions"`
-	Avatar             string         `bson:"avatar" json:"avatar"`
-	NutritionGoals     NutritionGoals `bson:"nutritionGoals" json:"nutritionGoals"`
+	DietaryPreferences []string        `bson:"dietaryPreferences" json:"dietaryPreferences"`
+	Allergies          []string        `bson:"allergies,omitempty" json:"allergies" validate:"omitempty,dive,oneof=peanut tree-nuts dairy gluten sesame shellfish soy egg mustard"` // dishes containing these are never suggested
+	DietRules          []string        `bson:"dietRules,omitempty" json:"dietRules" validate:"omitempty,dive,oneof=jain satvik navratri ekadashi halal"`                            // dishes must follow these rule sets
+	FastingPeriods     []FastingPeriod `bson:"fastingPeriods,omitempty" json:"fastingPeriods,omitempty" validate:"omitempty,dive"`
+	HealthConditions   []string        `bson:"healthConditions,omitempty" json:"healthConditions" validate:"omitempty,dive,oneof=diabetes hypertension pcos renal"` // tighten goals and add intake limits
+	SpiceLevel         string          `bson:"spiceLevel" json:"spiceLevel" validate:"omitempty,oneof=mild medium hot extra-hot"`
+	FavoriteRegions    []string        `bson:"favoriteRegions" json:"favoriteRegions"`
+	Avatar             string          `bson:"avatar" json:"avatar"`
+	NutritionGoals     NutritionGoals  `bson:"nutritionGoals" json:"nutritionGoals"`
+	TimeZone           string          `bson:"timeZone" json:"timeZone" validate:"omitempty,timezone"` // IANA name, e.g. Asia/Kolkata
+	BodyMetrics        *BodyMetrics    `bson:"bodyMetrics,omitempty" json:"bodyMetrics,omitempty"`
+	GoalsSource        string          `bson:"goalsSource,omitempty" json:"goalsSource,omitempty" validate:"omitempty,oneof=default calculated custom"`
+
+	// Ingredients the user would rather avoid; listings can leave them out on request
+	DislikedIngredients []string `bson:"dislikedIngredients,omitempty" json:"dislikedIngredients" validate:"omitempty,max=50,dive,min=2,max=50"`
+}
+
+// Location returns the profile's time zone, falling back to UTC when unset or unknown
+func (p UserProfile) Location() *time.Location {
+	if p.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ResolvedGoalsSource returns where the profile's goals came from, treating
+// profiles saved before goals were tracked as custom or default
+func (p UserProfile) ResolvedGoalsSource() string {
+	if p.GoalsSource != "" {
+		return p.GoalsSource
+	}
+	if p.NutritionGoals == (NutritionGoals{}) {
+		return GoalsSourceDefault
+	}
+	return GoalsSourceCustom
 }
 
 // NutritionGoals represents daily nutrition targets
@@ -38,6 +76,10 @@ type NutritionGoals struct {
 	Fat           int `bson:"fat" json:"fat" validate:"min=0"`         // grams
 	Fiber         int `bson:"fiber" json:"fiber" validate:"min=0"`     // grams
 	Sodium        int `bson:"sodium" json:"sodium" validate:"min=0"`   // milligrams
+	Sugar         int `bson:"sugar" json:"sugar" validate:"min=0"`     // grams
+
+	// How each target is judged; unset rules use the defaults in DefaultGoalSemantics
+	Semantics GoalSemantics `bson:"semantics" json:"semantics"`
 }
 
 // UserRegistrationRequest represents the request for user registration
@@ -45,6 +87,7 @@ type UserRegistrationRequest struct {
 	Name     string `json:"name" validate:"required,min=2,max=50"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	TimeZone string `json:"timeZone" validate:"omitempty,timezone"`
 }
 
 // UserLoginRequest represents the request for user login
@@ -58,23 +101,100 @@ type UserResponse struct {
 	ID      string      `json:"id"`
 	Name    string      `json:"name"`
 	Email   string      `json:"email"`
+	Role    string      `json:"role,omitempty"`
 	Profile UserProfile `json:"profile"`
+
+	HouseholdID string `json:"householdId,omitempty"`
 }
 
 // ProfileUpdateRequest represents the request for updating user profile
 type ProfileUpdateRequest struct {
-	Name    string      `json:"name,omitempty" validate:"omitempty,min=2,max=50"`
-	Profile UserProfile `json:"profile"`
+	Name    string       `json:"name,omitempty" validate:"omitempty,min=2,max=50"`
+	Profile ProfilePatch `json:"profile"`
+}
+
+// ProfilePatch represents a partial profile update; only the fields present are changed,
+// so a form that edits some settings never clears the others. Goals change through the goals endpoints.
+type ProfilePatch struct {
+	DietaryPreferences *[]string        `json:"dietaryPreferences"`
+	Allergies          *[]string        `json:"allergies" validate:"omitempty,dive,oneof=peanut tree-nuts dairy gluten sesame shellfish soy egg mustard"`
+	DietRules          *[]string        `json:"dietRules" validate:"omitempty,dive,oneof=jain satvik navratri ekadashi halal"`
+	FastingPeriods     *[]FastingPeriod `json:"fastingPeriods" validate:"omitempty,dive"`
+	HealthConditions   *[]string        `json:"healthConditions" validate:"omitempty,dive,oneof=diabetes hypertension pcos renal"`
+	SpiceLevel         *string          `json:"spiceLevel" validate:"omitempty,oneof=mild medium hot extra-hot"`
+	FavoriteRegions    *[]string        `json:"favoriteRegions"`
+	Avatar             *string          `json:"avatar"`
+	TimeZone           *string          `json:"timeZone" validate:"omitempty,timezone"`
+	BodyMetrics        *BodyMetrics     `json:"bodyMetrics"`
+
+	DislikedIngredients *[]string `json:"dislikedIngredients" validate:"omitempty,max=50,dive,min=2,max=50"`
+}
+
+// Apply copies the fields present in the patch onto the profile
+func (p ProfilePatch) Apply(profile *UserProfile) {
+	setStrings := func(dst *[]string, src *[]string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setString := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+
+	setStrings(&profile.DietaryPreferences, p.DietaryPreferences)
+	setStrings(&profile.Allergies, p.Allergies)
+	setStrings(&profile.DietRules, p.DietRules)
+	setStrings(&profile.HealthConditions, p.HealthConditions)
+	setStrings(&profile.FavoriteRegions, p.FavoriteRegions)
+	setStrings(&profile.DislikedIngredients, p.DislikedIngredients)
+	setString(&profile.SpiceLevel, p.SpiceLevel)
+	setString(&profile.Avatar, p.Avatar)
+	setString(&profile.TimeZone, p.TimeZone)
+	if p.FastingPeriods != nil {
+		profile.FastingPeriods = *p.FastingPeriods
+	}
+	if p.BodyMetrics != nil {
+		profile.BodyMetrics = p.BodyMetrics
+	}
+}
+
+// HouseholdInvite is issued by a household member and lets someone else join the household
+type HouseholdInvite struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// HouseholdJoinRequest represents the request for joining a household with an invite from one of its members
+type HouseholdJoinRequest struct {
+	InviteToken string `json:"inviteToken" validate:"required"`
 }
 
 // ToResponse converts User model to UserResponse
 func (u *User) ToResponse() UserResponse {
-	return UserResponse{
+	response := UserResponse{
 		ID:      u.ID.Hex(),
 		Name:    u.Name,
 		Email:   u.Email,
+		Role:    u.Role,
 		Profile: u.Profile,
 	}
+	if u.HouseholdID != nil {
+		response.HouseholdID = u.HouseholdID.Hex()
+	}
+	return response
+}
+
+// User roles. Admins are granted by setting the role in the database.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// IsAdmin reports whether the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 }
 
 // GetDietaryPreferences returns the list of valid dietary preferences
@@ -206,16 +206,100 @@ func TestUserProfile(t *testing.T) {
 
 func TestProfileUpdateRequest(t *testing.T) {
 	// Arrange
+	preferences, spiceLevel := []string{"vegan"}, "hot"
 	req := ProfileUpdateRequest{
 		Name: "Updated Name",
-		Profile: UserProfile{
-			DietaryPreferences: []string{"vegan"},
-			SpiceLevel:         "hot",
+		Profile: ProfilePatch{
+			DietaryPreferences: &preferences,
+			SpiceLevel:         &spiceLevel,
 		},
 	}
 
 	// Assert
 	assert.Equal(t, "Updated Name", req.Name)
-	assert.Equal(t, []string{"vegan"}, req.Profile.DietaryPreferences)
-	assert.Equal(t, "hot", req.Profile.SpiceLevel)
-}
\ No newline at end of file
+	assert.Equal(t, []string{"vegan"}, *req.Profile.DietaryPreferences)
+	assert.Equal(t, "hot", *req.Profile.SpiceLevel)
+}
+func TestProfilePatch_Apply(t *testing.T) {
+	spiceLevel := "hot"
+	noAllergies := []string{}
+
+	tests := []struct {
+		name            string
+		patch           ProfilePatch
+		expectSpice     string
+		expectAllergies []string
+	}{
+		{"empty patch", ProfilePatch{}, "medium", []string{AllergenPeanut}},
+		{"spice level only", ProfilePatch{SpiceLevel: &spiceLevel}, "hot", []string{AllergenPeanut}},
+		{"clear allergies", ProfilePatch{Allergies: &noAllergies}, "medium", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			profile := UserProfile{
+				SpiceLevel:     "medium",
+				Allergies:      []string{AllergenPeanut},
+				TimeZone:       "Asia/Kolkata",
+				NutritionGoals: NutritionGoals{DailyCalories: 2200},
+			}
+
+			// Act
+			tt.patch.Apply(&profile)
+
+			// Assert
+			assert.Equal(t, tt.expectSpice, profile.SpiceLevel)
+			assert.Equal(t, tt.expectAllergies, profile.Allergies)
+			assert.Equal(t, 2200, profile.NutritionGoals.DailyCalories)
+			assert.Equal(t, "Asia/Kolkata", profile.TimeZone)
+		})
+	}
+}
+
+func TestUserProfile_Location(t *testing.T) {
+	assert.Equal(t, time.UTC, UserProfile{}.Location())
+	assert.Equal(t, time.UTC, UserProfile{TimeZone: "Not/AZone"}.Location())
+	assert.Equal(t, "Asia/Kolkata", UserProfile{TimeZone: "Asia/Kolkata"}.Location().String())
+}
+
+func TestUserProfileActiveDietRules(t *testing.T) {
+	// Arrange
+	profile := UserProfile{
+		DietRules: []string{DietRuleSatvik},
+		FastingPeriods: []FastingPeriod{
+			{
+				RuleSet:   DietRuleNavratri,
+				Name:      "Sharad Navratri",
+				StartDate: time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2024, 10, 11, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				RuleSet:   DietRuleSatvik,
+				StartDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2024, 10, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected []string
+	}{
+		{"before the fast", time.Date(2024, 10, 2, 20, 0, 0, 0, time.UTC), []string{DietRuleSatvik}},
+		{"first day", time.Date(2024, 10, 3, 7, 0, 0, 0, time.UTC), []string{DietRuleSatvik, DietRuleNavratri}},
+		{"last day, late evening", time.Date(2024, 10, 11, 23, 30, 0, 0, time.UTC), []string{DietRuleSatvik, DietRuleNavratri}},
+		{"after the fast", time.Date(2024, 10, 12, 0, 0, 0, 0, time.UTC), []string{DietRuleSatvik}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			rules := profile.ActiveDietRules(tt.date)
+
+			// Assert
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
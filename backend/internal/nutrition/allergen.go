@@ -0,0 +1,81 @@
+package nutrition
+
+import (
+	"sort"
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// allergenRules map catalog ingredients to the allergens they contain
+var allergenRules = map[string]ingredientRule{
+	models.AllergenPeanut:    {keys: []string{"peanut"}},
+	models.AllergenTreeNuts:  {keys: []string{"cashew", "almond"}},
+	models.AllergenDairy:     {categories: []string{"dairy"}, keys: []string{"ghee"}},
+	models.AllergenGluten:    {keys: []string{"wheat_flour", "maida", "semolina"}},
+	models.AllergenSesame:    {keys: []string{"sesame"}},
+	models.AllergenShellfish: {keys: []string{"prawns"}},
+	models.AllergenSoy:       {keys: []string{"soybean", "tofu"}},
+	models.AllergenEgg:       {categories: []string{"egg"}},
+	models.AllergenMustard:   {keys: []string{"mustard_seeds", "mustard_oil"}},
+}
+
+// allergenWords flag ingredients missing from the catalog by the words in their names.
+// Matching errs on the side of flagging, so "peanut butter" is also flagged as dairy.
+var allergenWords = map[string][]string{
+	models.AllergenPeanut:    {"peanut", "peanuts", "groundnut", "groundnuts", "moongphali"},
+	models.AllergenTreeNuts:  {"cashew", "cashews", "kaju", "almond", "almonds", "badam", "pistachio", "pistachios", "pista", "walnut", "walnuts", "akhrot", "hazelnut", "pecan"},
+	models.AllergenDairy:     {"milk", "cream", "butter", "ghee", "paneer", "cheese", "curd", "yogurt", "yoghurt", "dahi", "khoya", "mawa", "malai", "buttermilk", "chaas", "lassi"},
+	models.AllergenGluten:    {"wheat", "atta", "maida", "sooji", "suji", "semolina", "rava", "rawa", "barley", "jau", "rye", "bread", "pasta", "noodles", "seitan"},
+	models.AllergenSesame:    {"sesame", "til", "tahini", "gingelly"},
+	models.AllergenShellfish: {"prawn", "prawns", "shrimp", "shrimps", "jhinga", "crab", "crabs", "lobster", "lobsters"},
+	models.AllergenSoy:       {"soy", "soya", "soybean", "tofu", "edamame"},
+	models.AllergenEgg:       {"egg", "eggs", "anda", "mayonnaise", "mayo"},
+	models.AllergenMustard:   {"mustard", "rai", "sarson", "kasundi"},
+}
+
+// DishAllergens derives the allergens in a dish from its ingredient names and quantities,
+// in the order of models.GetAllergens
+func DishAllergens(catalog *Catalog, dish *models.Dish) []string {
+	found := map[string]bool{}
+	for _, name := range dishIngredientNames(dish) {
+		for _, allergen := range IngredientAllergens(catalog, name) {
+			found[allergen] = true
+		}
+	}
+
+	allergens := []string{}
+	for _, allergen := range models.GetAllergens() {
+		if found[allergen] {
+			allergens = append(allergens, allergen)
+		}
+	}
+	return allergens
+}
+
+// IngredientAllergens returns the allergens in one ingredient, using the catalog when it
+// knows the ingredient and the words in its name otherwise
+func IngredientAllergens(catalog *Catalog, name string) []string {
+	var allergens []string
+	if ingredient, ok := catalog.Lookup(name); ok {
+		for allergen, rule := range allergenRules {
+			if rule.excludes(ingredient) {
+				allergens = append(allergens, allergen)
+			}
+		}
+		sort.Strings(allergens)
+		return allergens
+	}
+
+	words := strings.Fields(strings.NewReplacer("-", " ", "_", " ", ",", " ", "(", " ", ")", " ").Replace(strings.ToLower(name)))
+	for allergen, keywords := range allergenWords {
+		for _, word := range words {
+			if containsString(keywords, word) {
+				allergens = append(allergens, allergen)
+				break
+			}
+		}
+	}
+	sort.Strings(allergens)
+	return allergens
+}
@@ -0,0 +1,51 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDishAllergens(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		Ingredients: []string{"paneer", "cashews", "besan", "mustard oil", "coconut"},
+		IngredientQuantities: []models.IngredientQuantity{
+			{Name: "atta", Quantity: 200, Unit: models.UnitGram},
+			{Name: "paneer", Quantity: 100, Unit: models.UnitGram},
+		},
+	}
+
+	// Act
+	allergens := DishAllergens(DefaultCatalog(), dish)
+
+	// Assert - coconut is not treated as a tree nut
+	assert.Equal(t, []string{"tree-nuts", "dairy", "gluten", "mustard"}, allergens)
+}
+
+func TestIngredientAllergens(t *testing.T) {
+	tests := []struct {
+		name       string
+		ingredient string
+		expected   []string
+	}{
+		{"catalog ingredient", "ghee", []string{"dairy"}},
+		{"catalog alias", "jhinga", []string{"shellfish"}},
+		{"no allergens", "spinach", nil},
+		{"uncatalogued name by its words", "Soy Sauce", []string{"soy"}},
+		{"several words", "peanut butter", []string{"dairy", "peanut"}},
+		{"word must match whole", "coconut chutney", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			allergens := IngredientAllergens(DefaultCatalog(), tt.ingredient)
+
+			// Assert
+			assert.Equal(t, tt.expected, allergens)
+		})
+	}
+}
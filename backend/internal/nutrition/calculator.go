@@ -0,0 +1,177 @@
+// Package nutrition holds the food composition catalog and the calculations built on it:
+// dish nutrition from ingredient quantities, recipe scaling, substitutions, allergens,
+// diet rules, glycemic load, health-condition limits, micronutrient reports, weight
+// trends, dish similarity and comparison, and personalized targets from body metrics.
+//
+// Energy needs use the Mifflin-St Jeor equation for basal metabolic rate,
+// scaled by a physical activity factor. Macro and micronutrient targets
+// follow the ICMR-NIN Recommended Dietary Allowances for Indians (2020).
+package nutrition
+
+import (
+	"errors"
+	"math"
+
+	"nourish-backend/internal/models"
+)
+
+// Method describes how calculated goals are derived
+const Method = "Mifflin-St Jeor BMR x activity factor, ICMR-NIN 2020 RDA"
+
+// ICMR-NIN reference values
+const (
+	proteinPerKg       = 0.83 // g/kg/day, RDA for healthy adults
+	proteinPerKgLose   = 1.0  // higher intake preserves lean mass in a deficit
+	proteinPerKgGain   = 1.2  // supports muscle gain in a surplus
+	fatEnergyShare     = 0.25 // share of energy from fat, within the 20-30% range
+	fiberPer1000Kcal   = 15   // g, i.e. 30 g for a 2000 kcal diet
+	sodiumLimit        = 2000 // mg/day, equivalent to 5 g of salt
+	sugarEnergyShare   = 0.10 // free sugars below 10% of energy
+	caloriesPerGramPro = 4
+	caloriesPerGramCHO = 4
+	caloriesPerGramFat = 9
+)
+
+// Energy adjustments for weight goals, kcal/day
+const (
+	loseDeficit = 500 // roughly 0.5 kg a week
+	gainSurplus = 300
+)
+
+// Minimum daily energy targets below which a deficit is not applied
+const (
+	minCaloriesFemale = 1200
+	minCaloriesMale   = 1500
+)
+
+// activityFactors maps activity levels to physical activity multipliers
+var activityFactors = map[string]float64{
+	models.ActivitySedentary:  1.2,
+	models.ActivityLight:      1.375,
+	models.ActivityModerate:   1.55,
+	models.ActivityActive:     1.725,
+	models.ActivityVeryActive: 1.9,
+}
+
+// DefaultGoals returns the goals used until a user provides body metrics
+func DefaultGoals() models.NutritionGoals {
+	return models.NutritionGoals{
+		DailyCalories: 2000,
+		Protein:       150,
+		Carbs:         250,
+		Fat:           65,
+		Fiber:         25,
+		Sodium:        2300,
+		Sugar:         50,
+	}
+}
+
+// WithDefaults fills any unset (zero) goal with its default value
+func WithDefaults(goals models.NutritionGoals) models.NutritionGoals {
+	defaults := DefaultGoals()
+	if goals.DailyCalories == 0 {
+		goals.DailyCalories = defaults.DailyCalories
+	}
+	if goals.Protein == 0 {
+		goals.Protein = defaults.Protein
+	}
+	if goals.Carbs == 0 {
+		goals.Carbs = defaults.Carbs
+	}
+	if goals.Fat == 0 {
+		goals.Fat = defaults.Fat
+	}
+	if goals.Fiber == 0 {
+		goals.Fiber = defaults.Fiber
+	}
+	if goals.Sodium == 0 {
+		goals.Sodium = defaults.Sodium
+	}
+	if goals.Sugar == 0 {
+		goals.Sugar = defaults.Sugar
+	}
+	return goals
+}
+
+// GoalsForProfile returns the goals that apply to a profile, with defaults for unset values
+func GoalsForProfile(profile models.UserProfile) models.NutritionGoals {
+	return WithDefaults(profile.NutritionGoals)
+}
+
+// BMR returns the basal metabolic rate in kcal/day using the Mifflin-St Jeor equation
+func BMR(m models.BodyMetrics) float64 {
+	bmr := 10*m.WeightKg + 6.25*m.HeightCm - 5*float64(m.Age)
+	if m.Sex == models.SexFemale {
+		return bmr - 161
+	}
+	return bmr + 5
+}
+
+// TDEE returns the total daily energy expenditure in kcal/day
+func TDEE(m models.BodyMetrics) (float64, error) {
+	factor, ok := activityFactors[m.ActivityLevel]
+	if !ok {
+		return 0, errors.New("invalid activity level")
+	}
+	return BMR(m) * factor, nil
+}
+
+// Suggest calculates calorie and macro targets for the given body metrics
+func Suggest(m models.BodyMetrics) (*models.GoalSuggestion, error) {
+	if m.Age <= 0 || m.HeightCm <= 0 || m.WeightKg <= 0 {
+		return nil, errors.New("age, height and weight are required")
+	}
+	if m.Sex != models.SexMale && m.Sex != models.SexFemale {
+		return nil, errors.New("invalid sex")
+	}
+
+	bmr := BMR(m)
+	tdee, err := TDEE(m)
+	if err != nil {
+		return nil, err
+	}
+
+	calories := tdee
+	proteinPerKgTarget := proteinPerKg
+	switch m.Goal {
+	case models.WeightGoalLose:
+		calories -= loseDeficit
+		proteinPerKgTarget = proteinPerKgLose
+		minimum := float64(minCaloriesMale)
+		if m.Sex == models.SexFemale {
+			minimum = minCaloriesFemale
+		}
+		calories = math.Max(calories, math.Min(minimum, tdee))
+	case models.WeightGoalGain:
+		calories += gainSurplus
+		proteinPerKgTarget = proteinPerKgGain
+	case models.WeightGoalMaintain, "":
+	default:
+		return nil, errors.New("invalid weight goal")
+	}
+
+	protein := proteinPerKgTarget * m.WeightKg
+	fat := calories * fatEnergyShare / caloriesPerGramFat
+	carbs := (calories - protein*caloriesPerGramPro - fat*caloriesPerGramFat) / caloriesPerGramCHO
+
+	return &models.GoalSuggestion{
+		BodyMetrics: m,
+		BMR:         round(bmr),
+		TDEE:        round(tdee),
+		Goals: models.NutritionGoals{
+			DailyCalories: round(calories),
+			Protein:       round(protein),
+			Carbs:         round(math.Max(carbs, 0)),
+			Fat:           round(fat),
+			Fiber:         round(calories / 1000 * fiberPer1000Kcal),
+			Sodium:        sodiumLimit,
+			Sugar:         round(calories * sugarEnergyShare / caloriesPerGramCHO),
+		},
+		Method: Method,
+	}, nil
+}
+
+// round rounds a value to the nearest whole number
+func round(v float64) int {
+	return int(math.Round(v))
+}
@@ -0,0 +1,120 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBMR(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  models.BodyMetrics
+		expected float64
+	}{
+		{
+			name:     "male",
+			metrics:  models.BodyMetrics{Age: 30, Sex: models.SexMale, HeightCm: 175, WeightKg: 70},
+			expected: 1648.75,
+		},
+		{
+			name:     "female",
+			metrics:  models.BodyMetrics{Age: 30, Sex: models.SexFemale, HeightCm: 160, WeightKg: 55},
+			expected: 1239,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, BMR(tt.metrics), 0.001)
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	// Arrange
+	metrics := models.BodyMetrics{
+		Age:           30,
+		Sex:           models.SexMale,
+		HeightCm:      175,
+		WeightKg:      70,
+		ActivityLevel: models.ActivityModerate,
+		Goal:          models.WeightGoalMaintain,
+	}
+
+	// Act
+	suggestion, err := Suggest(metrics)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1649, suggestion.BMR)
+	assert.Equal(t, 2556, suggestion.TDEE)
+	assert.Equal(t, 2556, suggestion.Goals.DailyCalories)
+	assert.Equal(t, 58, suggestion.Goals.Protein)
+	assert.Equal(t, 71, suggestion.Goals.Fat)
+	assert.Equal(t, 38, suggestion.Goals.Fiber)
+	assert.Equal(t, 2000, suggestion.Goals.Sodium)
+
+	// Macros add up to the calorie target
+	goals := suggestion.Goals
+	total := goals.Protein*4 + goals.Carbs*4 + goals.Fat*9
+	assert.InDelta(t, goals.DailyCalories, total, 10)
+}
+
+func TestSuggest_WeightGoals(t *testing.T) {
+	base := models.BodyMetrics{Age: 30, Sex: models.SexFemale, HeightCm: 160, WeightKg: 55, ActivityLevel: models.ActivitySedentary}
+
+	maintain := base
+	maintain.Goal = models.WeightGoalMaintain
+	lose := base
+	lose.Goal = models.WeightGoalLose
+	gain := base
+	gain.Goal = models.WeightGoalGain
+
+	m, err := Suggest(maintain)
+	require.NoError(t, err)
+	l, err := Suggest(lose)
+	require.NoError(t, err)
+	g, err := Suggest(gain)
+	require.NoError(t, err)
+
+	// A sedentary 55 kg woman has a TDEE of ~1487 kcal, so the deficit is capped at 1200 kcal
+	assert.Equal(t, 1487, m.Goals.DailyCalories)
+	assert.Equal(t, 1200, l.Goals.DailyCalories)
+	assert.Equal(t, 1787, g.Goals.DailyCalories)
+	assert.Greater(t, l.Goals.Protein, m.Goals.Protein)
+	assert.Greater(t, g.Goals.Protein, l.Goals.Protein)
+}
+
+func TestSuggest_InvalidMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics models.BodyMetrics
+	}{
+		{"missing weight", models.BodyMetrics{Age: 30, Sex: models.SexMale, HeightCm: 175, ActivityLevel: models.ActivityLight}},
+		{"unknown sex", models.BodyMetrics{Age: 30, Sex: "x", HeightCm: 175, WeightKg: 70, ActivityLevel: models.ActivityLight}},
+		{"unknown activity", models.BodyMetrics{Age: 30, Sex: models.SexMale, HeightCm: 175, WeightKg: 70, ActivityLevel: "couch"}},
+		{"unknown goal", models.BodyMetrics{Age: 30, Sex: models.SexMale, HeightCm: 175, WeightKg: 70, ActivityLevel: models.ActivityLight, Goal: "bulk"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Suggest(tt.metrics)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	// Act
+	goals := WithDefaults(models.NutritionGoals{DailyCalories: 1800})
+
+	// Assert
+	defaults := DefaultGoals()
+	assert.Equal(t, 1800, goals.DailyCalories)
+	assert.Equal(t, defaults.Protein, goals.Protein)
+	assert.Equal(t, defaults.Sodium, goals.Sodium)
+}
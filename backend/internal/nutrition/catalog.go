@@ -0,0 +1,262 @@
+package nutrition
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"nourish-backend/internal/models"
+)
+
+// CompositionSource names the food composition data bundled with the catalog
+const CompositionSource = "Indian Food Composition Tables (IFCT 2017), per 100 g edible portion"
+
+// GlycemicIndexSource names the glycemic index values bundled with the catalog
+const GlycemicIndexSource = "International Tables of Glycemic Index (Atkinson et al. 2008) and Indian studies, glucose = 100"
+
+//go:embed data/ifct.csv
+var compositionCSV string
+
+// Household measures in millilitres and grams
+const (
+	teaspoonMl   = 5
+	tablespoonMl = 15
+	cupMl        = 240
+	katoriMl     = 150
+	glassMl      = 250
+	pinchGrams   = 0.3
+)
+
+// Ingredient is a canonical ingredient with its composition per 100 g
+type Ingredient struct {
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Category string   `json:"category"`
+
+	Calories       float64            `json:"calories"`
+	Protein        float64            `json:"protein"`
+	Carbs          float64            `json:"carbs"`
+	Fat            float64            `json:"fat"`
+	Fiber          float64            `json:"fiber"`
+	Sugar          float64            `json:"sugar"`
+	Sodium         float64            `json:"sodium"`
+	Micronutrients map[string]float64 `json:"micronutrients,omitempty"`
+
+	// Glycemic index of the available carbs, zero when unknown or negligible, e.g. for spices
+	GlycemicIndex float64 `json:"glycemicIndex,omitempty"`
+
+	PieceGrams float64 `json:"pieceGrams,omitempty"` // weight of one piece, when sold or used whole
+	Density    float64 `json:"density"`              // g/ml, used for volume measures
+}
+
+// Grams converts a quantity of the ingredient to grams
+func (i Ingredient) Grams(quantity float64, unit string) (float64, error) {
+	density := i.Density
+	if density <= 0 {
+		density = 1
+	}
+
+	switch unit {
+	case models.UnitGram:
+		return quantity, nil
+	case models.UnitKilogram:
+		return quantity * 1000, nil
+	case models.UnitMillilitre:
+		return quantity * density, nil
+	case models.UnitLitre:
+		return quantity * 1000 * density, nil
+	case models.UnitTeaspoon:
+		return quantity * teaspoonMl * density, nil
+	case models.UnitTablespoon:
+		return quantity * tablespoonMl * density, nil
+	case models.UnitCup:
+		return quantity * cupMl * density, nil
+	case models.UnitKatori:
+		return quantity * katoriMl * density, nil
+	case models.UnitGlass:
+		return quantity * glassMl * density, nil
+	case models.UnitPinch:
+		return quantity * pinchGrams, nil
+	case models.UnitPiece:
+		if i.PieceGrams <= 0 {
+			return 0, fmt.Errorf("%s cannot be measured in pieces", i.Name)
+		}
+		return quantity * i.PieceGrams, nil
+	}
+	return 0, fmt.Errorf("unknown unit %q", unit)
+}
+
+// Catalog is the canonical ingredient catalog, looked up by key, name or alias
+type Catalog struct {
+	ingredients []Ingredient
+	index       map[string]int
+}
+
+var (
+	defaultCatalog     *Catalog
+	defaultCatalogOnce sync.Once
+)
+
+// DefaultCatalog returns the catalog built from the bundled composition table
+func DefaultCatalog() *Catalog {
+	defaultCatalogOnce.Do(func() {
+		catalog, err := ParseCatalog(strings.NewReader(compositionCSV))
+		if err != nil {
+			panic(fmt.Sprintf("nutrition: invalid bundled composition table: %v", err))
+		}
+		defaultCatalog = catalog
+	})
+	return defaultCatalog
+}
+
+// compositionColumns maps nutrient columns to micronutrient keys
+var compositionColumns = map[string]string{
+	"iron_mg":         models.MicronutrientIron,
+	"calcium_mg":      models.MicronutrientCalcium,
+	"vitamin_b12_ug":  models.MicronutrientVitaminB12,
+	"vitamin_d_ug":    models.MicronutrientVitaminD,
+	"folate_ug":       models.MicronutrientFolate,
+	"potassium_mg":    models.MicronutrientPotassium,
+	"saturated_fat_g": models.MicronutrientSaturatedFat,
+}
+
+// ParseCatalog reads a composition table in CSV form. The header names the columns;
+// aliases are separated by "|" and empty numeric cells are treated as zero.
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("composition table is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"key", "name", "category", "energy_kcal", "protein_g", "carbs_g", "fat_g"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("composition table is missing column %q", required)
+		}
+	}
+
+	catalog := &Catalog{index: map[string]int{}}
+	for line, record := range records[1:] {
+		text := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(column string) (float64, error) {
+			value := text(column)
+			if value == "" {
+				return 0, nil
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("line %d: invalid %s %q", line+2, column, value)
+			}
+			return n, nil
+		}
+
+		ingredient := Ingredient{
+			Key:            text("key"),
+			Name:           text("name"),
+			Category:       text("category"),
+			Micronutrients: map[string]float64{},
+		}
+		if ingredient.Key == "" || ingredient.Name == "" {
+			return nil, fmt.Errorf("line %d: key and name are required", line+2)
+		}
+		if aliases := text("aliases"); aliases != "" {
+			ingredient.Aliases = strings.Split(aliases, "|")
+		}
+
+		fields := map[string]*float64{
+			"energy_kcal":  &ingredient.Calories,
+			"protein_g":    &ingredient.Protein,
+			"carbs_g":      &ingredient.Carbs,
+			"fat_g":        &ingredient.Fat,
+			"fiber_g":      &ingredient.Fiber,
+			"sugar_g":      &ingredient.Sugar,
+			"sodium_mg":    &ingredient.Sodium,
+			"piece_g":      &ingredient.PieceGrams,
+			"density_g_ml": &ingredient.Density,
+
+			"glycemic_index": &ingredient.GlycemicIndex,
+		}
+		for column, field := range fields {
+			if *field, err = number(column); err != nil {
+				return nil, err
+			}
+		}
+		for column, key := range compositionColumns {
+			value, err := number(column)
+			if err != nil {
+				return nil, err
+			}
+			if value > 0 {
+				ingredient.Micronutrients[key] = value
+			}
+		}
+
+		if err := catalog.add(ingredient); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+	}
+	return catalog, nil
+}
+
+// add registers an ingredient under its key, name and aliases
+func (c *Catalog) add(ingredient Ingredient) error {
+	position := len(c.ingredients)
+	for _, name := range append([]string{ingredient.Key, ingredient.Name}, ingredient.Aliases...) {
+		key := normalizeIngredient(name)
+		if existing, ok := c.index[key]; ok && existing != position {
+			return fmt.Errorf("%q is already used by %s", name, c.ingredients[existing].Key)
+		}
+		c.index[key] = position
+	}
+	c.ingredients = append(c.ingredients, ingredient)
+	return nil
+}
+
+// Lookup finds the canonical ingredient for a name, key or alias.
+// Matching ignores case, extra spaces and a plural "s".
+func (c *Catalog) Lookup(name string) (Ingredient, bool) {
+	key := normalizeIngredient(name)
+	if i, ok := c.index[key]; ok {
+		return c.ingredients[i], true
+	}
+	for _, suffix := range []string{"es", "s"} {
+		if trimmed := strings.TrimSuffix(key, suffix); trimmed != key {
+			if i, ok := c.index[trimmed]; ok {
+				return c.ingredients[i], true
+			}
+		}
+	}
+	return Ingredient{}, false
+}
+
+// Ingredients returns every catalog ingredient sorted by name
+func (c *Catalog) Ingredients() []Ingredient {
+	ingredients := append([]Ingredient(nil), c.ingredients...)
+	sort.Slice(ingredients, func(i, j int) bool {
+		return ingredients[i].Name < ingredients[j].Name
+	})
+	return ingredients
+}
+
+// normalizeIngredient lowercases a name and collapses underscores and whitespace
+func normalizeIngredient(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", " ")
+	return strings.Join(strings.Fields(name), " ")
+}
@@ -0,0 +1,131 @@
+package nutrition
+
+import (
+	"strings"
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultCatalog_Lookup(t *testing.T) {
+	catalog := DefaultCatalog()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		found    bool
+	}{
+		{"key", "toor_dal", "toor_dal", true},
+		{"name", "Toor dal", "toor_dal", true},
+		{"alias", "yellow lentils", "toor_dal", true},
+		{"case and spaces", "  Basmati   RICE ", "basmati_rice", true},
+		{"plural", "tomatoes", "tomato", true},
+		{"plural not in aliases", "green chillis", "green_chilli", true},
+		{"unknown", "dragon fruit", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			ingredient, found := catalog.Lookup(tt.input)
+
+			// Assert
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, ingredient.Key)
+		})
+	}
+}
+
+func TestDefaultCatalog_SeededIngredients(t *testing.T) {
+	// Every ingredient used by the seeded dishes is in the catalog
+	names := []string{
+		"chicken", "butter", "tomato", "cream", "garam masala", "ginger", "garlic",
+		"yellow lentils", "onion", "cumin", "turmeric", "green chilies",
+		"rice", "urad dal", "potato", "mustard seeds", "curry leaves",
+		"basmati rice", "yogurt", "saffron", "mint", "fried onions", "ghee", "whole spices",
+		"spinach", "paneer",
+	}
+
+	for _, name := range names {
+		_, found := DefaultCatalog().Lookup(name)
+		assert.True(t, found, name)
+	}
+}
+
+func TestIngredient_Grams(t *testing.T) {
+	oil, _ := DefaultCatalog().Lookup("oil")
+	egg, _ := DefaultCatalog().Lookup("egg")
+	spinach, _ := DefaultCatalog().Lookup("spinach")
+
+	tests := []struct {
+		name       string
+		ingredient Ingredient
+		quantity   float64
+		unit       string
+		expected   float64
+		expectErr  bool
+	}{
+		{"grams", spinach, 250, models.UnitGram, 250, false},
+		{"kilograms", spinach, 0.5, models.UnitKilogram, 500, false},
+		{"tablespoons use density", oil, 2, models.UnitTablespoon, 27.6, false},
+		{"volume without density", spinach, 1, models.UnitCup, 240, false},
+		{"pieces", egg, 3, models.UnitPiece, 150, false},
+		{"pinch", spinach, 2, models.UnitPinch, 0.6, false},
+		{"katori", spinach, 1, models.UnitKatori, 150, false},
+		{"glass uses density", oil, 1, models.UnitGlass, 230, false},
+		{"no piece weight", spinach, 1, models.UnitPiece, 0, true},
+		{"unknown unit", spinach, 1, "bunch", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			grams, err := tt.ingredient.Grams(tt.quantity, tt.unit)
+
+			// Assert
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, grams, 0.001)
+		})
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	header := "key,name,aliases,category,energy_kcal,protein_g,carbs_g,fat_g,iron_mg\n"
+
+	t.Run("valid table", func(t *testing.T) {
+		// Act
+		catalog, err := ParseCatalog(strings.NewReader(header + "jowar,Jowar,sorghum|jonna,cereal,334,10.4,67.7,1.7,3.9\n"))
+
+		// Assert
+		require.NoError(t, err)
+		ingredient, found := catalog.Lookup("sorghum")
+		require.True(t, found)
+		assert.Equal(t, 334.0, ingredient.Calories)
+		assert.Equal(t, 3.9, ingredient.Micronutrients[models.MicronutrientIron])
+		assert.Len(t, catalog.Ingredients(), 1)
+	})
+
+	t.Run("invalid number", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(header + "jowar,Jowar,,cereal,abc,10,67,1,3\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate alias", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader(header +
+			"jowar,Jowar,millet,cereal,334,10,67,1,3\nbajra,Bajra,millet,cereal,348,11,67,5,6\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := ParseCatalog(strings.NewReader("key,name\njowar,Jowar\n"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+package nutrition
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// Dish comparison settings
+const (
+	comparisonMealShare = 1.0 / 3 // a serving is judged as one of three main meals
+	verdictMargin       = 1.0     // goal fit points a dish must lead by to be called healthier
+)
+
+// CompareDishes lines dishes up per serving and judges which best fits the goals. Unset
+// goals take their defaults; allergens and glycemic load missing from older dishes are
+// derived from the ingredients.
+func CompareDishes(catalog *Catalog, dishes []*models.Dish, goals models.NutritionGoals) *models.DishComparison {
+	personal := goals != (models.NutritionGoals{})
+	mealGoals := mealShareOf(WithDefaults(goals), comparisonMealShare)
+
+	comparison := &models.DishComparison{
+		Dishes:      make([]models.ComparedDish, len(dishes)),
+		Allergens:   []string{},
+		DietaryTags: []string{},
+	}
+	allergens := map[string]bool{}
+	for i, dish := range dishes {
+		compared := models.ComparedDish{
+			ID:           dish.ID.Hex(),
+			Name:         dish.Name,
+			Type:         dish.Type,
+			Cuisine:      dish.Cuisine,
+			Image:        dish.Image,
+			Calories:     dish.Calories,
+			Nutrition:    dish.Nutrition,
+			MacroDensity: macroDensity(dish),
+			GlycemicLoad: dish.GlycemicLoad,
+			PrepTime:     dish.PrepTime,
+			CookTime:     dish.CookTime,
+			TotalTime:    dish.PrepTime + dish.CookTime,
+			Allergens:    dish.Allergens,
+			DietaryTags:  dish.DietaryTags,
+		}
+		if compared.GlycemicLoad == nil {
+			compared.GlycemicLoad = DishGlycemicLoad(catalog, dish)
+		}
+		if compared.Allergens == nil {
+			compared.Allergens = DishAllergens(catalog, dish)
+		}
+		if compared.DietaryTags == nil {
+			compared.DietaryTags = []string{}
+		}
+		compared.GoalFit = models.EvaluateDay(models.DailyNutrition{
+			Calories: dish.Calories,
+			Protein:  dish.Nutrition.Protein,
+			Carbs:    dish.Nutrition.Carbs,
+			Fat:      dish.Nutrition.Fat,
+			Fiber:    dish.Nutrition.Fiber,
+			Sodium:   dish.Nutrition.Sodium,
+			Sugar:    dish.Nutrition.Sugar,
+		}, mealGoals)
+
+		for _, allergen := range compared.Allergens {
+			allergens[allergen] = true
+		}
+		for _, tag := range compared.DietaryTags {
+			if !containsString(comparison.DietaryTags, tag) {
+				comparison.DietaryTags = append(comparison.DietaryTags, tag)
+			}
+		}
+		comparison.Dishes[i] = compared
+	}
+
+	for _, allergen := range models.GetAllergens() {
+		if allergens[allergen] {
+			comparison.Allergens = append(comparison.Allergens, allergen)
+		}
+	}
+	sort.Strings(comparison.DietaryTags)
+
+	goalsName := "the default nutrition goals"
+	if personal {
+		goalsName = "your nutrition goals"
+	}
+	comparison.Verdict = healthierChoice(comparison.Dishes, goalsName)
+	comparison.Verdict.PersonalGoals = personal
+	return comparison
+}
+
+// healthierChoice picks the dish with the best goal fit. Dishes within the margin of the
+// best are told apart by the lower glycemic load; failing that there is no verdict.
+func healthierChoice(dishes []models.ComparedDish, goalsName string) models.ComparisonVerdict {
+	verdict := models.ComparisonVerdict{BetterOn: []string{}}
+	if len(dishes) < 2 {
+		verdict.Reason = "Add another dish to compare"
+		return verdict
+	}
+
+	best := 0
+	for i, dish := range dishes {
+		if dish.GoalFit.Score > dishes[best].GoalFit.Score {
+			best = i
+		}
+	}
+
+	var contenders []int
+	for i, dish := range dishes {
+		if dishes[best].GoalFit.Score-dish.GoalFit.Score < verdictMargin {
+			contenders = append(contenders, i)
+		}
+	}
+	reason := "fits " + goalsName + " best"
+	if len(contenders) > 1 {
+		best = lowestGlycemicLoad(dishes, contenders)
+		if best < 0 {
+			verdict.Reason = "No dish fits " + goalsName + " clearly better"
+			return verdict
+		}
+		reason = "fits " + goalsName + " as well as the others with a lower glycemic load"
+	}
+
+	chosen := dishes[best]
+	for _, nutrient := range nutrientOrder {
+		result, ok := chosen.GoalFit.Nutrients[nutrient]
+		if !ok {
+			continue
+		}
+		leads := true
+		for i, other := range dishes {
+			if i != best && other.GoalFit.Nutrients[nutrient].Score >= result.Score {
+				leads = false
+				break
+			}
+		}
+		if leads {
+			verdict.BetterOn = append(verdict.BetterOn, nutrient)
+		}
+	}
+
+	verdict.DishID = chosen.ID
+	verdict.Name = chosen.Name
+	verdict.Reason = chosen.Name + " " + reason
+	if len(verdict.BetterOn) > 0 {
+		verdict.Reason += ", especially on " + strings.Join(verdict.BetterOn, ", ")
+	}
+	return verdict
+}
+
+// nutrientOrder lists the goal nutrients in the order they are reported
+var nutrientOrder = []string{
+	models.NutrientCalories, models.NutrientProtein, models.NutrientCarbs, models.NutrientFat,
+	models.NutrientFiber, models.NutrientSodium, models.NutrientSugar,
+}
+
+// lowestGlycemicLoad returns the candidate with the strictly lowest known glycemic load,
+// or -1 when none is known or the lowest is shared
+func lowestGlycemicLoad(dishes []models.ComparedDish, candidates []int) int {
+	lowest, tied := -1, false
+	for _, i := range candidates {
+		load := dishes[i].GlycemicLoad
+		if load == nil {
+			continue
+		}
+		switch {
+		case lowest < 0 || *load < *dishes[lowest].GlycemicLoad:
+			lowest, tied = i, false
+		case *load == *dishes[lowest].GlycemicLoad:
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return lowest
+}
+
+// mealShareOf scales daily goals down to one meal, keeping how each is judged
+func mealShareOf(goals models.NutritionGoals, share float64) models.NutritionGoals {
+	scale := func(target int) int {
+		return int(math.Round(float64(target) * share))
+	}
+	goals.DailyCalories = scale(goals.DailyCalories)
+	goals.Protein = scale(goals.Protein)
+	goals.Carbs = scale(goals.Carbs)
+	goals.Fat = scale(goals.Fat)
+	goals.Fiber = scale(goals.Fiber)
+	goals.Sodium = scale(goals.Sodium)
+	goals.Sugar = scale(goals.Sugar)
+	return goals
+}
+
+// macroDensity returns grams of each macro per 100 kcal, zero for a dish without calories
+func macroDensity(dish *models.Dish) models.MacroDensity {
+	if dish.Calories <= 0 {
+		return models.MacroDensity{}
+	}
+	per100 := func(grams int) float64 {
+		return roundTo(float64(grams)*100/float64(dish.Calories), 1)
+	}
+	return models.MacroDensity{
+		Protein: per100(dish.Nutrition.Protein),
+		Carbs:   per100(dish.Nutrition.Carbs),
+		Fat:     per100(dish.Nutrition.Fat),
+		Fiber:   per100(dish.Nutrition.Fiber),
+	}
+}
@@ -0,0 +1,117 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// comparedDish returns a dish with the given per serving nutrition
+func comparedDish(name string, calories int, nutrition models.Nutrition, glycemicLoad *float64) *models.Dish {
+	return &models.Dish{
+		ID:           primitive.NewObjectID(),
+		Name:         name,
+		Calories:     calories,
+		Nutrition:    nutrition,
+		GlycemicLoad: glycemicLoad,
+		Allergens:    []string{},
+	}
+}
+
+func TestCompareDishes(t *testing.T) {
+	// Arrange
+	rajma := comparedDish("Rajma Chawal", 500, models.Nutrition{Protein: 18, Carbs: 80, Fat: 10, Fiber: 12, Sodium: 600, Sugar: 4}, floatPtr(20))
+	rajma.PrepTime, rajma.CookTime = 15, 40
+	rajma.DietaryTags = []string{"vegetarian", "gluten-free"}
+	chole := comparedDish("Chole Bhature", 650, models.Nutrition{Protein: 15, Carbs: 75, Fat: 35, Fiber: 8, Sodium: 900, Sugar: 6}, nil)
+	chole.Ingredients = []string{"chickpeas", "maida", "curd"}
+	chole.Allergens = nil // saved before allergens were derived
+	chole.DietaryTags = []string{"vegetarian"}
+
+	// Act
+	comparison := CompareDishes(DefaultCatalog(), []*models.Dish{rajma, chole}, models.NutritionGoals{})
+
+	// Assert
+	require.Len(t, comparison.Dishes, 2)
+	first, second := comparison.Dishes[0], comparison.Dishes[1]
+	assert.Equal(t, "Rajma Chawal", first.Name)
+	assert.Equal(t, 55, first.TotalTime)
+	assert.Equal(t, models.MacroDensity{Protein: 3.6, Carbs: 16, Fat: 2, Fiber: 2.4}, first.MacroDensity)
+	assert.Equal(t, []string{models.AllergenDairy, models.AllergenGluten}, second.Allergens)
+	assert.Equal(t, []string{models.AllergenDairy, models.AllergenGluten}, comparison.Allergens)
+	assert.Equal(t, []string{"gluten-free", "vegetarian"}, comparison.DietaryTags)
+	assert.Equal(t, []string{"vegetarian"}, second.DietaryTags)
+
+	// Judged against a third of the default goals
+	assert.Equal(t, 89.0, first.GoalFit.Score)
+	assert.Equal(t, 81.6, second.GoalFit.Score)
+	assert.Equal(t, models.AdherenceOver, second.GoalFit.Nutrients[models.NutrientFat].Status)
+
+	assert.Equal(t, models.ComparisonVerdict{
+		DishID:   rajma.ID.Hex(),
+		Name:     "Rajma Chawal",
+		BetterOn: []string{models.NutrientProtein, models.NutrientFat, models.NutrientSodium},
+		Reason:   "Rajma Chawal fits the default nutrition goals best, especially on protein, fat, sodium",
+	}, comparison.Verdict)
+}
+
+func TestCompareDishes_Verdict(t *testing.T) {
+	same := models.Nutrition{Protein: 20, Carbs: 60, Fat: 15, Fiber: 8}
+
+	tests := []struct {
+		name           string
+		dishes         []*models.Dish
+		goals          models.NutritionGoals
+		expectedName   string
+		expectedReason string
+	}{
+		{
+			name: "lower glycemic load breaks a tie",
+			dishes: []*models.Dish{
+				comparedDish("Jeera Rice", 450, same, floatPtr(28)),
+				comparedDish("Millet Pulao", 450, same, floatPtr(16)),
+			},
+			expectedName:   "Millet Pulao",
+			expectedReason: "Millet Pulao fits the default nutrition goals as well as the others with a lower glycemic load",
+		},
+		{
+			name: "no way to tell apart",
+			dishes: []*models.Dish{
+				comparedDish("Jeera Rice", 450, same, nil),
+				comparedDish("Veg Pulao", 450, same, nil),
+			},
+			expectedReason: "No dish fits the default nutrition goals clearly better",
+		},
+		{
+			name: "personal goals",
+			dishes: []*models.Dish{
+				comparedDish("Paneer Bhurji", 350, models.Nutrition{Protein: 22, Carbs: 8, Fat: 25}, nil),
+				comparedDish("Aloo Paratha", 350, models.Nutrition{Protein: 7, Carbs: 50, Fat: 13}, nil),
+			},
+			goals:          models.NutritionGoals{DailyCalories: 1800, Carbs: 90, Semantics: models.GoalSemantics{Carbs: models.GoalRule{Kind: models.GoalKindMax}}},
+			expectedName:   "Paneer Bhurji",
+			expectedReason: "Paneer Bhurji fits your nutrition goals best, especially on protein, carbs",
+		},
+		{
+			name:           "a single dish",
+			dishes:         []*models.Dish{comparedDish("Poha", 250, same, nil)},
+			expectedReason: "Add another dish to compare",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			verdict := CompareDishes(DefaultCatalog(), tt.dishes, tt.goals).Verdict
+
+			// Assert
+			assert.Equal(t, tt.expectedName, verdict.Name)
+			assert.Equal(t, tt.expectedReason, verdict.Reason)
+			assert.Equal(t, tt.goals != models.NutritionGoals{}, verdict.PersonalGoals)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package nutrition
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// mealLimitShare is the share of a daily ceiling one recommended dish may use
+const mealLimitShare = 0.4
+
+// conditionProfiles hold each condition's limits, following ICMR-NIN, RSSDI and KDOQI
+// guidance for adults
+var conditionProfiles = map[string]models.ConditionLimits{
+	models.HealthConditionDiabetes: {
+		MaxSugar:             25,
+		MinFiber:             30,
+		MaxCarbShare:         0.50,
+		MaxMealCarbShare:     0.40, // spread carbs over the day's meals
+		MaxDailyGlycemicLoad: 100,
+		MaxMealGlycemicLoad:  20,
+	},
+	models.HealthConditionHypertension: {
+		MaxSodium: 1500, // DASH
+	},
+	models.HealthConditionPCOS: {
+		MaxSugar:            25,
+		MinFiber:            25,
+		MaxCarbShare:        0.45,
+		MaxMealGlycemicLoad: 20,
+	},
+	models.HealthConditionRenal: {
+		MaxSodium:     2000,
+		MaxPotassium:  2000,
+		MaxPhosphorus: 800,
+	},
+}
+
+// ConditionLimitsFor combines the limits of the declared conditions, keeping the
+// strictest value of each
+func ConditionLimitsFor(conditions []string) models.ConditionLimits {
+	limits := models.ConditionLimits{Conditions: []string{}}
+	for _, condition := range models.GetHealthConditions() {
+		if !containsString(conditions, condition) {
+			continue
+		}
+		profile := conditionProfiles[condition]
+		limits.Conditions = append(limits.Conditions, condition)
+		limits.MaxSodium = int(stricter(float64(limits.MaxSodium), float64(profile.MaxSodium)))
+		limits.MaxPotassium = stricter(limits.MaxPotassium, profile.MaxPotassium)
+		limits.MaxPhosphorus = stricter(limits.MaxPhosphorus, profile.MaxPhosphorus)
+		limits.MaxSugar = int(stricter(float64(limits.MaxSugar), float64(profile.MaxSugar)))
+		limits.MaxCarbShare = stricter(limits.MaxCarbShare, profile.MaxCarbShare)
+		limits.MaxMealCarbShare = stricter(limits.MaxMealCarbShare, profile.MaxMealCarbShare)
+		limits.MaxDailyGlycemicLoad = stricter(limits.MaxDailyGlycemicLoad, profile.MaxDailyGlycemicLoad)
+		limits.MaxMealGlycemicLoad = stricter(limits.MaxMealGlycemicLoad, profile.MaxMealGlycemicLoad)
+		if profile.MinFiber > limits.MinFiber {
+			limits.MinFiber = profile.MinFiber
+		}
+	}
+	return limits
+}
+
+// ApplyConditionLimits tightens goals to the limits: sodium and sugar no higher than their
+// ceilings, fiber at least its minimum and carbs no more than their share of calories
+func ApplyConditionLimits(goals models.NutritionGoals, limits models.ConditionLimits) models.NutritionGoals {
+	if limits.MaxSodium > 0 && (goals.Sodium == 0 || goals.Sodium > limits.MaxSodium) {
+		goals.Sodium = limits.MaxSodium
+	}
+	if limits.MaxSugar > 0 && (goals.Sugar == 0 || goals.Sugar > limits.MaxSugar) {
+		goals.Sugar = limits.MaxSugar
+	}
+	if limits.MinFiber > goals.Fiber {
+		goals.Fiber = limits.MinFiber
+	}
+	if limits.MaxCarbShare > 0 && goals.DailyCalories > 0 {
+		maxCarbs := int(float64(goals.DailyCalories) * limits.MaxCarbShare / caloriesPerGramCHO)
+		if goals.Carbs == 0 || goals.Carbs > maxCarbs {
+			goals.Carbs = maxCarbs
+		}
+	}
+	return goals
+}
+
+// EvaluateConditions checks a logged day and its meals against each declared condition's
+// ceilings. A limit shared by several conditions is reported once, at its strictest value.
+func EvaluateConditions(conditions []string, day models.DailyNutrition, meals []models.MealIntake) []models.ConditionWarning {
+	var warnings []models.ConditionWarning
+	for _, condition := range models.GetHealthConditions() {
+		if !containsString(conditions, condition) {
+			continue
+		}
+		for _, warning := range checkLimits(conditionProfiles[condition], day, meals) {
+			warning.Conditions = []string{condition}
+			warnings = mergeWarning(warnings, warning)
+		}
+	}
+	for i := range warnings {
+		warnings[i].Message = warningMessage(warnings[i])
+	}
+	return warnings
+}
+
+// checkLimits returns the ceilings one profile's limits put on the day that it broke
+func checkLimits(limits models.ConditionLimits, day models.DailyNutrition, meals []models.MealIntake) []models.ConditionWarning {
+	var warnings []models.ConditionWarning
+	over := func(nutrient, mealType string, limit, actual float64) {
+		if limit > 0 && actual > limit {
+			warnings = append(warnings, models.ConditionWarning{Nutrient: nutrient, MealType: mealType, Limit: limit, Actual: actual})
+		}
+	}
+
+	over(models.NutrientSodium, "", float64(limits.MaxSodium), float64(day.Sodium))
+	over(models.NutrientSugar, "", float64(limits.MaxSugar), float64(day.Sugar))
+	over(models.MicronutrientPotassium, "", limits.MaxPotassium, roundTo(day.Micronutrients[models.MicronutrientPotassium], 0))
+	over(models.MicronutrientPhosphorus, "", limits.MaxPhosphorus, roundTo(day.Micronutrients[models.MicronutrientPhosphorus], 0))
+	if day.Calories > 0 {
+		carbShare := float64(day.Carbs*caloriesPerGramCHO) / float64(day.Calories)
+		over(models.ConditionCarbShare, "", percent(limits.MaxCarbShare), percent(carbShare))
+	}
+
+	// Carb distribution needs at least two meals to compare
+	dayGlycemicLoad := 0.0
+	for _, meal := range meals {
+		dayGlycemicLoad += meal.GlycemicLoad
+		over(models.ConditionGlycemicLoad, meal.MealType, limits.MaxMealGlycemicLoad, roundTo(meal.GlycemicLoad, 1))
+		if len(meals) > 1 && day.Carbs > 0 {
+			mealShare := float64(meal.Carbs) / float64(day.Carbs)
+			over(models.ConditionMealCarbShare, meal.MealType, percent(limits.MaxMealCarbShare), percent(mealShare))
+		}
+	}
+	over(models.ConditionGlycemicLoad, "", limits.MaxDailyGlycemicLoad, roundTo(dayGlycemicLoad, 1))
+	return warnings
+}
+
+// mergeWarning adds a warning, folding it into an earlier one for the same nutrient and meal
+func mergeWarning(warnings []models.ConditionWarning, warning models.ConditionWarning) []models.ConditionWarning {
+	for i, existing := range warnings {
+		if existing.Nutrient != warning.Nutrient || existing.MealType != warning.MealType {
+			continue
+		}
+		warnings[i].Conditions = append(existing.Conditions, warning.Conditions...)
+		warnings[i].Limit = math.Min(existing.Limit, warning.Limit)
+		return warnings
+	}
+	return append(warnings, warning)
+}
+
+// warningLabels name each warning nutrient and its unit
+var warningLabels = map[string][2]string{
+	models.NutrientSodium:          {"Sodium", " mg"},
+	models.NutrientSugar:           {"Sugar", " g"},
+	models.MicronutrientPotassium:  {"Potassium", " mg"},
+	models.MicronutrientPhosphorus: {"Phosphorus", " mg"},
+	models.ConditionCarbShare:      {"Carbs as a share of calories", "%"},
+	models.ConditionMealCarbShare:  {"Share of the day's carbs", "%"},
+	models.ConditionGlycemicLoad:   {"Glycemic load", ""},
+}
+
+// warningMessage explains a warning, e.g. "Sodium was 2100 mg, above the 1500 mg limit
+// for hypertension and renal"
+func warningMessage(w models.ConditionWarning) string {
+	label := warningLabels[w.Nutrient]
+	subject := label[0]
+	if w.MealType != "" {
+		subject = fmt.Sprintf("%s at %s", subject, w.MealType)
+	}
+	return fmt.Sprintf("%s was %s%s, above the %s%s limit for %s",
+		subject, formatAmount(w.Actual), label[1], formatAmount(w.Limit), label[1], strings.Join(w.Conditions, " and "))
+}
+
+// DishConditionConflicts returns the limits a single dish would use too much of: more than
+// mealLimitShare of a daily ceiling, more carbs than one meal's share of the carb goal, or
+// more than a meal's glycemic load
+func DishConditionConflicts(limits models.ConditionLimits, goals models.NutritionGoals, dish *models.Dish) []string {
+	var conflicts []string
+	over := func(nutrient string, limit, actual float64) {
+		if limit > 0 && actual > limit*mealLimitShare {
+			conflicts = append(conflicts, nutrient)
+		}
+	}
+
+	over(models.NutrientSodium, float64(limits.MaxSodium), float64(dish.Nutrition.Sodium))
+	over(models.NutrientSugar, float64(limits.MaxSugar), float64(dish.Nutrition.Sugar))
+	over(models.MicronutrientPotassium, limits.MaxPotassium, dish.Nutrition.Micronutrients[models.MicronutrientPotassium])
+	over(models.MicronutrientPhosphorus, limits.MaxPhosphorus, dish.Nutrition.Micronutrients[models.MicronutrientPhosphorus])
+	if dish.GlycemicLoad != nil && limits.MaxMealGlycemicLoad > 0 && *dish.GlycemicLoad > limits.MaxMealGlycemicLoad {
+		conflicts = append(conflicts, models.ConditionGlycemicLoad)
+	}
+
+	if limits.MaxMealCarbShare > 0 {
+		dailyCarbs := ApplyConditionLimits(WithDefaults(goals), limits).Carbs
+		if float64(dish.Nutrition.Carbs) > float64(dailyCarbs)*limits.MaxMealCarbShare {
+			conflicts = append(conflicts, models.NutrientCarbs)
+		}
+	}
+	return conflicts
+}
+
+// stricter returns the lower of two ceilings, where zero means no ceiling
+func stricter(a, b float64) float64 {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// percent converts a share to a percentage with one decimal place
+func percent(share float64) float64 {
+	return roundTo(share*100, 1)
+}
+
+// formatAmount prints whole numbers without a decimal point
+func formatAmount(v float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0")
+}
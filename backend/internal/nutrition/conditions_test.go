@@ -0,0 +1,227 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionLimitsFor(t *testing.T) {
+	// Act
+	limits := ConditionLimitsFor([]string{models.HealthConditionRenal, models.HealthConditionHypertension, models.HealthConditionDiabetes})
+
+	// Assert
+	assert.Equal(t, []string{"diabetes", "hypertension", "renal"}, limits.Conditions)
+	assert.Equal(t, 1500, limits.MaxSodium) // hypertension is stricter than renal
+	assert.Equal(t, 2000.0, limits.MaxPotassium)
+	assert.Equal(t, 800.0, limits.MaxPhosphorus)
+	assert.Equal(t, 0.50, limits.MaxCarbShare)
+	assert.Equal(t, 20.0, limits.MaxMealGlycemicLoad)
+	assert.True(t, ConditionLimitsFor(nil).IsZero())
+}
+
+func TestApplyConditionLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		goals      models.NutritionGoals
+		expected   models.NutritionGoals
+	}{
+		{
+			name:       "no conditions",
+			conditions: nil,
+			goals:      DefaultGoals(),
+			expected:   DefaultGoals(),
+		},
+		{
+			name:       "diabetes caps carbs at half of calories and tightens sugar and fiber",
+			conditions: []string{models.HealthConditionDiabetes},
+			goals:      DefaultGoals(),
+			expected:   models.NutritionGoals{DailyCalories: 2000, Protein: 150, Carbs: 250, Fat: 65, Fiber: 30, Sodium: 2300, Sugar: 25},
+		},
+		{
+			name:       "pcos caps carbs at 45% of calories",
+			conditions: []string{models.HealthConditionPCOS},
+			goals:      DefaultGoals(),
+			expected:   models.NutritionGoals{DailyCalories: 2000, Protein: 150, Carbs: 225, Fat: 65, Fiber: 25, Sodium: 2300, Sugar: 25},
+		},
+		{
+			name:       "hypertension sets the DASH sodium ceiling",
+			conditions: []string{models.HealthConditionHypertension},
+			goals:      DefaultGoals(),
+			expected:   models.NutritionGoals{DailyCalories: 2000, Protein: 150, Carbs: 250, Fat: 65, Fiber: 25, Sodium: 1500, Sugar: 50},
+		},
+		{
+			name:       "stricter goals are kept",
+			conditions: []string{models.HealthConditionRenal},
+			goals:      models.NutritionGoals{DailyCalories: 1800, Sodium: 1200},
+			expected:   models.NutritionGoals{DailyCalories: 1800, Sodium: 1200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			goals := ApplyConditionLimits(tt.goals, ConditionLimitsFor(tt.conditions))
+
+			// Assert
+			assert.Equal(t, tt.expected, goals)
+		})
+	}
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	balancedMeals := []models.MealIntake{
+		{MealType: "breakfast", Carbs: 70, GlycemicLoad: 15},
+		{MealType: "lunch", Carbs: 80, GlycemicLoad: 18},
+		{MealType: "dinner", Carbs: 70, GlycemicLoad: 16},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []string
+		day        models.DailyNutrition
+		meals      []models.MealIntake
+		expected   []string // nutrient and meal type of each warning
+	}{
+		{
+			name:       "diabetes, balanced day",
+			conditions: []string{models.HealthConditionDiabetes},
+			day:        models.DailyNutrition{Calories: 1900, Carbs: 220, Sugar: 20},
+			meals:      balancedMeals,
+			expected:   nil,
+		},
+		{
+			name:       "diabetes, carb-heavy day",
+			conditions: []string{models.HealthConditionDiabetes},
+			day:        models.DailyNutrition{Calories: 2000, Carbs: 300, Sugar: 40},
+			meals:      []models.MealIntake{{MealType: "breakfast", Carbs: 60}, {MealType: "lunch", Carbs: 180}, {MealType: "dinner", Carbs: 60}},
+			expected:   []string{"sugar", "carbShare", "mealCarbShare lunch"},
+		},
+		{
+			name:       "diabetes, one meal logged is not a distribution",
+			conditions: []string{models.HealthConditionDiabetes},
+			day:        models.DailyNutrition{Calories: 800, Carbs: 90},
+			meals:      []models.MealIntake{{MealType: "lunch", Carbs: 90}},
+			expected:   nil,
+		},
+		{
+			name:       "diabetes, high glycemic load",
+			conditions: []string{models.HealthConditionDiabetes},
+			day:        models.DailyNutrition{Calories: 1900, Carbs: 220},
+			meals:      []models.MealIntake{{MealType: "breakfast", Carbs: 70, GlycemicLoad: 35}, {MealType: "lunch", Carbs: 80, GlycemicLoad: 40}, {MealType: "dinner", Carbs: 70, GlycemicLoad: 30}},
+			expected:   []string{"glycemicLoad breakfast", "glycemicLoad lunch", "glycemicLoad dinner", "glycemicLoad"},
+		},
+		{
+			name:       "hypertension, salty day",
+			conditions: []string{models.HealthConditionHypertension},
+			day:        models.DailyNutrition{Calories: 1900, Carbs: 220, Sodium: 1800},
+			expected:   []string{"sodium"},
+		},
+		{
+			name:       "hypertension, within DASH",
+			conditions: []string{models.HealthConditionHypertension},
+			day:        models.DailyNutrition{Calories: 1900, Carbs: 220, Sodium: 1400},
+			expected:   nil,
+		},
+		{
+			name:       "renal, potassium and phosphorus over",
+			conditions: []string{models.HealthConditionRenal},
+			day: models.DailyNutrition{Calories: 1800, Carbs: 250, Sodium: 1900, Micronutrients: map[string]float64{
+				models.MicronutrientPotassium: 2600, models.MicronutrientPhosphorus: 950,
+			}},
+			expected: []string{"potassium", "phosphorus"},
+		},
+		{
+			name:       "renal, phosphorus not recorded",
+			conditions: []string{models.HealthConditionRenal},
+			day:        models.DailyNutrition{Calories: 1800, Carbs: 250, Micronutrients: map[string]float64{models.MicronutrientPotassium: 1800}},
+			expected:   nil,
+		},
+		{
+			name:       "pcos, carbs within the diabetes share but over its own",
+			conditions: []string{models.HealthConditionPCOS},
+			day:        models.DailyNutrition{Calories: 2000, Carbs: 240},
+			meals:      balancedMeals,
+			expected:   []string{"carbShare"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			warnings := EvaluateConditions(tt.conditions, tt.day, tt.meals)
+
+			// Assert
+			var found []string
+			for _, w := range warnings {
+				if w.MealType != "" {
+					found = append(found, w.Nutrient+" "+w.MealType)
+					continue
+				}
+				found = append(found, w.Nutrient)
+			}
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+}
+
+func TestEvaluateConditions_MergesSharedLimits(t *testing.T) {
+	// Arrange
+	day := models.DailyNutrition{Calories: 1800, Carbs: 200, Sodium: 2100}
+
+	// Act
+	warnings := EvaluateConditions([]string{models.HealthConditionRenal, models.HealthConditionHypertension}, day, nil)
+
+	// Assert
+	require.Len(t, warnings, 1)
+	assert.Equal(t, []string{"hypertension", "renal"}, warnings[0].Conditions)
+	assert.Equal(t, 1500.0, warnings[0].Limit)
+	assert.Equal(t, 2100.0, warnings[0].Actual)
+	assert.Equal(t, "Sodium was 2100 mg, above the 1500 mg limit for hypertension and renal", warnings[0].Message)
+}
+
+func TestEvaluateConditions_Messages(t *testing.T) {
+	// Arrange
+	day := models.DailyNutrition{Calories: 2000, Carbs: 300}
+	meals := []models.MealIntake{{MealType: "breakfast", Carbs: 100}, {MealType: "lunch", Carbs: 200, GlycemicLoad: 26}}
+
+	// Act
+	warnings := EvaluateConditions([]string{models.HealthConditionDiabetes}, day, meals)
+
+	// Assert
+	require.Len(t, warnings, 3)
+	assert.Equal(t, "Carbs as a share of calories was 60%, above the 50% limit for diabetes", warnings[0].Message)
+	assert.Equal(t, "Glycemic load at lunch was 26, above the 20 limit for diabetes", warnings[1].Message)
+	assert.Equal(t, "Share of the day's carbs at lunch was 66.7%, above the 40% limit for diabetes", warnings[2].Message)
+}
+
+func TestDishConditionConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		nutrition  models.Nutrition
+		expected   []string
+	}{
+		{"light dish", []string{models.HealthConditionDiabetes, models.HealthConditionHypertension}, models.Nutrition{Carbs: 45, Sodium: 400, Sugar: 5}, nil},
+		{"salty dish for hypertension", []string{models.HealthConditionHypertension}, models.Nutrition{Carbs: 45, Sodium: 900}, []string{"sodium"}},
+		{"rice-heavy dish for diabetes", []string{models.HealthConditionDiabetes}, models.Nutrition{Carbs: 120, Sodium: 400}, []string{"carbs"}},
+		{"potassium-rich dish for renal", []string{models.HealthConditionRenal}, models.Nutrition{Micronutrients: map[string]float64{models.MicronutrientPotassium: 1100}}, []string{"potassium"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dish := &models.Dish{Nutrition: tt.nutrition}
+
+			// Act
+			conflicts := DishConditionConflicts(ConditionLimitsFor(tt.conditions), models.NutritionGoals{}, dish)
+
+			// Assert
+			assert.Equal(t, tt.expected, conflicts)
+		})
+	}
+}
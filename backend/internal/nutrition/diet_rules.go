@@ -0,0 +1,171 @@
+package nutrition
+
+import (
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// dietRuleSet declares what a religious or traditional diet allows. Catalog ingredients are
+// matched by category and key; ingredients the catalog does not know are matched by phrases
+// in their names.
+type dietRuleSet struct {
+	excludes     ingredientRule
+	excludeWords []string
+
+	// When set, only matching ingredients are allowed
+	allowOnly  *ingredientRule
+	allowWords []string
+
+	// Allowed even though they match an exclusion, e.g. peanuts on Ekadashi
+	exceptKeys  []string
+	exceptWords []string
+
+	// Allowed, but need checking
+	cautions     ingredientRule
+	cautionWords []string
+	cautionNote  string
+}
+
+// nonVegWords name meat, fish and eggs the catalog may not know
+var nonVegWords = []string{
+	"chicken", "mutton", "lamb", "goat", "beef", "pork", "bacon", "ham", "sausage", "salami", "pepperoni",
+	"fish", "prawn", "prawns", "shrimp", "crab", "lobster", "squid", "egg", "eggs", "anda", "keema", "gelatin", "gelatine",
+}
+
+// alcoholWords name alcoholic ingredients
+var alcoholWords = []string{"wine", "rum", "beer", "brandy", "whisky", "whiskey", "vodka", "alcohol", "liqueur", "sake", "mirin"}
+
+// vratWords name ingredients eaten during vrat (fasting) that the catalog does not know
+var vratWords = []string{
+	"kuttu", "buckwheat", "singhara", "water chestnut", "sabudana", "sago", "tapioca", "rajgira", "amaranth",
+	"samak", "sama", "barnyard millet", "vrat", "sendha namak", "rock salt", "sweet potato", "shakarkandi", "arbi",
+	"colocasia", "raw banana", "kachcha kela", "pumpkin", "kaddu", "lauki", "bottle gourd", "cucumber",
+	"black pepper", "kali mirch", "peanut oil", "groundnut oil", "makhana", "fox nut", "dates", "raisins", "kishmish",
+}
+
+// dietRuleSets declares each rule set
+var dietRuleSets = map[string]dietRuleSet{
+	models.DietRuleJain: {
+		excludes: ingredientRule{
+			categories: []string{"meat", "seafood", "egg"},
+			keys:       []string{"honey", "potato", "onion", "fried_onion", "garlic", "ginger", "carrot"},
+		},
+		excludeWords: append([]string{
+			"honey", "potato", "aloo", "onion", "pyaz", "garlic", "lehsun", "ginger", "adrak", "carrot", "gajar",
+			"radish", "mooli", "beetroot", "beet", "sweet potato", "shakarkandi", "yam", "suran", "arbi", "colocasia",
+			"turnip", "shalgam", "mushroom", "mushrooms",
+		}, nonVegWords...),
+	},
+	models.DietRuleSatvik: {
+		excludes: ingredientRule{
+			categories: []string{"meat", "seafood", "egg"},
+			keys:       []string{"onion", "fried_onion", "garlic"},
+		},
+		excludeWords: append(append([]string{"onion", "pyaz", "garlic", "lehsun", "mushroom", "mushrooms"}, nonVegWords...), alcoholWords...),
+	},
+	models.DietRuleNavratri: {
+		allowOnly: &ingredientRule{
+			categories: []string{"dairy", "fruit", "nut"},
+			keys: []string{
+				"ghee", "potato", "tomato", "peanut", "sugar", "jaggery", "honey", "cumin",
+				"green_chilli", "ginger", "coriander_leaves", "mint",
+			},
+		},
+		allowWords: append([]string{"potato", "aloo", "ghee", "peanut", "peanuts", "curd", "dahi", "milk", "paneer"}, vratWords...),
+	},
+	models.DietRuleEkadashi: {
+		excludes: ingredientRule{
+			categories: []string{"cereal", "legume", "meat", "seafood", "egg"},
+			keys:       []string{"onion", "fried_onion", "garlic"},
+		},
+		excludeWords: append([]string{
+			"rice", "wheat", "atta", "maida", "sooji", "suji", "rava", "poha", "oats", "corn", "maize", "bread",
+			"dal", "lentil", "lentils", "beans", "rajma", "chana", "besan", "soy", "soya", "onion", "garlic",
+		}, nonVegWords...),
+		exceptKeys:  []string{"peanut"},
+		exceptWords: vratWords,
+	},
+	models.DietRuleHalal: {
+		excludeWords: append([]string{"pork", "bacon", "ham", "lard", "gelatin", "gelatine", "pepperoni", "salami"}, alcoholWords...),
+		cautions:     ingredientRule{categories: []string{"meat"}},
+		cautionWords: []string{"chicken", "mutton", "lamb", "goat", "beef", "keema"},
+		cautionNote:  "must be halal-certified",
+	},
+}
+
+// EvaluateDietRules checks a dish's ingredients against every rule set
+func EvaluateDietRules(catalog *Catalog, dish *models.Dish) []models.DietRuleResult {
+	names := dishIngredientNames(dish)
+	results := make([]models.DietRuleResult, 0, len(dietRuleSets))
+	for _, name := range models.GetDietRules() {
+		results = append(results, evaluateDietRule(catalog, name, dietRuleSets[name], names))
+	}
+	return results
+}
+
+// CompliantDietRules returns the rule sets a dish's ingredients comply with
+func CompliantDietRules(catalog *Catalog, dish *models.Dish) []string {
+	rules := []string{}
+	for _, result := range EvaluateDietRules(catalog, dish) {
+		if result.Compliant {
+			rules = append(rules, result.RuleSet)
+		}
+	}
+	return rules
+}
+
+// evaluateDietRule checks ingredient names against one rule set
+func evaluateDietRule(catalog *Catalog, name string, rules dietRuleSet, ingredients []string) models.DietRuleResult {
+	result := models.DietRuleResult{RuleSet: name, Violations: []string{}}
+	for _, ingredientName := range ingredients {
+		ingredient, known := catalog.Lookup(ingredientName)
+		phrase := normalizeIngredient(ingredientName)
+
+		if !rules.allows(ingredient, known, phrase) {
+			result.Violations = append(result.Violations, ingredientName)
+			continue
+		}
+		if (known && rules.cautions.excludes(ingredient)) || (!known && hasPhrase(phrase, rules.cautionWords)) {
+			result.Cautions = append(result.Cautions, ingredientName+" "+rules.cautionNote)
+		}
+	}
+	result.Compliant = len(result.Violations) == 0
+	return result
+}
+
+// allows reports whether the rule set allows an ingredient
+func (r dietRuleSet) allows(ingredient Ingredient, known bool, phrase string) bool {
+	if (known && containsString(r.exceptKeys, ingredient.Key)) || hasPhrase(phrase, r.exceptWords) {
+		return true
+	}
+	if r.allowOnly != nil {
+		return (known && r.allowOnly.excludes(ingredient)) || hasPhrase(phrase, r.allowWords)
+	}
+	if known {
+		return !r.excludes.excludes(ingredient)
+	}
+	return !hasPhrase(phrase, r.excludeWords)
+}
+
+// hasPhrase reports whether a normalized name contains any of the phrases as whole words
+func hasPhrase(name string, phrases []string) bool {
+	padded := " " + strings.NewReplacer("-", " ", ",", " ", "(", " ", ")", " ").Replace(name) + " "
+	for _, phrase := range phrases {
+		if strings.Contains(padded, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// dishIngredientNames returns the names of a dish's ingredients and ingredient quantities
+func dishIngredientNames(dish *models.Dish) []string {
+	names := append([]string{}, dish.Ingredients...)
+	for _, quantity := range dish.IngredientQuantities {
+		if !containsString(names, quantity.Name) {
+			names = append(names, quantity.Name)
+		}
+	}
+	return names
+}
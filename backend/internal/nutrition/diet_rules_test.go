@@ -0,0 +1,61 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateDietRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		ingredients []string
+		compliant   []string
+	}{
+		{"dal tadka", []string{"toor dal", "onion", "tomato", "ghee", "cumin", "salt"}, []string{"halal"}},
+		{"jeera aloo", []string{"potato", "cumin", "green chilli", "salt"}, []string{"satvik", "ekadashi", "halal"}},
+		{"paneer tikka without onion", []string{"paneer", "curd", "capsicum", "red chilli powder", "salt"}, []string{"jain", "satvik", "ekadashi", "halal"}},
+		{"sabudana khichdi", []string{"sabudana", "peanuts", "potato", "ghee", "cumin", "sendha namak", "green chilli"}, []string{"satvik", "navratri", "ekadashi", "halal"}},
+		{"kuttu puri", []string{"kuttu atta", "boiled potato", "sendha namak", "ghee"}, []string{"satvik", "navratri", "ekadashi", "halal"}},
+		{"butter chicken", []string{"chicken", "butter", "tomato", "cream", "garlic"}, []string{"halal"}},
+		{"pork vindaloo", []string{"pork", "vinegar", "garlic"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dish := &models.Dish{Ingredients: tt.ingredients}
+
+			// Act
+			compliant := CompliantDietRules(DefaultCatalog(), dish)
+
+			// Assert
+			assert.Equal(t, tt.compliant, compliant)
+		})
+	}
+}
+
+func TestEvaluateDietRules_Explains(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		Ingredients:          []string{"chicken", "onion", "rice"},
+		IngredientQuantities: []models.IngredientQuantity{{Name: "garlic", Quantity: 2, Unit: models.UnitPiece}},
+	}
+
+	// Act
+	results := EvaluateDietRules(DefaultCatalog(), dish)
+
+	// Assert
+	require.Len(t, results, 5)
+	jain := results[0]
+	assert.Equal(t, models.DietRuleJain, jain.RuleSet)
+	assert.False(t, jain.Compliant)
+	assert.Equal(t, []string{"chicken", "onion", "garlic"}, jain.Violations)
+
+	halal := results[4]
+	assert.True(t, halal.Compliant)
+	assert.Equal(t, []string{"chicken must be halal-certified"}, halal.Cautions)
+}
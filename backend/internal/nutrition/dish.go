@@ -0,0 +1,203 @@
+package nutrition
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"nourish-backend/internal/models"
+)
+
+// Tolerances for comparing entered nutrition with calculated values. A value is a
+// mismatch when it differs by more than the relative tolerance and the absolute floor.
+const (
+	mismatchTolerance  = 0.20 // 20%
+	mismatchCaloriesKc = 30   // kcal
+	mismatchGrams      = 3    // g
+	mismatchSodiumMg   = 100  // mg
+)
+
+// DishEstimate is the nutrition per serving calculated from a recipe's ingredients
+type DishEstimate struct {
+	Calories  int
+	Nutrition models.Nutrition
+
+	// Glycemic load per serving of the ingredients with a known glycemic index
+	GlycemicLoad float64
+	Matched      int      // ingredients found in the catalog
+	Unmatched    []string // ingredient names not in the catalog or with unusable units
+}
+
+// Coverage returns the percentage of ingredients found in the catalog
+func (e DishEstimate) Coverage() float64 {
+	total := e.Matched + len(e.Unmatched)
+	if total == 0 {
+		return 0
+	}
+	return roundTo(float64(e.Matched)/float64(total)*100, 1)
+}
+
+// CalculateDish sums the composition of each ingredient quantity and divides by servings.
+// The canonical key of every recognised ingredient is written back into quantities.
+func CalculateDish(catalog *Catalog, quantities []models.IngredientQuantity, servings int) DishEstimate {
+	if servings < 1 {
+		servings = 1
+	}
+
+	var estimate DishEstimate
+	var calories, protein, carbs, fat, fiber, sugar, sodium, glycemicLoad float64
+	micronutrients := map[string]float64{}
+
+	for i := range quantities {
+		quantity := &quantities[i]
+		ingredient, ok := catalog.Lookup(quantity.Name)
+		if !ok {
+			quantity.IngredientKey = ""
+			estimate.Unmatched = append(estimate.Unmatched, quantity.Name)
+			continue
+		}
+		quantity.IngredientKey = ingredient.Key
+
+		grams, err := ingredient.Grams(quantity.Quantity, quantity.Unit)
+		if err != nil {
+			estimate.Unmatched = append(estimate.Unmatched, quantity.Name)
+			continue
+		}
+		estimate.Matched++
+
+		factor := grams / 100
+		calories += ingredient.Calories * factor
+		protein += ingredient.Protein * factor
+		carbs += ingredient.Carbs * factor
+		fat += ingredient.Fat * factor
+		fiber += ingredient.Fiber * factor
+		sugar += ingredient.Sugar * factor
+		sodium += ingredient.Sodium * factor
+		glycemicLoad += ingredientGlycemicLoad(ingredient) * factor
+		for key, value := range ingredient.Micronutrients {
+			micronutrients[key] += value * factor
+		}
+	}
+
+	perServing := func(total float64) int {
+		return round(total / float64(servings))
+	}
+	estimate.Calories = perServing(calories)
+	estimate.Nutrition = models.Nutrition{
+		Protein: perServing(protein),
+		Carbs:   perServing(carbs),
+		Fat:     perServing(fat),
+		Fiber:   perServing(fiber),
+		Sugar:   perServing(sugar),
+		Sodium:  perServing(sodium),
+	}
+	estimate.GlycemicLoad = roundTo(glycemicLoad/float64(servings), 1)
+	for key, total := range micronutrients {
+		if value := roundTo(total/float64(servings), 1); value > 0 {
+			if estimate.Nutrition.Micronutrients == nil {
+				estimate.Nutrition.Micronutrients = map[string]float64{}
+			}
+			estimate.Nutrition.Micronutrients[key] = value
+		}
+	}
+	return estimate
+}
+
+// CompareNutrition lists the entered values that differ from the calculated ones beyond tolerance.
+// Micronutrients are not compared because they are often left out of hand-entered data.
+func CompareNutrition(calories int, entered models.Nutrition, estimate DishEstimate) []models.NutrientMismatch {
+	checks := []struct {
+		nutrient   string
+		entered    int
+		calculated int
+		floor      float64
+	}{
+		{models.NutrientCalories, calories, estimate.Calories, mismatchCaloriesKc},
+		{models.NutrientProtein, entered.Protein, estimate.Nutrition.Protein, mismatchGrams},
+		{models.NutrientCarbs, entered.Carbs, estimate.Nutrition.Carbs, mismatchGrams},
+		{models.NutrientFat, entered.Fat, estimate.Nutrition.Fat, mismatchGrams},
+		{models.NutrientFiber, entered.Fiber, estimate.Nutrition.Fiber, mismatchGrams},
+		{models.NutrientSugar, entered.Sugar, estimate.Nutrition.Sugar, mismatchGrams},
+		{models.NutrientSodium, entered.Sodium, estimate.Nutrition.Sodium, mismatchSodiumMg},
+	}
+
+	var mismatches []models.NutrientMismatch
+	for _, check := range checks {
+		difference := math.Abs(float64(check.entered - check.calculated))
+		if difference <= check.floor || difference <= float64(check.calculated)*mismatchTolerance {
+			continue
+		}
+		deviation := 100.0
+		if check.calculated > 0 {
+			deviation = roundTo(float64(check.entered-check.calculated)/float64(check.calculated)*100, 1)
+		}
+		mismatches = append(mismatches, models.NutrientMismatch{
+			Nutrient:         check.nutrient,
+			Entered:          float64(check.entered),
+			Calculated:       float64(check.calculated),
+			DeviationPercent: deviation,
+		})
+	}
+	return mismatches
+}
+
+// ApplyDishNutrition calculates a dish's nutrition from its ingredient quantities. Dishes with
+// calculated nutrition are filled in; dishes with manual nutrition are checked for mismatches.
+// Ingredient names are derived from the quantities when the dish has none.
+func ApplyDishNutrition(catalog *Catalog, dish *models.Dish, now time.Time) error {
+	if dish.NutritionSource == "" {
+		dish.NutritionSource = models.NutritionSourceManual
+	}
+	if len(dish.IngredientQuantities) == 0 {
+		if dish.NutritionSource == models.NutritionSourceCalculated {
+			return errors.New("ingredient quantities are required to calculate nutrition")
+		}
+		dish.NutritionCheck = nil
+		return nil
+	}
+
+	if len(dish.Ingredients) == 0 {
+		for _, quantity := range dish.IngredientQuantities {
+			dish.Ingredients = append(dish.Ingredients, quantity.Name)
+		}
+	}
+
+	estimate := CalculateDish(catalog, dish.IngredientQuantities, dish.Servings)
+	check := &models.NutritionCheck{
+		Calories:  estimate.Calories,
+		Nutrition: estimate.Nutrition,
+		Coverage:  estimate.Coverage(),
+		Unmatched: estimate.Unmatched,
+		CheckedAt: now,
+	}
+
+	switch {
+	case estimate.Matched == 0:
+		if dish.NutritionSource == models.NutritionSourceCalculated {
+			return errors.New("no ingredients found in the food composition table")
+		}
+		check.Status = models.NutritionCheckUnverified
+	case dish.NutritionSource == models.NutritionSourceCalculated:
+		dish.Calories = estimate.Calories
+		dish.Nutrition = estimate.Nutrition
+		check.Status = models.NutritionCheckCalculated
+	default:
+		check.Mismatches = CompareNutrition(dish.Calories, dish.Nutrition, estimate)
+		check.Status = models.NutritionCheckConsistent
+		if len(check.Mismatches) > 0 {
+			check.Status = models.NutritionCheckMismatch
+		}
+	}
+
+	dish.NutritionCheck = check
+	return nil
+}
+
+// ApplyIngredientFacts derives a dish's allergens, the diet rules it complies with and its
+// glycemic load from its ingredients
+func ApplyIngredientFacts(catalog *Catalog, dish *models.Dish, now time.Time) {
+	dish.Allergens = DishAllergens(catalog, dish)
+	dish.DietRules = CompliantDietRules(catalog, dish)
+	dish.GlycemicLoad = DishGlycemicLoad(catalog, dish)
+	dish.FactsDerivedAt = &now
+}
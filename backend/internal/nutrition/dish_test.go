@@ -0,0 +1,154 @@
+package nutrition
+
+import (
+	"testing"
+	"time"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dalTadka returns quantities for four servings of dal tadka
+func dalTadka() []models.IngredientQuantity {
+	return []models.IngredientQuantity{
+		{Name: "toor dal", Quantity: 200, Unit: models.UnitGram},
+		{Name: "onion", Quantity: 1, Unit: models.UnitPiece},
+		{Name: "tomato", Quantity: 2, Unit: models.UnitPiece},
+		{Name: "ghee", Quantity: 2, Unit: models.UnitTablespoon},
+		{Name: "salt", Quantity: 1, Unit: models.UnitTeaspoon},
+	}
+}
+
+func TestCalculateDish(t *testing.T) {
+	// Arrange
+	quantities := dalTadka()
+
+	// Act
+	estimate := CalculateDish(DefaultCatalog(), quantities, 4)
+
+	// Assert - 686 + 44 + 36 + 246 kcal over four servings
+	assert.Equal(t, 5, estimate.Matched)
+	assert.Empty(t, estimate.Unmatched)
+	assert.Equal(t, 253, estimate.Calories)
+	assert.Equal(t, 12, estimate.Nutrition.Protein)
+	assert.Equal(t, 8, estimate.Nutrition.Fat)
+	assert.Equal(t, 593, estimate.Nutrition.Sodium)
+	assert.Equal(t, 2.2, estimate.Nutrition.Micronutrients[models.MicronutrientIron])
+	assert.Equal(t, 100.0, estimate.Coverage())
+	assert.Equal(t, "toor_dal", quantities[0].IngredientKey)
+}
+
+func TestCalculateDish_Unmatched(t *testing.T) {
+	// Arrange
+	quantities := []models.IngredientQuantity{
+		{Name: "paneer", Quantity: 200, Unit: models.UnitGram},
+		{Name: "spinach", Quantity: 2, Unit: models.UnitPiece},
+		{Name: "kasuri methi", Quantity: 1, Unit: models.UnitTeaspoon},
+	}
+
+	// Act
+	estimate := CalculateDish(DefaultCatalog(), quantities, 0)
+
+	// Assert - spinach has no piece weight; servings default to one
+	assert.Equal(t, 1, estimate.Matched)
+	assert.Equal(t, []string{"spinach", "kasuri methi"}, estimate.Unmatched)
+	assert.Equal(t, 530, estimate.Calories)
+	assert.Equal(t, 33.3, estimate.Coverage())
+}
+
+func TestApplyDishNutrition(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		dish           models.Dish
+		expectErr      string
+		expectStatus   string
+		expectCalories int
+		mismatches     []string
+	}{
+		{
+			name:           "auto-fill",
+			dish:           models.Dish{NutritionSource: models.NutritionSourceCalculated, Servings: 4, IngredientQuantities: dalTadka()},
+			expectStatus:   models.NutritionCheckCalculated,
+			expectCalories: 253,
+		},
+		{
+			name: "consistent",
+			dish: models.Dish{
+				Servings: 4, IngredientQuantities: dalTadka(), Calories: 240,
+				Nutrition: models.Nutrition{Protein: 12, Carbs: 33, Fat: 7, Fiber: 6, Sugar: 3, Sodium: 600},
+			},
+			expectStatus:   models.NutritionCheckConsistent,
+			expectCalories: 240,
+		},
+		{
+			name: "mismatch",
+			dish: models.Dish{
+				Servings: 4, IngredientQuantities: dalTadka(), Calories: 180,
+				Nutrition: models.Nutrition{Protein: 12, Carbs: 33, Fat: 7, Fiber: 6, Sugar: 3, Sodium: 200},
+			},
+			expectStatus:   models.NutritionCheckMismatch,
+			expectCalories: 180,
+			mismatches:     []string{models.NutrientCalories, models.NutrientSodium},
+		},
+		{
+			name:         "nothing recognised",
+			dish:         models.Dish{Servings: 2, Calories: 300, IngredientQuantities: []models.IngredientQuantity{{Name: "kasuri methi", Quantity: 1, Unit: models.UnitGram}}},
+			expectStatus: models.NutritionCheckUnverified, expectCalories: 300,
+		},
+		{
+			name:      "auto-fill with nothing recognised",
+			dish:      models.Dish{NutritionSource: models.NutritionSourceCalculated, IngredientQuantities: []models.IngredientQuantity{{Name: "kasuri methi", Quantity: 1, Unit: models.UnitGram}}},
+			expectErr: "no ingredients found in the food composition table",
+		},
+		{
+			name:      "auto-fill without quantities",
+			dish:      models.Dish{NutritionSource: models.NutritionSourceCalculated},
+			expectErr: "ingredient quantities are required to calculate nutrition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			dish := tt.dish
+			err := ApplyDishNutrition(DefaultCatalog(), &dish, now)
+
+			// Assert
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, dish.NutritionCheck)
+			assert.Equal(t, tt.expectStatus, dish.NutritionCheck.Status)
+			assert.Equal(t, tt.expectCalories, dish.Calories)
+			assert.Equal(t, now, dish.NutritionCheck.CheckedAt)
+			assert.NotEmpty(t, dish.Ingredients)
+
+			var nutrients []string
+			for _, mismatch := range dish.NutritionCheck.Mismatches {
+				nutrients = append(nutrients, mismatch.Nutrient)
+			}
+			assert.Equal(t, tt.mismatches, nutrients)
+		})
+	}
+}
+
+func TestApplyDishNutrition_ManualWithoutQuantities(t *testing.T) {
+	// Arrange
+	dish := models.Dish{Calories: 300, NutritionCheck: &models.NutritionCheck{Status: models.NutritionCheckMismatch}}
+
+	// Act
+	err := ApplyDishNutrition(DefaultCatalog(), &dish, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.NutritionSourceManual, dish.NutritionSource)
+	assert.Nil(t, dish.NutritionCheck)
+	assert.Equal(t, 300, dish.Calories)
+}
@@ -0,0 +1,48 @@
+package nutrition
+
+import (
+	"math"
+
+	"nourish-backend/internal/models"
+)
+
+// DishGlycemicLoad estimates the glycemic load of one serving. With ingredient quantities it
+// sums each ingredient's carb contribution; otherwise the carb-weighted glycemic index of the
+// named ingredients is applied to the dish's available carbs. It returns nil when no
+// ingredient has a known glycemic index.
+func DishGlycemicLoad(catalog *Catalog, dish *models.Dish) *float64 {
+	if len(dish.IngredientQuantities) > 0 {
+		quantities := append([]models.IngredientQuantity(nil), dish.IngredientQuantities...)
+		if estimate := CalculateDish(catalog, quantities, dish.Servings); estimate.Matched > 0 {
+			return &estimate.GlycemicLoad
+		}
+	}
+
+	var weighted, weights float64
+	for _, name := range dishIngredientNames(dish) {
+		ingredient, ok := catalog.Lookup(name)
+		if !ok || ingredient.GlycemicIndex == 0 {
+			continue
+		}
+		available := availableCarbs(ingredient)
+		weighted += ingredient.GlycemicIndex * available
+		weights += available
+	}
+	if weights == 0 {
+		return nil
+	}
+
+	available := math.Max(float64(dish.Nutrition.Carbs-dish.Nutrition.Fiber), 0)
+	glycemicLoad := roundTo(weighted/weights*available/100, 1)
+	return &glycemicLoad
+}
+
+// ingredientGlycemicLoad is the glycemic load of 100 g of an ingredient
+func ingredientGlycemicLoad(ingredient Ingredient) float64 {
+	return ingredient.GlycemicIndex * availableCarbs(ingredient) / 100
+}
+
+// availableCarbs is the carbs in 100 g of an ingredient less its fiber
+func availableCarbs(ingredient Ingredient) float64 {
+	return math.Max(ingredient.Carbs-ingredient.Fiber, 0)
+}
@@ -0,0 +1,86 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDishGlycemicLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		dish     models.Dish
+		expected *float64
+	}{
+		{
+			name: "from ingredient quantities",
+			dish: models.Dish{
+				Servings: 2,
+				IngredientQuantities: []models.IngredientQuantity{
+					{Name: "rice", Quantity: 150, Unit: models.UnitGram},
+					{Name: "toor dal", Quantity: 100, Unit: models.UnitGram},
+					{Name: "salt", Quantity: 5, Unit: models.UnitGram},
+				},
+			},
+			expected: floatPtr(46.5), // (73 × 75.2 × 1.5 + 22 × 48.4) / 100 / 2
+		},
+		{
+			name: "from ingredient names and the dish's carbs",
+			dish: models.Dish{
+				Ingredients: []string{"potato", "tomato", "cumin"},
+				Nutrition:   models.Nutrition{Carbs: 30, Fiber: 4},
+			},
+			expected: floatPtr(17.8), // carb-weighted GI 68.3 applied to 26 g
+		},
+		{
+			name: "no ingredient with a glycemic index",
+			dish: models.Dish{
+				Ingredients: []string{"chicken", "salt"},
+				Nutrition:   models.Nutrition{Carbs: 2},
+			},
+			expected: nil,
+		},
+		{
+			name: "unknown ingredients",
+			dish: models.Dish{
+				Servings:             1,
+				IngredientQuantities: []models.IngredientQuantity{{Name: "mystery flour", Quantity: 100, Unit: models.UnitGram}},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			glycemicLoad := DishGlycemicLoad(DefaultCatalog(), &tt.dish)
+
+			// Assert
+			assert.Equal(t, tt.expected, glycemicLoad)
+		})
+	}
+}
+
+func TestDishGlycemicLoad_LeavesQuantitiesUnchanged(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		Servings:             1,
+		IngredientQuantities: []models.IngredientQuantity{{Name: "Basmati", Quantity: 100, Unit: models.UnitGram}},
+	}
+
+	// Act
+	glycemicLoad := DishGlycemicLoad(DefaultCatalog(), dish)
+
+	// Assert
+	require.NotNil(t, glycemicLoad)
+	assert.Equal(t, 44.2, *glycemicLoad) // 58 × 76.2 / 100
+	assert.Empty(t, dish.IngredientQuantities[0].IngredientKey)
+	assert.Equal(t, models.GlycemicLoadHigh, models.GlycemicLoadLevel(*glycemicLoad))
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
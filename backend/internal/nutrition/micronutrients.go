@@ -0,0 +1,120 @@
+package nutrition
+
+import (
+	"fmt"
+	"math"
+
+	"nourish-backend/internal/models"
+)
+
+// MicronutrientSource names the reference intakes used in the deficiency report
+const MicronutrientSource = "ICMR-NIN Recommended Dietary Allowances 2020, adults"
+
+// Report thresholds as a percentage of the reference intake
+const (
+	deficientBelowPercent = 50 // below half the RDA
+	lowBelowPercent       = 80
+	saturatedFatShare     = 0.10 // saturated fat below 10% of energy
+	minDataCoverage       = 50   // warn when fewer than half the meals have micronutrient data
+)
+
+// adultRDA holds sex-specific reference intakes for sedentary-to-moderate adults
+var adultRDA = map[string]map[string]float64{
+	models.SexMale: {
+		models.MicronutrientIron:       19,
+		models.MicronutrientCalcium:    1000,
+		models.MicronutrientVitaminB12: 2.5,
+		models.MicronutrientVitaminD:   15,
+		models.MicronutrientFolate:     300,
+		models.MicronutrientPotassium:  3510,
+	},
+	models.SexFemale: {
+		models.MicronutrientIron:       29,
+		models.MicronutrientCalcium:    1000,
+		models.MicronutrientVitaminB12: 2.5,
+		models.MicronutrientVitaminD:   15,
+		models.MicronutrientFolate:     220,
+		models.MicronutrientPotassium:  3510,
+	},
+}
+
+// MicronutrientReferences returns the reference intake for each tracked micronutrient.
+// Without body metrics the higher of the male and female values is used, so that
+// deficiencies are not missed. The saturated fat limit scales with the calorie goal.
+func MicronutrientReferences(metrics *models.BodyMetrics, dailyCalories int) map[string]float64 {
+	refs := map[string]float64{}
+	if metrics != nil {
+		if rda, ok := adultRDA[metrics.Sex]; ok {
+			for key, value := range rda {
+				refs[key] = value
+			}
+		}
+	}
+	if len(refs) == 0 {
+		for _, rda := range adultRDA {
+			for key, value := range rda {
+				refs[key] = math.Max(refs[key], value)
+			}
+		}
+	}
+
+	if dailyCalories <= 0 {
+		dailyCalories = DefaultGoals().DailyCalories
+	}
+	refs[models.MicronutrientSaturatedFat] = math.Round(float64(dailyCalories) * saturatedFatShare / caloriesPerGramFat)
+	return refs
+}
+
+// BuildDeficiencyReport compares average micronutrient intake with the reference intakes
+func BuildDeficiencyReport(intake models.MicronutrientIntake, refs map[string]float64) models.DeficiencyReport {
+	report := models.DeficiencyReport{
+		Range:      intake.Range,
+		DaysLogged: intake.DaysLogged,
+		Nutrients:  []models.MicronutrientStatus{},
+		Deficient:  []string{},
+		Source:     MicronutrientSource,
+	}
+	if intake.MealCount > 0 {
+		report.DataCoverage = roundTo(float64(intake.MealsWithData)/float64(intake.MealCount)*100, 1)
+	}
+
+	if intake.DaysLogged == 0 {
+		report.Notes = append(report.Notes, "No meals were logged in this period")
+		return report
+	}
+	if report.DataCoverage < minDataCoverage {
+		report.Notes = append(report.Notes, fmt.Sprintf("Only %.0f%% of logged meals have micronutrient data, so intakes are likely underestimated", report.DataCoverage))
+	}
+
+	for _, info := range models.GetMicronutrients() {
+		ref, ok := refs[info.Key]
+		if !ok || ref <= 0 {
+			continue
+		}
+		avg := intake.Averages[info.Key]
+		percent := roundTo(avg/ref*100, 1)
+
+		status := models.MicronutrientAdequate
+		switch {
+		case info.Kind == models.GoalKindMax && percent > 100:
+			status = models.MicronutrientExcess
+		case info.Kind == models.GoalKindMax:
+		case percent < deficientBelowPercent:
+			status = models.MicronutrientDeficient
+		case percent < lowBelowPercent:
+			status = models.MicronutrientLow
+		}
+		if status == models.MicronutrientDeficient {
+			report.Deficient = append(report.Deficient, info.Key)
+		}
+
+		report.Nutrients = append(report.Nutrients, models.MicronutrientStatus{
+			MicronutrientInfo: info,
+			AvgIntake:         roundTo(avg, 2),
+			Reference:         ref,
+			PercentOfRef:      percent,
+			Status:            status,
+		})
+	}
+	return report
+}
@@ -0,0 +1,63 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMicronutrientReferences(t *testing.T) {
+	female := &models.BodyMetrics{Sex: models.SexFemale}
+
+	assert.Equal(t, 29.0, MicronutrientReferences(female, 2000)[models.MicronutrientIron])
+	assert.Equal(t, 22.0, MicronutrientReferences(female, 2000)[models.MicronutrientSaturatedFat])
+
+	// Without body metrics the higher value is used
+	refs := MicronutrientReferences(nil, 0)
+	assert.Equal(t, 29.0, refs[models.MicronutrientIron])
+	assert.Equal(t, 300.0, refs[models.MicronutrientFolate])
+}
+
+func TestBuildDeficiencyReport(t *testing.T) {
+	// Arrange - a vegetarian week low in B12 and iron, high in saturated fat
+	intake := models.MicronutrientIntake{
+		DaysLogged:    7,
+		MealCount:     21,
+		MealsWithData: 21,
+		Averages: map[string]float64{
+			models.MicronutrientIron:         12,
+			models.MicronutrientCalcium:      950,
+			models.MicronutrientVitaminB12:   0.5,
+			models.MicronutrientSaturatedFat: 30,
+		},
+	}
+	refs := MicronutrientReferences(&models.BodyMetrics{Sex: models.SexMale}, 2000)
+
+	// Act
+	report := BuildDeficiencyReport(intake, refs)
+
+	// Assert
+	statuses := map[string]string{}
+	for _, n := range report.Nutrients {
+		statuses[n.Key] = n.Status
+	}
+	require.Len(t, report.Nutrients, len(models.GetMicronutrients()))
+	assert.Equal(t, models.MicronutrientLow, statuses[models.MicronutrientIron])
+	assert.Equal(t, models.MicronutrientAdequate, statuses[models.MicronutrientCalcium])
+	assert.Equal(t, models.MicronutrientDeficient, statuses[models.MicronutrientVitaminB12])
+	assert.Equal(t, models.MicronutrientDeficient, statuses[models.MicronutrientVitaminD])
+	assert.Equal(t, models.MicronutrientExcess, statuses[models.MicronutrientSaturatedFat])
+	assert.Contains(t, report.Deficient, models.MicronutrientVitaminB12)
+	assert.Equal(t, 100.0, report.DataCoverage)
+	assert.Empty(t, report.Notes)
+}
+
+func TestBuildDeficiencyReport_LowCoverage(t *testing.T) {
+	report := BuildDeficiencyReport(models.MicronutrientIntake{DaysLogged: 2, MealCount: 4, MealsWithData: 1}, MicronutrientReferences(nil, 2000))
+
+	assert.Equal(t, 25.0, report.DataCoverage)
+	assert.NotEmpty(t, report.Notes)
+}
@@ -0,0 +1,267 @@
+package nutrition
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// MaxScaledServings is the largest batch a recipe can be scaled to
+const MaxScaledServings = 100
+
+// seasoningExponent scales salt, spices and chillies less than proportionally:
+// four times the servings needs about three times the seasoning.
+const seasoningExponent = 0.8
+
+// Household measure thresholds in millilitres
+const (
+	pinchBelowMl     = 0.625 // an eighth of a teaspoon
+	tablespoonFromMl = tablespoonMl
+	largeMeasureFrom = 4 * tablespoonMl // a quarter cup
+)
+
+// cookingTimeFactor is how much larger or smaller a batch can be before cooking times change
+const cookingTimeFactor = 2
+
+// ScaleDish recalculates a dish's ingredient quantities and nutrition for the given number
+// of servings, converting quantities to metric or household measures.
+func ScaleDish(catalog *Catalog, dish *models.Dish, servings int, units string) (*models.ScaledDish, error) {
+	if servings < 1 || servings > MaxScaledServings {
+		return nil, fmt.Errorf("servings must be between 1 and %d", MaxScaledServings)
+	}
+	if units == "" {
+		units = models.UnitsMetric
+	}
+	if units != models.UnitsMetric && units != models.UnitsHousehold {
+		return nil, errors.New("units must be metric or household")
+	}
+
+	original := dish.Servings
+	if original < 1 {
+		original = 1
+	}
+	factor := float64(servings) / float64(original)
+
+	scaled := &models.ScaledDish{
+		DishID:           dish.ID.Hex(),
+		Name:             dish.Name,
+		OriginalServings: original,
+		Servings:         servings,
+		Factor:           roundTo(factor, 3),
+		Units:            units,
+		Ingredients:      []models.ScaledIngredient{},
+		Calories:         dish.Calories,
+		Nutrition:        dish.Nutrition,
+	}
+
+	quantities := make([]models.IngredientQuantity, 0, len(dish.IngredientQuantities))
+	adjusted := false
+	for _, quantity := range dish.IngredientQuantities {
+		ingredient, known := catalog.Lookup(quantity.Name)
+
+		ingredientFactor := factor
+		if known && isSeasoning(ingredient) && factor != 1 {
+			ingredientFactor = math.Pow(factor, seasoningExponent)
+			adjusted = true
+		}
+		amount := quantity.Quantity * ingredientFactor
+		quantities = append(quantities, models.IngredientQuantity{Name: quantity.Name, Quantity: amount, Unit: quantity.Unit})
+
+		item := convertQuantity(ingredient, known, amount, quantity.Unit, units)
+		item.Name = quantity.Name
+		item.Original = quantity
+		item.Adjusted = known && isSeasoning(ingredient) && factor != 1
+		if known {
+			item.IngredientKey = ingredient.Key
+			if grams, err := ingredient.Grams(amount, quantity.Unit); err == nil {
+				item.Grams = roundTo(grams, 1)
+			}
+		}
+		scaled.Ingredients = append(scaled.Ingredients, item)
+	}
+
+	// Calculated nutrition is recalculated so that seasoning adjustments show in sodium
+	if dish.NutritionSource == models.NutritionSourceCalculated && len(quantities) > 0 {
+		if estimate := CalculateDish(catalog, quantities, servings); estimate.Matched > 0 {
+			scaled.Calories = estimate.Calories
+			scaled.Nutrition = estimate.Nutrition
+		}
+	}
+	scaled.TotalCalories = scaled.Calories * servings
+	scaled.TotalNutrition = multiplyNutrition(scaled.Nutrition, servings)
+
+	if len(dish.IngredientQuantities) == 0 {
+		scaled.Notes = append(scaled.Notes, "This dish has no ingredient quantities, so only its nutrition was scaled")
+	}
+	if adjusted {
+		scaled.Notes = append(scaled.Notes, "Salt, spices and chillies are scaled less than proportionally; taste and adjust before serving")
+	}
+	if factor >= cookingTimeFactor || factor <= 1.0/cookingTimeFactor {
+		scaled.Notes = append(scaled.Notes, "Cooking times and pressure cooker whistles may change for a batch of this size")
+	}
+	return scaled, nil
+}
+
+// isSeasoning reports whether an ingredient should scale less than proportionally
+func isSeasoning(ingredient Ingredient) bool {
+	return ingredient.Category == "spice" || ingredient.Key == "green_chilli"
+}
+
+// convertQuantity expresses an amount in the requested unit system
+func convertQuantity(ingredient Ingredient, known bool, amount float64, unit, units string) models.ScaledIngredient {
+	switch {
+	case unit == models.UnitPiece:
+		return measured(roundPieces(amount), models.UnitPiece)
+	case unit == models.UnitPinch:
+		return measured(math.Max(1, math.Round(amount)), models.UnitPinch)
+	}
+
+	density := 1.0
+	if known && ingredient.Density > 0 {
+		density = ingredient.Density
+	}
+
+	if ml, ok := millilitres(amount, unit); ok {
+		if units == models.UnitsHousehold {
+			return householdVolume(ml, density, unit)
+		}
+		return metric(ml, models.UnitMillilitre, models.UnitLitre)
+	}
+
+	grams := amount
+	if unit == models.UnitKilogram {
+		grams = amount * 1000
+	}
+	if units == models.UnitsHousehold && known {
+		switch {
+		case ingredient.PieceGrams > 0 && (ingredient.Category == "vegetable" || ingredient.Category == "fruit" || ingredient.Category == "egg"):
+			return measured(roundPieces(grams/ingredient.PieceGrams), models.UnitPiece)
+		case ingredient.Density > 0 && ingredient.Category != "meat" && ingredient.Category != "seafood":
+			return householdVolume(grams/ingredient.Density, ingredient.Density, unit)
+		}
+	}
+	return metric(grams, models.UnitGram, models.UnitKilogram)
+}
+
+// millilitres converts a volume measure to millilitres
+func millilitres(amount float64, unit string) (float64, bool) {
+	perUnit := map[string]float64{
+		models.UnitMillilitre: 1,
+		models.UnitLitre:      1000,
+		models.UnitTeaspoon:   teaspoonMl,
+		models.UnitTablespoon: tablespoonMl,
+		models.UnitCup:        cupMl,
+		models.UnitKatori:     katoriMl,
+		models.UnitGlass:      glassMl,
+	}
+	ml, ok := perUnit[unit]
+	return amount * ml, ok
+}
+
+// householdVolume picks the household measure that reads most naturally for a volume:
+// pinches and spoons for small amounts, then cups, glasses or katori
+func householdVolume(ml, density float64, sourceUnit string) models.ScaledIngredient {
+	switch {
+	case ml < pinchBelowMl:
+		return measured(math.Max(1, math.Round(ml*density/pinchGrams)), models.UnitPinch)
+	case ml < tablespoonFromMl:
+		return measured(math.Max(0.25, roundToStep(ml/teaspoonMl, 0.25)), models.UnitTeaspoon)
+	case ml <= largeMeasureFrom:
+		return measured(roundToStep(ml/tablespoonMl, 0.5), models.UnitTablespoon)
+	case sourceUnit == models.UnitCup:
+		return measured(roundToStep(ml/cupMl, 0.25), models.UnitCup)
+	case ml >= glassMl && (sourceUnit == models.UnitGlass || sourceUnit == models.UnitMillilitre || sourceUnit == models.UnitLitre):
+		return measured(roundToStep(ml/glassMl, 0.25), models.UnitGlass)
+	}
+	return measured(math.Max(0.25, roundToStep(ml/katoriMl, 0.25)), models.UnitKatori)
+}
+
+// metric rounds a weight or volume to a precision that suits its size, switching to
+// kilograms or litres from 1000
+func metric(value float64, unit, largeUnit string) models.ScaledIngredient {
+	switch {
+	case value < 10:
+		value = math.Max(0.5, roundToStep(value, 0.5))
+	case value < 100:
+		value = math.Round(value)
+	case value < 1000:
+		value = roundToStep(value, 5)
+	default:
+		value = roundToStep(value, 10)
+	}
+
+	if value >= 1000 {
+		value = roundTo(value/1000, 2)
+		return models.ScaledIngredient{Quantity: value, Unit: largeUnit, Display: formatDecimal(value) + " " + largeUnit}
+	}
+	return models.ScaledIngredient{Quantity: value, Unit: unit, Display: formatDecimal(value) + " " + unit}
+}
+
+// measured builds a household quantity with a fractional display, such as "1½ tsp"
+func measured(quantity float64, unit string) models.ScaledIngredient {
+	label := unit
+	if quantity > 1 {
+		switch unit {
+		case models.UnitPiece, models.UnitCup:
+			label += "s"
+		case models.UnitPinch, models.UnitGlass:
+			label += "es"
+		}
+	}
+	return models.ScaledIngredient{Quantity: quantity, Unit: unit, Display: formatFraction(quantity) + " " + label}
+}
+
+// roundPieces rounds a count to halves for small counts and to whole pieces otherwise
+func roundPieces(count float64) float64 {
+	if count < 3 {
+		return math.Max(0.5, roundToStep(count, 0.5))
+	}
+	return math.Round(count)
+}
+
+// roundToStep rounds v to the nearest multiple of step
+func roundToStep(v, step float64) float64 {
+	return math.Round(v/step) * step
+}
+
+// formatFraction writes quarters as fraction characters, e.g. 1.5 as "1½"
+func formatFraction(v float64) string {
+	whole := math.Floor(v)
+	fractions := map[float64]string{0.25: "¼", 0.5: "½", 0.75: "¾"}
+	fraction, ok := fractions[roundTo(v-whole, 2)]
+	switch {
+	case !ok:
+		return formatDecimal(v)
+	case whole == 0:
+		return fraction
+	}
+	return strconv.Itoa(int(whole)) + fraction
+}
+
+// formatDecimal writes a number without trailing zeros
+func formatDecimal(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(strconv.FormatFloat(v, 'f', 2, 64), "0"), ".")
+}
+
+// multiplyNutrition multiplies per-serving nutrition by a number of servings
+func multiplyNutrition(n models.Nutrition, servings int) models.Nutrition {
+	total := models.Nutrition{
+		Protein: n.Protein * servings,
+		Carbs:   n.Carbs * servings,
+		Fat:     n.Fat * servings,
+		Fiber:   n.Fiber * servings,
+		Sugar:   n.Sugar * servings,
+		Sodium:  n.Sodium * servings,
+	}
+	if len(n.Micronutrients) > 0 {
+		total.Micronutrients = make(map[string]float64, len(n.Micronutrients))
+		for key, value := range n.Micronutrients {
+			total.Micronutrients[key] = roundTo(value*float64(servings), 1)
+		}
+	}
+	return total
+}
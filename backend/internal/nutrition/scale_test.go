@@ -0,0 +1,126 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestScaleDish_Household(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		ID:                   primitive.NewObjectID(),
+		Name:                 "Dal Tadka",
+		Servings:             4,
+		IngredientQuantities: dalTadka(),
+		NutritionSource:      models.NutritionSourceCalculated,
+	}
+
+	// Act
+	scaled, err := ScaleDish(DefaultCatalog(), dish, 8, models.UnitsHousehold)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, scaled.Factor)
+	displays := []string{}
+	for _, ingredient := range scaled.Ingredients {
+		displays = append(displays, ingredient.Display)
+	}
+	assert.Equal(t, []string{"3¼ katori", "2 pieces", "4 pieces", "4 tbsp", "1¾ tsp"}, displays)
+	assert.Equal(t, 400.0, scaled.Ingredients[0].Grams)
+
+	salt := scaled.Ingredients[4]
+	assert.True(t, salt.Adjusted, "salt scales less than proportionally")
+	assert.Equal(t, "salt", salt.IngredientKey)
+	assert.Equal(t, 1.0, salt.Original.Quantity)
+
+	assert.Less(t, scaled.Nutrition.Sodium, 593, "less salt per serving in a larger batch")
+	assert.Equal(t, scaled.Calories*8, scaled.TotalCalories)
+	assert.Equal(t, scaled.Nutrition.Protein*8, scaled.TotalNutrition.Protein)
+	assert.Len(t, scaled.Notes, 2)
+}
+
+func TestScaleDish_Metric(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		ID:       primitive.NewObjectID(),
+		Name:     "Jeera Rice",
+		Servings: 2,
+		Calories: 210,
+		IngredientQuantities: []models.IngredientQuantity{
+			{Name: "basmati rice", Quantity: 1, Unit: models.UnitCup},
+			{Name: "water", Quantity: 2, Unit: models.UnitGlass},
+			{Name: "potato", Quantity: 1, Unit: models.UnitPiece},
+			{Name: "ghee", Quantity: 1, Unit: models.UnitTablespoon},
+		},
+	}
+
+	// Act
+	scaled, err := ScaleDish(DefaultCatalog(), dish, 5, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.UnitsMetric, scaled.Units)
+	assert.Equal(t, "600 ml", scaled.Ingredients[0].Display)
+	assert.Equal(t, "1.25 l", scaled.Ingredients[1].Display)
+	assert.Equal(t, "2½ pieces", scaled.Ingredients[2].Display)
+	assert.Equal(t, "38 ml", scaled.Ingredients[3].Display)
+	assert.Equal(t, 210, scaled.Calories, "entered nutrition is kept per serving")
+	assert.Equal(t, 1050, scaled.TotalCalories)
+}
+
+func TestScaleDish_Invalid(t *testing.T) {
+	dish := &models.Dish{Name: "Dal Tadka", Servings: 4}
+
+	tests := []struct {
+		name     string
+		servings int
+		units    string
+		expected string
+	}{
+		{"no servings", 0, models.UnitsMetric, "servings must be between 1 and 100"},
+		{"too many servings", 101, models.UnitsMetric, "servings must be between 1 and 100"},
+		{"unknown units", 4, "imperial", "units must be metric or household"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			scaled, err := ScaleDish(DefaultCatalog(), dish, tt.servings, tt.units)
+
+			// Assert
+			assert.Nil(t, scaled)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestHouseholdVolume(t *testing.T) {
+	tests := []struct {
+		name     string
+		ml       float64
+		unit     string
+		expected string
+	}{
+		{"pinch", 0.3, models.UnitTeaspoon, "1 pinch"},
+		{"teaspoons", 7.5, models.UnitTeaspoon, "1½ tsp"},
+		{"tablespoons", 45, models.UnitTablespoon, "3 tbsp"},
+		{"cups stay cups", 360, models.UnitCup, "1½ cups"},
+		{"liquids in glasses", 500, models.UnitMillilitre, "2 glasses"},
+		{"katori otherwise", 300, models.UnitGram, "2 katori"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			scaled := householdVolume(tt.ml, 1, tt.unit)
+
+			// Assert
+			assert.Equal(t, tt.expected, scaled.Display)
+		})
+	}
+}
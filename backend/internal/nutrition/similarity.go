@@ -0,0 +1,187 @@
+package nutrition
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"nourish-backend/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Weights of each part of the dish similarity score, summing to 1
+const (
+	ingredientSimilarityWeight = 0.4
+	cuisineSimilarityWeight    = 0.15
+	typeSimilarityWeight       = 0.1
+	nutritionSimilarityWeight  = 0.2
+	tagSimilarityWeight        = 0.15
+)
+
+// DishProfile is what dish similarity compares, worked out once per dish
+type DishProfile struct {
+	ID          primitive.ObjectID
+	Type        string
+	Cuisine     string
+	Ingredients map[string]string // canonical key to display name
+	DietaryTags []string
+
+	// Per serving calories and the shares of energy from each macro
+	Calories     float64
+	ProteinShare float64
+	CarbShare    float64
+	FatShare     float64
+}
+
+// NewDishProfile profiles a dish for similarity. Ingredients are reduced to catalog keys
+// so "palak" and "spinach" match; names missing from the catalog are compared as written.
+func NewDishProfile(catalog *Catalog, dish *models.Dish) DishProfile {
+	profile := DishProfile{
+		ID:          dish.ID,
+		Type:        dish.Type,
+		Cuisine:     strings.ToLower(strings.TrimSpace(dish.Cuisine)),
+		Ingredients: map[string]string{},
+		DietaryTags: dish.DietaryTags,
+		Calories:    float64(dish.Calories),
+	}
+	for _, name := range dishIngredientNames(dish) {
+		if ingredient, ok := catalog.Lookup(name); ok {
+			profile.Ingredients[ingredient.Key] = ingredient.Name
+		} else if key := normalizeIngredient(name); key != "" {
+			profile.Ingredients[key] = name
+		}
+	}
+
+	protein := float64(dish.Nutrition.Protein) * 4
+	carbs := float64(dish.Nutrition.Carbs) * 4
+	fat := float64(dish.Nutrition.Fat) * 9
+	if energy := protein + carbs + fat; energy > 0 {
+		profile.ProteinShare = protein / energy
+		profile.CarbShare = carbs / energy
+		profile.FatShare = fat / energy
+	}
+	return profile
+}
+
+// DishSimilarity scores how well a candidate stands in for a dish. It is not symmetric:
+// the candidate is judged on keeping the dish's dietary tags, not the other way round.
+func DishSimilarity(dish, candidate DishProfile) models.DishSimilarity {
+	similarity := models.DishSimilarity{
+		Ingredients: roundTo(ingredientJaccard(dish.Ingredients, candidate.Ingredients), 3),
+		Nutrition:   roundTo(nutritionCloseness(dish, candidate), 3),
+		DietaryTags: roundTo(tagCompatibility(dish.DietaryTags, candidate.DietaryTags), 3),
+	}
+	if dish.Cuisine != "" && dish.Cuisine == candidate.Cuisine {
+		similarity.Cuisine = 1
+	}
+	if dish.Type == candidate.Type {
+		similarity.Type = 1
+	}
+	similarity.Score = roundTo(ingredientSimilarityWeight*similarity.Ingredients+
+		cuisineSimilarityWeight*similarity.Cuisine+
+		typeSimilarityWeight*similarity.Type+
+		nutritionSimilarityWeight*similarity.Nutrition+
+		tagSimilarityWeight*similarity.DietaryTags, 3)
+	return similarity
+}
+
+// DishNeighbor is a candidate ranked against a dish
+type DishNeighbor struct {
+	ID                primitive.ObjectID
+	Similarity        models.DishSimilarity
+	SharedIngredients []string // display names, sorted
+}
+
+// SimilarDishes ranks the candidates against a dish, best first, keeping at most limit.
+// The dish itself is skipped; ties fall back to ID order so rankings are stable.
+func SimilarDishes(dish DishProfile, candidates []DishProfile, limit int) []DishNeighbor {
+	type ranked struct {
+		candidate  int
+		similarity models.DishSimilarity
+	}
+	ranking := make([]ranked, 0, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.ID == dish.ID {
+			continue
+		}
+		if similarity := DishSimilarity(dish, candidate); similarity.Score > 0 {
+			ranking = append(ranking, ranked{candidate: i, similarity: similarity})
+		}
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].similarity.Score != ranking[j].similarity.Score {
+			return ranking[i].similarity.Score > ranking[j].similarity.Score
+		}
+		return candidates[ranking[i].candidate].ID.Hex() < candidates[ranking[j].candidate].ID.Hex()
+	})
+	if len(ranking) > limit {
+		ranking = ranking[:limit]
+	}
+
+	neighbors := make([]DishNeighbor, len(ranking))
+	for i, r := range ranking {
+		candidate := candidates[r.candidate]
+		neighbors[i] = DishNeighbor{
+			ID:                candidate.ID,
+			Similarity:        r.similarity,
+			SharedIngredients: sharedIngredients(dish.Ingredients, candidate.Ingredients),
+		}
+	}
+	return neighbors
+}
+
+// ingredientJaccard is the size of the intersection of two ingredient sets over their union
+func ingredientJaccard(a, b map[string]string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for key := range a {
+		if _, ok := b[key]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// sharedIngredients returns the display names of the ingredients in both sets
+func sharedIngredients(a, b map[string]string) []string {
+	shared := []string{}
+	for key, name := range a {
+		if _, ok := b[key]; ok {
+			shared = append(shared, name)
+		}
+	}
+	sort.Strings(shared)
+	return shared
+}
+
+// nutritionCloseness is 1 less the average of the relative calorie difference and the
+// distance between the macro shares, both from 0 to 1
+func nutritionCloseness(a, b DishProfile) float64 {
+	var calories float64
+	if high := math.Max(a.Calories, b.Calories); high > 0 {
+		calories = math.Abs(a.Calories-b.Calories) / high
+	}
+	macros := (math.Abs(a.ProteinShare-b.ProteinShare) +
+		math.Abs(a.CarbShare-b.CarbShare) +
+		math.Abs(a.FatShare-b.FatShare)) / 2
+	return 1 - (calories+macros)/2
+}
+
+// tagCompatibility is the share of a dish's dietary tags the candidate also has, 1 for a
+// dish without tags
+func tagCompatibility(tags, candidateTags []string) float64 {
+	if len(tags) == 0 {
+		return 1
+	}
+	kept := 0
+	for _, tag := range tags {
+		if containsString(candidateTags, tag) {
+			kept++
+		}
+	}
+	return float64(kept) / float64(len(tags))
+}
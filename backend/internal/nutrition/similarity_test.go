@@ -0,0 +1,107 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNewDishProfile(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		Cuisine:     " North Indian ",
+		Ingredients: []string{"Palak", "paneer", "kasuri methi"},
+		Nutrition:   models.Nutrition{Protein: 10, Carbs: 10, Fat: 20},
+	}
+
+	// Act
+	profile := NewDishProfile(DefaultCatalog(), dish)
+
+	// Assert
+	assert.Equal(t, "north indian", profile.Cuisine)
+	assert.Equal(t, map[string]string{"spinach": "Spinach", "paneer": "Paneer", "kasuri methi": "kasuri methi"}, profile.Ingredients)
+	assert.InDelta(t, 0.154, profile.CarbShare, 0.001) // 40 of 260 kcal
+	assert.InDelta(t, 0.692, profile.FatShare, 0.001)
+}
+
+func TestDishSimilarity(t *testing.T) {
+	catalog := DefaultCatalog()
+	dish := NewDishProfile(catalog, palakPaneer())
+
+	tests := []struct {
+		name     string
+		other    *models.Dish
+		expected models.DishSimilarity
+	}{
+		{
+			name:     "same dish without a cuisine",
+			other:    palakPaneer(),
+			expected: models.DishSimilarity{Score: 0.85, Ingredients: 1, Cuisine: 0, Type: 1, Nutrition: 1, DietaryTags: 1},
+		},
+		{
+			name: "shares two ingredients and drops a tag",
+			other: &models.Dish{
+				Type:        "Veg",
+				Ingredients: []string{"spinach", "potato", "salt"},
+				DietaryTags: []string{"vegetarian", "gluten-free"},
+				Calories:    145,
+				Nutrition:   models.Nutrition{Protein: 11, Carbs: 6, Fat: 25},
+			},
+			// 2 of 6 ingredients, half the calories, two of three tags
+			expected: models.DishSimilarity{Score: 0.483, Ingredients: 0.333, Type: 1, Nutrition: 0.75, DietaryTags: 0.667},
+		},
+		{
+			name: "different type",
+			other: &models.Dish{
+				Type:      "Non-Veg",
+				Calories:  290,
+				Nutrition: models.Nutrition{Protein: 11, Carbs: 6, Fat: 25},
+			},
+			expected: models.DishSimilarity{Score: 0.2, Nutrition: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			similarity := DishSimilarity(dish, NewDishProfile(catalog, tt.other))
+
+			// Assert
+			assert.Equal(t, tt.expected, similarity)
+		})
+	}
+}
+
+func TestSimilarDishes(t *testing.T) {
+	// Arrange
+	catalog := DefaultCatalog()
+	source := palakPaneer()
+	source.Cuisine = "North Indian"
+	tofu := &models.Dish{ID: primitive.NewObjectID(), Type: "Veg", Cuisine: "North Indian", Ingredients: []string{"palak", "tofu", "cream", "salt"},
+		DietaryTags: []string{"vegetarian", "gluten-free", "nut-free"}, Calories: 250, Nutrition: models.Nutrition{Protein: 10, Carbs: 6, Fat: 20}}
+	aloo := &models.Dish{ID: primitive.NewObjectID(), Type: "Veg", Cuisine: "North Indian", Ingredients: []string{"potato", "onion", "salt"},
+		DietaryTags: []string{"vegan"}, Calories: 180, Nutrition: models.Nutrition{Protein: 3, Carbs: 30, Fat: 6}}
+	chicken := &models.Dish{ID: primitive.NewObjectID(), Type: "Non-Veg", Cuisine: "Mughlai", Ingredients: []string{"chicken", "cream", "tomato"},
+		Calories: 420, Nutrition: models.Nutrition{Protein: 30, Carbs: 10, Fat: 28}}
+
+	candidates := []DishProfile{
+		NewDishProfile(catalog, source),
+		NewDishProfile(catalog, chicken),
+		NewDishProfile(catalog, aloo),
+		NewDishProfile(catalog, tofu),
+	}
+
+	// Act
+	neighbors := SimilarDishes(candidates[0], candidates, 2)
+
+	// Assert
+	require.Len(t, neighbors, 2)
+	assert.Equal(t, tofu.ID, neighbors[0].ID)
+	assert.Equal(t, []string{"Cream", "Salt", "Spinach"}, neighbors[0].SharedIngredients)
+	assert.Equal(t, aloo.ID, neighbors[1].ID)
+	assert.Greater(t, neighbors[0].Similarity.Score, neighbors[1].Similarity.Score)
+}
@@ -0,0 +1,400 @@
+package nutrition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"nourish-backend/internal/models"
+)
+
+// Dietary goals a dish can be transformed for
+const (
+	GoalVegetarian = "vegetarian"
+	GoalVegan      = "vegan"
+	GoalDairyFree  = "dairy-free"
+	GoalGlutenFree = "gluten-free"
+	GoalWholeGrain = "whole-grain"
+	GoalUnrefined  = "unrefined" // unrefined sweeteners instead of white sugar
+)
+
+// ingredientRule matches catalog ingredients by category or key
+type ingredientRule struct {
+	categories []string
+	keys       []string
+}
+
+// goalRules are the exclusions for each substitution goal
+var goalRules = map[string]ingredientRule{
+	GoalVegetarian: {categories: []string{"meat", "seafood", "egg"}},
+	GoalVegan:      {categories: []string{"meat", "seafood", "egg", "dairy"}, keys: []string{"ghee", "honey"}},
+	GoalDairyFree:  {categories: []string{"dairy"}, keys: []string{"ghee"}},
+	GoalGlutenFree: {keys: []string{"wheat_flour", "maida", "semolina"}},
+	GoalWholeGrain: {keys: []string{"maida"}},
+	GoalUnrefined:  {keys: []string{"sugar"}},
+}
+
+// excludes reports whether the rule matches an ingredient
+func (r ingredientRule) excludes(ingredient Ingredient) bool {
+	for _, category := range r.categories {
+		if ingredient.Category == category {
+			return true
+		}
+	}
+	for _, key := range r.keys {
+		if ingredient.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// substitution is an entry in the substitution knowledge base
+type substitution struct {
+	from, to string
+	ratio    float64
+	goals    []string
+	adds     []string
+	removes  []string
+	note     string
+}
+
+// substitutions is the knowledge base, keyed by canonical ingredient. When an ingredient has
+// several entries for a goal, the first is preferred.
+var substitutions = []substitution{
+	{from: "paneer", to: "tofu", ratio: 1, goals: []string{GoalVegan, GoalDairyFree}, adds: []string{GoalVegan, GoalDairyFree}, note: "Use firm tofu and press out the water before cubing"},
+	{from: "cream", to: "cashew", ratio: 0.4, goals: []string{GoalVegan, GoalDairyFree}, adds: []string{GoalVegan, GoalDairyFree}, removes: []string{"nut-free"}, note: "Soak the cashews and blend them with water into a smooth paste"},
+	{from: "milk", to: "coconut_milk", ratio: 1, goals: []string{GoalVegan, GoalDairyFree}, adds: []string{GoalVegan, GoalDairyFree}},
+	{from: "ghee", to: "vegetable_oil", ratio: 1, goals: []string{GoalVegan, GoalDairyFree}, adds: []string{GoalVegan, GoalDairyFree}},
+	{from: "butter", to: "vegetable_oil", ratio: 0.8, goals: []string{GoalVegan, GoalDairyFree}, adds: []string{GoalVegan, GoalDairyFree}},
+	{from: "honey", to: "jaggery", ratio: 1.2, goals: []string{GoalVegan}, adds: []string{GoalVegan}, note: "Dissolve the jaggery in a little warm water"},
+	{from: "chicken", to: "paneer", ratio: 1, goals: []string{GoalVegetarian}, adds: []string{GoalVegetarian}, removes: []string{GoalDairyFree}, note: "Add the paneer near the end so it stays soft"},
+	{from: "chicken", to: "soybean", ratio: 0.5, goals: []string{GoalVegan}, adds: []string{GoalVegetarian, GoalVegan}, note: "Use soya chunks soaked in hot water and squeezed dry"},
+	{from: "mutton", to: "soybean", ratio: 0.5, goals: []string{GoalVegetarian, GoalVegan}, adds: []string{GoalVegetarian, GoalVegan}, note: "Use soya chunks soaked in hot water and squeezed dry"},
+	{from: "egg", to: "besan", ratio: 0.5, goals: []string{GoalVegetarian, GoalVegan}, adds: []string{GoalVegetarian, GoalVegan}, note: "Whisk the besan with water into a thick batter"},
+	{from: "maida", to: "wheat_flour", ratio: 1, goals: []string{GoalWholeGrain}, note: "Add a little more water; atta absorbs more than maida"},
+	{from: "maida", to: "besan", ratio: 1, goals: []string{GoalGlutenFree}, adds: []string{GoalGlutenFree}},
+	{from: "wheat_flour", to: "besan", ratio: 1, goals: []string{GoalGlutenFree}, adds: []string{GoalGlutenFree}},
+	{from: "semolina", to: "poha", ratio: 1, goals: []string{GoalGlutenFree}, adds: []string{GoalGlutenFree}},
+	{from: "sugar", to: "jaggery", ratio: 1, goals: []string{GoalUnrefined}, note: "Jaggery darkens the dish and adds a caramel note"},
+}
+
+// SubstitutionGoals returns the dietary goals dishes can be transformed for
+func SubstitutionGoals() []string {
+	return []string{GoalVegetarian, GoalVegan, GoalDairyFree, GoalGlutenFree, GoalWholeGrain, GoalUnrefined}
+}
+
+// Substitutions returns every entry of the knowledge base with its nutrition delta
+func Substitutions(catalog *Catalog) []models.IngredientSubstitution {
+	entries := make([]models.IngredientSubstitution, 0, len(substitutions))
+	for _, entry := range substitutions {
+		if resolved, ok := resolveSubstitution(catalog, entry); ok {
+			entries = append(entries, resolved)
+		}
+	}
+	return entries
+}
+
+// findSubstitution returns the preferred swap for an ingredient that conflicts with a goal.
+// The substitute must not conflict with the goal itself.
+func findSubstitution(catalog *Catalog, ingredient Ingredient, goal string) (models.IngredientSubstitution, Ingredient, bool) {
+	for _, entry := range substitutions {
+		if entry.from != ingredient.Key || !containsString(entry.goals, goal) {
+			continue
+		}
+		to, ok := catalog.Lookup(entry.to)
+		if !ok || goalRules[goal].excludes(to) {
+			continue
+		}
+		if resolved, ok := resolveSubstitution(catalog, entry); ok {
+			return resolved, to, true
+		}
+	}
+	return models.IngredientSubstitution{}, Ingredient{}, false
+}
+
+// resolveSubstitution fills in ingredient names and the nutrition delta from the catalog
+func resolveSubstitution(catalog *Catalog, entry substitution) (models.IngredientSubstitution, bool) {
+	from, ok := catalog.Lookup(entry.from)
+	if !ok {
+		return models.IngredientSubstitution{}, false
+	}
+	to, ok := catalog.Lookup(entry.to)
+	if !ok {
+		return models.IngredientSubstitution{}, false
+	}
+
+	delta := func(original, replacement float64) int {
+		return round(replacement*entry.ratio - original)
+	}
+	return models.IngredientSubstitution{
+		From:          from.Key,
+		FromName:      from.Name,
+		To:            to.Key,
+		ToName:        to.Name,
+		Ratio:         entry.ratio,
+		Goals:         entry.goals,
+		AddsTags:      nonNil(entry.adds),
+		RemovesTags:   nonNil(entry.removes),
+		Note:          entry.note,
+		CaloriesDelta: delta(from.Calories, to.Calories),
+		NutritionDelta: models.Nutrition{
+			Protein: delta(from.Protein, to.Protein),
+			Carbs:   delta(from.Carbs, to.Carbs),
+			Fat:     delta(from.Fat, to.Fat),
+			Fiber:   delta(from.Fiber, to.Fiber),
+			Sugar:   delta(from.Sugar, to.Sugar),
+			Sodium:  delta(from.Sodium, to.Sodium),
+		},
+	}, true
+}
+
+// ParseSubstitutionGoals splits a comma-separated list of goals and checks each is known
+func ParseSubstitutionGoals(value string) ([]string, error) {
+	var goals []string
+	for _, goal := range strings.Split(value, ",") {
+		goal = strings.ToLower(strings.TrimSpace(goal))
+		if goal == "" || containsString(goals, goal) {
+			continue
+		}
+		if _, ok := goalRules[goal]; !ok {
+			return nil, fmt.Errorf("unknown dietary goal %q", goal)
+		}
+		goals = append(goals, goal)
+	}
+	if len(goals) == 0 {
+		return nil, errors.New("choose a dietary goal to substitute for")
+	}
+	return goals, nil
+}
+
+// SubstituteDish swaps the ingredients of a dish that conflict with the goals, then recomputes
+// its dietary tags and, when it has ingredient quantities, its nutrition.
+func SubstituteDish(catalog *Catalog, dish *models.Dish, goals []string) *models.SubstitutedDish {
+	result := &models.SubstitutedDish{
+		DishID:      dish.ID.Hex(),
+		Name:        dish.Name,
+		Goals:       goals,
+		Ingredients: []models.SubstitutedIngredient{},
+		Unresolved:  []string{},
+		Type:        dish.Type,
+		Calories:    dish.Calories,
+		Nutrition:   dish.Nutrition,
+	}
+
+	names := dish.Ingredients
+	if len(dish.IngredientQuantities) > 0 {
+		names = make([]string, 0, len(dish.IngredientQuantities))
+		for _, quantity := range dish.IngredientQuantities {
+			names = append(names, quantity.Name)
+		}
+	}
+
+	var replaced []models.IngredientQuantity
+	var final []Ingredient
+	addsTags, removesTags := []string{}, []string{}
+	for i, name := range names {
+		item := models.SubstitutedIngredient{Name: name}
+		var quantity *models.IngredientQuantity
+		if len(dish.IngredientQuantities) > 0 {
+			q := dish.IngredientQuantities[i]
+			quantity = &q
+		}
+
+		// Only the last swap's removed tags apply: a later swap replaces the earlier substitute
+		ingredient, known := catalog.Lookup(name)
+		var removes []string
+		for _, goal := range goals {
+			if !known || !goalRules[goal].excludes(ingredient) {
+				continue
+			}
+			swap, to, ok := findSubstitution(catalog, ingredient, goal)
+			if !ok {
+				result.Unresolved = append(result.Unresolved, name)
+				break
+			}
+			item = models.SubstitutedIngredient{Name: to.Name, Original: name, Substitution: &swap}
+			if quantity != nil {
+				quantity = convertSubstitute(ingredient, to, *quantity, swap.Ratio)
+			}
+			addsTags = appendUnique(addsTags, swap.AddsTags...)
+			removes = swap.RemovesTags
+			ingredient = to
+		}
+		removesTags = appendUnique(removesTags, removes...)
+
+		if quantity != nil {
+			item.Quantity = quantity
+			replaced = append(replaced, *quantity)
+		}
+		if known {
+			final = append(final, ingredient)
+		}
+		result.Ingredients = append(result.Ingredients, item)
+	}
+
+	result.DietaryTags = substitutedTags(dish.DietaryTags, final, addsTags, removesTags)
+	if !anyExcluded(final, GoalVegetarian) && containsString(result.DietaryTags, GoalVegetarian) {
+		result.Type = "Veg"
+	}
+
+	swapped := len(addsTags) > 0 || len(removesTags) > 0 || hasSubstitution(result.Ingredients)
+	switch {
+	case !swapped:
+		result.Notes = append(result.Notes, "No ingredients needed substituting")
+	case len(dish.IngredientQuantities) == 0:
+		result.Notes = append(result.Notes, "This dish has no ingredient quantities, so its nutrition was not recalculated")
+	default:
+		original := append([]models.IngredientQuantity(nil), dish.IngredientQuantities...)
+		before := CalculateDish(catalog, original, dish.Servings)
+		after := CalculateDish(catalog, replaced, dish.Servings)
+		result.Calories = shift(dish.Calories, before.Calories, after.Calories)
+		result.Nutrition = models.Nutrition{
+			Protein:        shift(dish.Nutrition.Protein, before.Nutrition.Protein, after.Nutrition.Protein),
+			Carbs:          shift(dish.Nutrition.Carbs, before.Nutrition.Carbs, after.Nutrition.Carbs),
+			Fat:            shift(dish.Nutrition.Fat, before.Nutrition.Fat, after.Nutrition.Fat),
+			Fiber:          shift(dish.Nutrition.Fiber, before.Nutrition.Fiber, after.Nutrition.Fiber),
+			Sugar:          shift(dish.Nutrition.Sugar, before.Nutrition.Sugar, after.Nutrition.Sugar),
+			Sodium:         shift(dish.Nutrition.Sodium, before.Nutrition.Sodium, after.Nutrition.Sodium),
+			Micronutrients: after.Nutrition.Micronutrients,
+		}
+	}
+	if len(result.Unresolved) > 0 {
+		result.Notes = append(result.Notes, "Some ingredients have no known substitute for this goal")
+	}
+	return result
+}
+
+// SuggestSwaps lists, for each of the user's dietary preferences the dish conflicts with,
+// the swaps that would make it fit
+func SuggestSwaps(catalog *Catalog, dish *models.Dish, preferences []string) []models.DishSwapSuggestion {
+	names := dish.Ingredients
+	if len(names) == 0 {
+		for _, quantity := range dish.IngredientQuantities {
+			names = append(names, quantity.Name)
+		}
+	}
+
+	var suggestions []models.DishSwapSuggestion
+	for _, preference := range preferences {
+		rule, ok := goalRules[preference]
+		if !ok {
+			continue
+		}
+		suggestion := models.DishSwapSuggestion{Preference: preference, Conflicts: []string{}, Swaps: []models.IngredientSubstitution{}, Resolvable: true}
+		for _, name := range names {
+			ingredient, known := catalog.Lookup(name)
+			if !known || !rule.excludes(ingredient) {
+				continue
+			}
+			suggestion.Conflicts = append(suggestion.Conflicts, name)
+			if swap, _, ok := findSubstitution(catalog, ingredient, preference); ok {
+				suggestion.Swaps = append(suggestion.Swaps, swap)
+			} else {
+				suggestion.Resolvable = false
+			}
+		}
+		if len(suggestion.Conflicts) > 0 {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions
+}
+
+// convertSubstitute converts a quantity of an ingredient to the equivalent quantity of its
+// substitute, keeping the unit when the substitute can be measured in it
+func convertSubstitute(from, to Ingredient, quantity models.IngredientQuantity, ratio float64) *models.IngredientQuantity {
+	converted := models.IngredientQuantity{Name: to.Name, IngredientKey: to.Key, Unit: quantity.Unit}
+	grams, err := from.Grams(quantity.Quantity, quantity.Unit)
+	if err != nil {
+		converted.Quantity = quantity.Quantity * ratio
+		return &converted
+	}
+	grams *= ratio
+
+	if perUnit, err := to.Grams(1, quantity.Unit); err == nil && perUnit > 0 {
+		converted.Quantity = roundTo(grams/perUnit, 2)
+		return &converted
+	}
+	converted.Quantity = roundTo(grams, 1)
+	converted.Unit = models.UnitGram
+	return &converted
+}
+
+// substitutedTags recomputes dietary tags after substitution. Removed tags are dropped; added
+// tags are kept only when no remaining ingredient conflicts with them.
+func substitutedTags(tags []string, ingredients []Ingredient, adds, removes []string) []string {
+	result := []string{}
+	for _, tag := range tags {
+		if !containsString(removes, tag) {
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range adds {
+		if containsString(removes, tag) || anyExcluded(ingredients, tag) {
+			continue
+		}
+		result = appendUnique(result, tag)
+		if tag == GoalVegan && !anyExcluded(ingredients, GoalVegetarian) {
+			result = appendUnique(result, GoalVegetarian)
+		}
+	}
+	return result
+}
+
+// anyExcluded reports whether any ingredient conflicts with a goal
+func anyExcluded(ingredients []Ingredient, goal string) bool {
+	rule, ok := goalRules[goal]
+	if !ok {
+		return false
+	}
+	for _, ingredient := range ingredients {
+		if rule.excludes(ingredient) {
+			return true
+		}
+	}
+	return false
+}
+
+// shift applies the calculated change in a nutrient to the dish's stored value
+func shift(stored, before, after int) int {
+	if value := stored + after - before; value > 0 {
+		return value
+	}
+	return 0
+}
+
+// hasSubstitution reports whether any ingredient was swapped
+func hasSubstitution(ingredients []models.SubstitutedIngredient) bool {
+	for _, ingredient := range ingredients {
+		if ingredient.Substitution != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, additions ...string) []string {
+	for _, value := range additions {
+		if !containsString(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
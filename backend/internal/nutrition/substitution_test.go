@@ -0,0 +1,148 @@
+package nutrition
+
+import (
+	"testing"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// palakPaneer returns a dish with four servings of palak paneer
+func palakPaneer() *models.Dish {
+	return &models.Dish{
+		ID:          primitive.NewObjectID(),
+		Name:        "Palak Paneer",
+		Type:        "Veg",
+		Servings:    4,
+		DietaryTags: []string{"vegetarian", "gluten-free", "nut-free"},
+		IngredientQuantities: []models.IngredientQuantity{
+			{Name: "paneer", Quantity: 200, Unit: models.UnitGram},
+			{Name: "spinach", Quantity: 300, Unit: models.UnitGram},
+			{Name: "cream", Quantity: 2, Unit: models.UnitTablespoon},
+			{Name: "ghee", Quantity: 1, Unit: models.UnitTablespoon},
+			{Name: "salt", Quantity: 1, Unit: models.UnitTeaspoon},
+		},
+		Calories:  290,
+		Nutrition: models.Nutrition{Protein: 11, Carbs: 6, Fat: 25},
+	}
+}
+
+func TestSubstituteDish_Vegan(t *testing.T) {
+	// Arrange
+	dish := palakPaneer()
+
+	// Act
+	result := SubstituteDish(DefaultCatalog(), dish, []string{GoalVegan})
+
+	// Assert
+	require.Len(t, result.Ingredients, 5)
+	tofu := result.Ingredients[0]
+	assert.Equal(t, "Tofu", tofu.Name)
+	assert.Equal(t, "paneer", tofu.Original)
+	assert.Equal(t, 200.0, tofu.Quantity.Quantity)
+	assert.Equal(t, "tofu", tofu.Quantity.IngredientKey)
+
+	cashew := result.Ingredients[2]
+	assert.Equal(t, "Cashew", cashew.Name)
+	assert.Equal(t, 0.8, cashew.Quantity.Quantity, "30 g of cream becomes 12 g of cashews")
+	assert.Equal(t, "Vegetable oil", result.Ingredients[3].Name)
+	assert.Nil(t, result.Ingredients[1].Substitution)
+
+	assert.Empty(t, result.Unresolved)
+	assert.ElementsMatch(t, []string{"vegetarian", "gluten-free", "vegan", "dairy-free"}, result.DietaryTags)
+	assert.Less(t, result.Calories, dish.Calories)
+	assert.Less(t, result.Nutrition.Protein, dish.Nutrition.Protein)
+}
+
+func TestSubstituteDish_ChainsGoals(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{
+		Name:        "Chicken Curry",
+		Type:        "Non-Veg",
+		Ingredients: []string{"chicken", "onion", "tomato"},
+		DietaryTags: []string{"gluten-free", "dairy-free"},
+	}
+
+	// Act
+	result := SubstituteDish(DefaultCatalog(), dish, []string{GoalVegetarian, GoalDairyFree})
+
+	// Assert - chicken becomes paneer, which dairy-free swaps for tofu
+	assert.Equal(t, "Tofu", result.Ingredients[0].Name)
+	assert.Equal(t, "chicken", result.Ingredients[0].Original)
+	assert.Equal(t, "Veg", result.Type)
+	assert.ElementsMatch(t, []string{"gluten-free", "dairy-free", "vegetarian", "vegan"}, result.DietaryTags)
+	assert.Contains(t, result.Notes, "This dish has no ingredient quantities, so its nutrition was not recalculated")
+}
+
+func TestSubstituteDish_Unresolved(t *testing.T) {
+	// Arrange
+	dish := &models.Dish{Name: "Fish Fry", Type: "Non-Veg", Ingredients: []string{"fish", "besan"}}
+
+	// Act
+	result := SubstituteDish(DefaultCatalog(), dish, []string{GoalVegetarian})
+
+	// Assert
+	assert.Equal(t, []string{"fish"}, result.Unresolved)
+	assert.Equal(t, "Non-Veg", result.Type)
+	assert.NotContains(t, result.DietaryTags, "vegetarian")
+}
+
+func TestSuggestSwaps(t *testing.T) {
+	// Arrange
+	dish := palakPaneer()
+	dish.Ingredients = []string{"paneer", "spinach", "cream", "ghee", "salt"}
+
+	// Act
+	suggestions := SuggestSwaps(DefaultCatalog(), dish, []string{"vegetarian", "dairy-free", "keto"})
+
+	// Assert
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "dairy-free", suggestions[0].Preference)
+	assert.Equal(t, []string{"paneer", "cream", "ghee"}, suggestions[0].Conflicts)
+	assert.Len(t, suggestions[0].Swaps, 3)
+	assert.True(t, suggestions[0].Resolvable)
+}
+
+func TestParseSubstitutionGoals(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []string
+		expectedErr string
+	}{
+		{"single goal", "vegan", []string{"vegan"}, ""},
+		{"several goals", " Vegan, gluten-free,vegan ", []string{"vegan", "gluten-free"}, ""},
+		{"unknown goal", "paleo", nil, `unknown dietary goal "paleo"`},
+		{"empty", "", nil, "choose a dietary goal to substitute for"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			goals, err := ParseSubstitutionGoals(tt.value)
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, goals)
+		})
+	}
+}
+
+func TestSubstitutions(t *testing.T) {
+	// Act
+	entries := Substitutions(DefaultCatalog())
+
+	// Assert - every entry resolves against the catalog
+	assert.Len(t, entries, len(substitutions))
+	for _, entry := range entries {
+		assert.NotEmpty(t, entry.Goals, entry.From)
+	}
+	assert.Equal(t, -265+76, entries[0].CaloriesDelta, "paneer to tofu per 100 g")
+}
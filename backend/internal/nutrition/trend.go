@@ -0,0 +1,148 @@
+package nutrition
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"nourish-backend/internal/models"
+)
+
+// Weight trend and energy balance parameters
+const (
+	trendSmoothing     = 0.1  // daily EMA smoothing factor
+	kcalPerKg          = 7700 // approximate energy content of a kilogram of body weight
+	minTrendSpanDays   = 14   // weigh-ins must span at least two weeks
+	minIntakeDays      = 10   // minimum days with logged meals inside the span
+	maintainRateKgWeek = 0.1  // weekly change below this counts as maintaining
+)
+
+// WeightTrend smooths logged weights with an exponential moving average.
+// Gaps between weigh-ins are accounted for by compounding the daily smoothing
+// factor, so a weigh-in after a week away moves the trend further than one taken the next day.
+// Entries without a weight are skipped.
+func WeightTrend(measurements []*models.BodyMeasurement) []models.WeightTrendPoint {
+	weighed := make([]*models.BodyMeasurement, 0, len(measurements))
+	for _, m := range measurements {
+		if m != nil && m.WeightKg != nil {
+			weighed = append(weighed, m)
+		}
+	}
+	sort.SliceStable(weighed, func(i, j int) bool {
+		return weighed[i].Date.Before(weighed[j].Date)
+	})
+
+	points := make([]models.WeightTrendPoint, 0, len(weighed))
+	for i, m := range weighed {
+		weight := *m.WeightKg
+		trend := weight
+		if i > 0 {
+			prev := points[i-1]
+			days := math.Max(1, math.Round(m.Date.Sub(prev.Date).Hours()/24))
+			alpha := 1 - math.Pow(1-trendSmoothing, days)
+			trend = prev.TrendKg + alpha*(weight-prev.TrendKg)
+		}
+		points = append(points, models.WeightTrendPoint{
+			Date:     m.Date,
+			WeightKg: weight,
+			TrendKg:  roundTo(trend, 2),
+		})
+	}
+	return points
+}
+
+// EstimateEnergyBalance correlates a weight trend with logged intake over the same days to
+// estimate real-world TDEE, and checks whether the calorie goal is producing the intended change
+func EstimateEnergyBalance(trend []models.WeightTrendPoint, intake []models.DailyNutrition, weightGoal string, calorieGoal int) models.EnergyBalanceEstimate {
+	estimate := models.EnergyBalanceEstimate{
+		CalorieGoal:       calorieGoal,
+		IntendedDirection: intendedDirection(weightGoal),
+	}
+
+	if len(trend) < 2 {
+		estimate.Notes = append(estimate.Notes, "At least two weigh-ins are needed to estimate a trend")
+		return estimate
+	}
+
+	first, last := trend[0], trend[len(trend)-1]
+	span := models.NewDateRange(first.Date, last.Date)
+	spanDays := span.Days() - 1
+
+	totalCalories := 0
+	for _, day := range intake {
+		if day.MealCount == 0 || day.Date.Before(span.From) || day.Date.After(span.To) {
+			continue
+		}
+		estimate.DaysLogged++
+		totalCalories += day.Calories
+	}
+	if estimate.DaysLogged > 0 {
+		estimate.AvgCalories = roundTo(float64(totalCalories)/float64(estimate.DaysLogged), 1)
+	}
+
+	estimate.TrendChangeKg = roundTo(last.TrendKg-first.TrendKg, 2)
+	if spanDays > 0 {
+		estimate.WeeklyRateKg = roundTo(estimate.TrendChangeKg/float64(spanDays)*7, 2)
+	}
+	estimate.ActualDirection = directionForRate(estimate.WeeklyRateKg)
+
+	if spanDays < minTrendSpanDays {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("Weigh-ins span %d days; at least %d are needed to estimate TDEE", spanDays, minTrendSpanDays))
+		return estimate
+	}
+	if estimate.DaysLogged < minIntakeDays {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("Meals were logged on %d days; at least %d are needed to estimate TDEE", estimate.DaysLogged, minIntakeDays))
+		return estimate
+	}
+	if estimate.DaysLogged < span.Days() {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("Meals were logged on %d of %d days; the estimate assumes unlogged days were similar", estimate.DaysLogged, span.Days()))
+	}
+
+	// Intake minus the energy stored (or released) as body weight per day
+	tdee := round(estimate.AvgCalories - estimate.TrendChangeKg*kcalPerKg/float64(spanDays))
+	estimate.EstimatedTDEE = &tdee
+
+	onTrack := estimate.ActualDirection == estimate.IntendedDirection
+	estimate.OnTrack = &onTrack
+
+	suggested := tdee
+	switch weightGoal {
+	case models.WeightGoalLose:
+		suggested -= loseDeficit
+	case models.WeightGoalGain:
+		suggested += gainSurplus
+	}
+	estimate.SuggestedCalories = &suggested
+
+	return estimate
+}
+
+// intendedDirection maps a weight goal to the expected trend direction
+func intendedDirection(weightGoal string) string {
+	switch weightGoal {
+	case models.WeightGoalLose:
+		return models.TrendLosing
+	case models.WeightGoalGain:
+		return models.TrendGaining
+	default:
+		return models.TrendMaintaining
+	}
+}
+
+// directionForRate classifies a weekly rate of change
+func directionForRate(weeklyRateKg float64) string {
+	switch {
+	case weeklyRateKg <= -maintainRateKgWeek:
+		return models.TrendLosing
+	case weeklyRateKg >= maintainRateKgWeek:
+		return models.TrendGaining
+	default:
+		return models.TrendMaintaining
+	}
+}
+
+// roundTo rounds a value to the given number of decimal places
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
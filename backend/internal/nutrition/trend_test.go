@@ -0,0 +1,109 @@
+package nutrition
+
+import (
+	"testing"
+	"time"
+
+	"nourish-backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func weighIn(day int, kg float64) *models.BodyMeasurement {
+	return &models.BodyMeasurement{
+		Date:     time.Date(2024, 3, day, 7, 0, 0, 0, time.UTC),
+		WeightKg: &kg,
+	}
+}
+
+func TestWeightTrend(t *testing.T) {
+	// Arrange - out of order, with a waist-only entry
+	waist := 85.0
+	measurements := []*models.BodyMeasurement{
+		weighIn(3, 79),
+		weighIn(1, 80),
+		{Date: time.Date(2024, 3, 2, 7, 0, 0, 0, time.UTC), WaistCm: &waist},
+	}
+
+	// Act
+	trend := WeightTrend(measurements)
+
+	// Assert
+	require.Len(t, trend, 2)
+	assert.Equal(t, 80.0, trend[0].TrendKg)
+
+	// Two days later the smoothing factor compounds to 1 - 0.9^2 = 0.19
+	assert.Equal(t, 79.0, trend[1].WeightKg)
+	assert.InDelta(t, 79.81, trend[1].TrendKg, 0.001)
+}
+
+func TestEstimateEnergyBalance(t *testing.T) {
+	// Arrange - trend drops 1 kg over 28 days while eating 2000 kcal a day
+	trend := []models.WeightTrendPoint{
+		{Date: time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC), TrendKg: 80},
+		{Date: time.Date(2024, 3, 29, 7, 0, 0, 0, time.UTC), TrendKg: 79},
+	}
+	var intake []models.DailyNutrition
+	for d := 1; d <= 29; d++ {
+		intake = append(intake, models.DailyNutrition{
+			Date:      time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC),
+			Calories:  2000,
+			MealCount: 3,
+		})
+	}
+
+	// Act
+	estimate := EstimateEnergyBalance(trend, intake, models.WeightGoalLose, 1800)
+
+	// Assert
+	assert.Equal(t, 29, estimate.DaysLogged)
+	assert.Equal(t, 2000.0, estimate.AvgCalories)
+	assert.Equal(t, -1.0, estimate.TrendChangeKg)
+	assert.Equal(t, -0.25, estimate.WeeklyRateKg)
+	assert.Equal(t, models.TrendLosing, estimate.ActualDirection)
+	require.NotNil(t, estimate.EstimatedTDEE)
+	assert.Equal(t, 2275, *estimate.EstimatedTDEE)
+	require.NotNil(t, estimate.OnTrack)
+	assert.True(t, *estimate.OnTrack)
+	require.NotNil(t, estimate.SuggestedCalories)
+	assert.Equal(t, 1775, *estimate.SuggestedCalories)
+}
+
+func TestEstimateEnergyBalance_NotEnoughData(t *testing.T) {
+	tests := []struct {
+		name   string
+		trend  []models.WeightTrendPoint
+		intake []models.DailyNutrition
+	}{
+		{
+			name:  "single weigh-in",
+			trend: []models.WeightTrendPoint{{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), TrendKg: 80}},
+		},
+		{
+			name: "short span",
+			trend: []models.WeightTrendPoint{
+				{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), TrendKg: 80},
+				{Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), TrendKg: 79.8},
+			},
+		},
+		{
+			name: "no intake logged",
+			trend: []models.WeightTrendPoint{
+				{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), TrendKg: 80},
+				{Date: time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), TrendKg: 79},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := EstimateEnergyBalance(tt.trend, tt.intake, models.WeightGoalMaintain, 2000)
+
+			assert.Nil(t, estimate.EstimatedTDEE)
+			assert.Nil(t, estimate.OnTrack)
+			assert.NotEmpty(t, estimate.Notes)
+			assert.Equal(t, models.TrendMaintaining, estimate.IntendedDirection)
+		})
+	}
+}
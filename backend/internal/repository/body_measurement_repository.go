@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"nourish-backend/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BodyMeasurementRepository interface defines body measurement database operations
+type BodyMeasurementRepository interface {
+	Create(ctx context.Context, measurement *models.BodyMeasurement) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*models.BodyMeasurement, error)
+	GetByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.BodyMeasurement, error)
+	GetLatest(ctx context.Context, userID primitive.ObjectID) (*models.BodyMeasurement, error)
+	Update(ctx context.Context, id primitive.ObjectID, measurement *models.BodyMeasurement) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// bodyMeasurementRepository implements BodyMeasurementRepository interface
+type bodyMeasurementRepository struct {
+	collection *mongo.Collection
+}
+
+// NewBodyMeasurementRepository creates a new body measurement repository
+func NewBodyMeasurementRepository(db *mongo.Database) BodyMeasurementRepository {
+	collection := db.Collection("bodymeasurements")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Compound index for user history queries
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{"userId", 1}, {"date", -1}},
+	})
+
+	return &bodyMeasurementRepository{
+		collection: collection,
+	}
+}
+
+// Create creates a new body measurement
+func (r *bodyMeasurementRepository) Create(ctx context.Context, measurement *models.BodyMeasurement) error {
+	measurement.CreatedAt = time.Now()
+	measurement.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, measurement)
+	if err != nil {
+		return err
+	}
+
+	measurement.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a body measurement by ID
+func (r *bodyMeasurementRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.BodyMeasurement, error) {
+	var measurement models.BodyMeasurement
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&measurement)
+	if err != nil {
+		return nil, err
+	}
+	return &measurement, nil
+}
+
+// GetByDateRange retrieves a user's body measurements within a date range, oldest first
+func (r *bodyMeasurementRepository) GetByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.BodyMeasurement, error) {
+	query := bson.M{
+		"userId": userID,
+		"date": bson.M{
+			"$gte": startDate,
+			"$lte": endDate,
+		},
+	}
+
+	opts := options.Find().SetSort(bson.D{{"date", 1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	measurements := []*models.BodyMeasurement{}
+	if err = cursor.All(ctx, &measurements); err != nil {
+		return nil, err
+	}
+
+	return measurements, nil
+}
+
+// GetLatest retrieves a user's most recent body measurement with a weight
+func (r *bodyMeasurementRepository) GetLatest(ctx context.Context, userID primitive.ObjectID) (*models.BodyMeasurement, error) {
+	query := bson.M{
+		"userId":   userID,
+		"weightKg": bson.M{"$exists": true},
+	}
+	opts := options.FindOne().SetSort(bson.D{{"date", -1}})
+
+	var measurement models.BodyMeasurement
+	if err := r.collection.FindOne(ctx, query, opts).Decode(&measurement); err != nil {
+		return nil, err
+	}
+	return &measurement, nil
+}
+
+// Update replaces a body measurement, so that cleared optional values are removed
+func (r *bodyMeasurementRepository) Update(ctx context.Context, id primitive.ObjectID, measurement *models.BodyMeasurement) error {
+	measurement.UpdatedAt = time.Now()
+
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": id}, measurement)
+	return err
+}
+
+// Delete deletes a body measurement
+func (r *bodyMeasurementRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"time"
 
 	"nourish-backend/internal/models"
@@ -20,18 +22,61 @@ type DishRepository interface {
 	Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*models.Dish, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID, filter DishFilter) ([]*models.Dish, error)
 	Search(ctx context.Context, query string, filter DishFilter, page, limit int) ([]*models.Dish, int64, error)
+	ListPage(ctx context.Context, query string, filter DishFilter, cursor *models.PageCursor, limit int) ([]*models.Dish, models.CursorPage, error)
+	CountMatching(ctx context.Context, query string, filter DishFilter) (int64, error)
+	Facets(ctx context.Context, query string, filter DishFilter) (*models.DishFacets, error)
+	ListAll(ctx context.Context) ([]*models.Dish, error)
+	Archive(ctx context.Context, id primitive.ObjectID) error
+	GetByReviewStatus(ctx context.Context, status string, page, limit int) ([]*models.Dish, int64, error)
+	MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error
+	UpdateStats(ctx context.Context, stats map[primitive.ObjectID]models.DishStats) error
 }
 
-// DishFilter represents filters for dish queries
+// DishFilter represents filters for dish queries. Multi-select fields match any of their
+// values; different fields must all match.
 type DishFilter struct {
-	Type        string   // "Veg" or "Non-Veg"
-	Cuisine     string   // cuisine type
+	Type        []string // "Veg" or "Non-Veg"
+	Cuisine     []string // cuisine type
 	DietaryTags []string // dietary tags
-	SpiceLevel  string   // spice level
+	SpiceLevel  []string // spice level
+	Difficulty  []string // easy, medium or hard
 	MaxCalories int      // maximum calories
 	MinCalories int      // minimum calories
-	Ingredients []string // must contain these ingredients
+	Ingredients []string // must contain any of these ingredients, matched as words
+
+	AllDietaryTags     []string // must have all of these tags
+	ExcludeDietaryTags []string // must have none of these tags
+	AllIngredients     []string // must contain all of these ingredients, matched as words
+	ExcludeIngredients []string // must contain none of these ingredients, matched as words
+
+	MaxPrepTime int // minutes
+	MaxCookTime int // minutes
+	MinProtein  int // grams per serving
+	MinFiber    int // grams per serving
+
+	// Macro ratio bounds as shares of calories, 0 for no bound
+	MinProteinShare float64
+	MaxCarbShare    float64
+	MaxFatShare     float64
+
+	CalorieRanges  []string // keys of models.GetCalorieBuckets
+	PrepTimeRanges []string // keys of models.GetPrepTimeBuckets
+
+	MaxGlycemicLoad float64 // maximum glycemic load per serving; dishes without one are left out
+
+	ExcludeAllergens []string // must not contain any of these allergens
+	DietRules        []string // must comply with all of these rule sets
+
+	// IDs restricts the dishes to these, e.g. the candidates of a fuzzy search; nil for no restriction
+	IDs []primitive.ObjectID
+
+	// Viewer sees public dishes plus their own and their household's; nil for public dishes only
+	Viewer *models.User
+
+	// SortBy is one of models.GetDishSorts; empty for name order, or relevance when searching
+	SortBy string
 }
 
 // dishRepository implements DishRepository interface
@@ -70,6 +115,15 @@ func NewDishRepository(db *mongo.Database) DishRepository {
 		Keys: bson.D{{"calories", 1}},
 	})
 
+	// Indexes for visibility and the review queue
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "visibility", Value: 1}, {Key: "createdBy", Value: 1}},
+	})
+
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "review.status", Value: 1}, {Key: "review.submittedAt", Value: 1}},
+	})
+
 	return &dishRepository{
 		collection: collection,
 	}
@@ -113,22 +167,10 @@ func (r *dishRepository) GetAll(ctx context.Context, filter DishFilter, page, li
 	// Calculate pagination
 	skip := (page - 1) * limit
 
-	// Find options
-	opts := options.Find().
-		SetSkip(int64(skip)).
-		SetLimit(int64(limit)).
-		SetSort(bson.D{{"name", 1}})
-
-	cursor, err := r.collection.Find(ctx, query, opts)
+	dishes, err := r.findSorted(ctx, query, filter.SortBy, int64(skip), int64(limit))
 	if err != nil {
 		return nil, 0, err
 	}
-	defer cursor.Close(ctx)
-
-	var dishes []*models.Dish
-	if err = cursor.All(ctx, &dishes); err != nil {
-		return nil, 0, err
-	}
 
 	return dishes, total, nil
 }
@@ -147,6 +189,14 @@ func (r *dishRepository) Search(ctx context.Context, query string, filter DishFi
 	// Calculate pagination
 	skip := (page - 1) * limit
 
+	if filter.SortBy != "" && filter.SortBy != models.DishSortRelevance {
+		dishes, err := r.findSorted(ctx, searchQuery, filter.SortBy, int64(skip), int64(limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		return dishes, total, nil
+	}
+
 	// Find options with text score sorting
 	opts := options.Find().
 		SetSkip(int64(skip)).
@@ -168,6 +218,190 @@ func (r *dishRepository) Search(ctx context.Context, query string, filter DishFi
 	return dishes, total, nil
 }
 
+// dishSortKeys are the keys of each sort order. Every order ends with the ID so that dishes
+// with equal keys keep a stable order, which cursor pagination relies on.
+var dishSortKeys = map[string][]sortKey{
+	models.DishSortName:           {{Field: "name"}, {Field: "_id"}},
+	models.DishSortRelevance:      {{Field: "textScore", Desc: true, Expr: bson.M{"$meta": "textScore"}}, {Field: "_id"}},
+	models.DishSortCalories:       {{Field: "calories"}, {Field: "name"}, {Field: "_id"}},
+	models.DishSortProteinDensity: {{Field: "proteinDensity", Desc: true, Expr: proteinDensityExpr}, {Field: "name"}, {Field: "_id"}},
+	models.DishSortPrepTime:       {{Field: "prepTime"}, {Field: "name"}, {Field: "_id"}},
+	models.DishSortPopularity:     {{Field: "timesLogged", Desc: true, Expr: statOrZero("timesLogged")}, {Field: "name"}, {Field: "_id"}},
+	models.DishSortRating: {
+		{Field: "avgRating", Desc: true, Expr: statOrZero("avgRating")},
+		{Field: "ratingCount", Desc: true, Expr: statOrZero("ratingCount")},
+		{Field: "name"}, {Field: "_id"},
+	},
+	models.DishSortRecent: {{Field: "createdAt", Desc: true}, {Field: "_id", Desc: true}},
+}
+
+// proteinDensityExpr computes grams of protein per 100 kcal, 0 without calories
+var proteinDensityExpr = bson.M{"$cond": bson.A{
+	bson.M{"$gt": bson.A{"$calories", 0}},
+	bson.M{"$divide": bson.A{bson.M{"$multiply": bson.A{"$nutrition.protein", 100}}, "$calories"}},
+	0,
+}}
+
+// statOrZero reads a usage stat, 0 for dishes never logged
+func statOrZero(stat string) bson.M {
+	return bson.M{"$ifNull": bson.A{"$stats." + stat, 0}}
+}
+
+// resolveDishSort returns the sort order to use: relevance by default when searching,
+// name otherwise, and name for relevance without a search
+func resolveDishSort(sortBy, query string) string {
+	if _, ok := dishSortKeys[sortBy]; !ok {
+		sortBy = ""
+	}
+	switch {
+	case sortBy == "" && query != "":
+		return models.DishSortRelevance
+	case sortBy == "" || (sortBy == models.DishSortRelevance && query == ""):
+		return models.DishSortName
+	}
+	return sortBy
+}
+
+// findSorted finds a page of the dishes matching the query in the sort order. Orders on
+// computed keys need an aggregation.
+func (r *dishRepository) findSorted(ctx context.Context, query bson.M, sortBy string, skip, limit int64) ([]*models.Dish, error) {
+	keys := dishSortKeys[resolveDishSort(sortBy, "")]
+
+	var cursor *mongo.Cursor
+	var err error
+	if fields := computedFields(keys); fields != nil {
+		cursor, err = r.collection.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: query}},
+			{{Key: "$addFields", Value: fields}},
+			{{Key: "$sort", Value: sortDocument(keys, false)}},
+			{{Key: "$skip", Value: skip}},
+			{{Key: "$limit", Value: limit}},
+		})
+	} else {
+		cursor, err = r.collection.Find(ctx, query, options.Find().SetSort(sortDocument(keys, false)).SetSkip(skip).SetLimit(limit))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dishes []*models.Dish
+	if err = cursor.All(ctx, &dishes); err != nil {
+		return nil, err
+	}
+
+	return dishes, nil
+}
+
+// ListPage finds the page of dishes matching a text query and filter after or before the
+// cursor, keyed on the sort order. An empty query lists without text search.
+func (r *dishRepository) ListPage(ctx context.Context, query string, filter DishFilter, cursor *models.PageCursor, limit int) ([]*models.Dish, models.CursorPage, error) {
+	sortName := resolveDishSort(filter.SortBy, query)
+	docs, page, err := findPage(ctx, r.collection, r.buildSearchQuery(query, filter), sortName, dishSortKeys[sortName], cursor, limit)
+	if err != nil {
+		return nil, page, err
+	}
+
+	dishes := make([]*models.Dish, len(docs))
+	for i, doc := range docs {
+		var dish models.Dish
+		if err := bson.Unmarshal(doc, &dish); err != nil {
+			return nil, page, err
+		}
+		dishes[i] = &dish
+	}
+	return dishes, page, nil
+}
+
+// CountMatching counts the dishes matching a text query and filter
+func (r *dishRepository) CountMatching(ctx context.Context, query string, filter DishFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, r.buildSearchQuery(query, filter))
+}
+
+// facetBucket is one group of a facet aggregation
+type facetBucket struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// Facets counts the dishes matching a text query and filter by each facet in one $facet
+// aggregation. Each facet applies the other facets' selections but not its own.
+func (r *dishRepository) Facets(ctx context.Context, query string, filter DishFilter) (*models.DishFacets, error) {
+	base := r.buildBaseQuery(filter)
+	if query != "" {
+		base["$text"] = bson.M{"$search": query}
+	}
+
+	clauses := facetClauses(filter)
+	count := bson.M{"$sum": 1}
+	facetPipeline := func(facet string, stages ...bson.M) bson.A {
+		pipeline := bson.A{bson.M{"$match": withFacetClauses(bson.M{}, clauses, facet)}}
+		for _, stage := range stages {
+			pipeline = append(pipeline, stage)
+		}
+		return pipeline
+	}
+	groupBy := func(key interface{}) bson.M {
+		return bson.M{"$group": bson.M{"_id": key, "count": count}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: base}},
+		{{Key: "$facet", Value: bson.M{
+			facetType:        facetPipeline(facetType, groupBy("$type")),
+			facetCuisine:     facetPipeline(facetCuisine, groupBy("$cuisine")),
+			facetDietaryTags: facetPipeline(facetDietaryTags, bson.M{"$unwind": "$dietaryTags"}, groupBy("$dietaryTags")),
+			facetSpiceLevel:  facetPipeline(facetSpiceLevel, groupBy("$spiceLevel")),
+			facetDifficulty:  facetPipeline(facetDifficulty, groupBy("$difficulty")),
+			facetCalories:    facetPipeline(facetCalories, groupBy(bucketSwitch("$calories", models.GetCalorieBuckets()))),
+			facetPrepTime:    facetPipeline(facetPrepTime, groupBy(bucketSwitch("$prepTime", models.GetPrepTimeBuckets()))),
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []map[string][]facetBucket
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	counts := func(facet string) map[string]int {
+		byValue := map[string]int{}
+		if len(results) > 0 {
+			for _, bucket := range results[0][facet] {
+				byValue[bucket.Value] += bucket.Count
+			}
+		}
+		return byValue
+	}
+
+	return &models.DishFacets{
+		Type:        models.ValueFacetCounts(counts(facetType), filter.Type),
+		Cuisine:     models.ValueFacetCounts(counts(facetCuisine), filter.Cuisine),
+		DietaryTags: models.ValueFacetCounts(counts(facetDietaryTags), filter.DietaryTags),
+		SpiceLevel:  models.ValueFacetCounts(counts(facetSpiceLevel), filter.SpiceLevel),
+		Difficulty:  models.ValueFacetCounts(counts(facetDifficulty), filter.Difficulty),
+		Calories:    models.BucketFacetCounts(models.GetCalorieBuckets(), counts(facetCalories), filter.CalorieRanges),
+		PrepTime:    models.BucketFacetCounts(models.GetPrepTimeBuckets(), counts(facetPrepTime), filter.PrepTimeRanges),
+	}, nil
+}
+
+// bucketSwitch names the bucket a numeric field falls in, or "" for none
+func bucketSwitch(field string, buckets []models.RangeBucket) bson.M {
+	branches := bson.A{}
+	for _, bucket := range buckets {
+		bounds := bson.A{bson.M{"$gte": bson.A{field, bucket.Min}}}
+		if bucket.Max > 0 {
+			bounds = append(bounds, bson.M{"$lt": bson.A{field, bucket.Max}})
+		}
+		branches = append(branches, bson.M{"case": bson.M{"$and": bounds}, "then": bucket.Key})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": ""}}
+}
+
 // GetByIDs retrieves multiple dishes by their IDs
 func (r *dishRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]*models.Dish, error) {
 	query := bson.M{"_id": bson.M{"$in": ids}}
@@ -186,39 +420,158 @@ func (r *dishRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID)
 	return dishes, nil
 }
 
+// FindByIDs retrieves the dishes among ids that match the filter, in no particular order
+func (r *dishRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID, filter DishFilter) ([]*models.Dish, error) {
+	query := r.buildFilterQuery(filter)
+	query["_id"] = bson.M{"$in": ids}
+
+	cursor, err := r.collection.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dishes []*models.Dish
+	if err = cursor.All(ctx, &dishes); err != nil {
+		return nil, err
+	}
+
+	return dishes, nil
+}
+
+// ListAll retrieves every dish, ordered by name
+func (r *dishRepository) ListAll(ctx context.Context) ([]*models.Dish, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dishes []*models.Dish
+	if err = cursor.All(ctx, &dishes); err != nil {
+		return nil, err
+	}
+
+	return dishes, nil
+}
+
 // Update updates a dish
 func (r *dishRepository) Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish) error {
 	dish.UpdatedAt = time.Now()
 
-	update := bson.M{"$set": dish}
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": id}, dish)
+	return err
+}
+
+// Archive hides a dish from listings and search while keeping it for existing references
+func (r *dishRepository) Archive(ctx context.Context, id primitive.ObjectID) error {
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"archivedAt": now, "updatedAt": now}}
 	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
 	return err
 }
 
+// GetByReviewStatus retrieves dishes whose review has the given status, oldest submission first
+func (r *dishRepository) GetByReviewStatus(ctx context.Context, status string, page, limit int) ([]*models.Dish, int64, error) {
+	query := bson.M{"review.status": status, "archivedAt": bson.M{"$exists": false}}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "review.submittedAt", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var dishes []*models.Dish
+	if err = cursor.All(ctx, &dishes); err != nil {
+		return nil, 0, err
+	}
+
+	return dishes, total, nil
+}
+
+// MoveHouseholdDishes moves an owner's household dishes to their new household.
+// When the owner leaves without joining another, the dishes become private.
+func (r *dishRepository) MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	filter := bson.M{"createdBy": ownerID, "visibility": models.VisibilityHousehold}
+
+	update := bson.M{"$set": bson.M{"householdId": householdID, "updatedAt": time.Now()}}
+	if householdID == nil {
+		update = bson.M{
+			"$set":   bson.M{"visibility": models.VisibilityPrivate, "updatedAt": time.Now()},
+			"$unset": bson.M{"householdId": ""},
+		}
+	}
+
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	return err
+}
+
+// UpdateStats sets the usage stats of the given dishes and clears them from all others
+func (r *dishRepository) UpdateStats(ctx context.Context, stats map[primitive.ObjectID]models.DishStats) error {
+	ids := make([]primitive.ObjectID, 0, len(stats))
+	writes := make([]mongo.WriteModel, 0, len(stats))
+	for id, dishStats := range stats {
+		ids = append(ids, id)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"stats": dishStats}}))
+	}
+
+	if len(writes) > 0 {
+		if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$nin": ids}, "stats": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"stats": ""}})
+	return err
+}
+
 // Delete deletes a dish
 func (r *dishRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
 
+// Facets a dish listing can be narrowed by
+const (
+	facetType        = "type"
+	facetCuisine     = "cuisine"
+	facetDietaryTags = "dietaryTags"
+	facetSpiceLevel  = "spiceLevel"
+	facetDifficulty  = "difficulty"
+	facetCalories    = "calories"
+	facetPrepTime    = "prepTime"
+)
+
 // buildFilterQuery builds MongoDB query from DishFilter
 func (r *dishRepository) buildFilterQuery(filter DishFilter) bson.M {
-	query := bson.M{}
-
-	if filter.Type != "" {
-		query["type"] = filter.Type
-	}
-
-	if filter.Cuisine != "" {
-		query["cuisine"] = filter.Cuisine
-	}
+	return withFacetClauses(r.buildBaseQuery(filter), facetClauses(filter), "")
+}
 
-	if len(filter.DietaryTags) > 0 {
-		query["dietaryTags"] = bson.M{"$in": filter.DietaryTags}
+// buildBaseQuery builds the part of the filter query that is not a facet selection
+func (r *dishRepository) buildBaseQuery(filter DishFilter) bson.M {
+	query := bson.M{
+		"archivedAt": bson.M{"$exists": false},
+		"$or":        visibilityQuery(filter.Viewer),
 	}
 
-	if filter.SpiceLevel != "" {
-		query["spiceLevel"] = filter.SpiceLevel
+	if filter.IDs != nil {
+		query["_id"] = bson.M{"$in": filter.IDs}
 	}
 
 	if filter.MaxCalories > 0 || filter.MinCalories > 0 {
@@ -233,12 +586,179 @@ func (r *dishRepository) buildFilterQuery(filter DishFilter) bson.M {
 	}
 
 	if len(filter.Ingredients) > 0 {
-		query["ingredients"] = bson.M{"$in": filter.Ingredients}
+		patterns := make(bson.A, len(filter.Ingredients))
+		for i, ingredient := range filter.Ingredients {
+			patterns[i] = ingredientPattern(ingredient)
+		}
+		query["ingredients"] = bson.M{"$in": patterns}
+	}
+
+	var and bson.A
+	for _, ingredient := range filter.AllIngredients {
+		and = append(and, bson.M{"ingredients": ingredientPattern(ingredient)})
+	}
+	if len(filter.ExcludeIngredients) > 0 {
+		patterns := make(bson.A, len(filter.ExcludeIngredients))
+		for i, ingredient := range filter.ExcludeIngredients {
+			patterns[i] = ingredientPattern(ingredient)
+		}
+		and = append(and, bson.M{"ingredients": bson.M{"$nin": patterns}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+
+	if len(filter.AllDietaryTags) > 0 || len(filter.ExcludeDietaryTags) > 0 {
+		tagsQuery := bson.M{}
+		if len(filter.AllDietaryTags) > 0 {
+			tagsQuery["$all"] = filter.AllDietaryTags
+		}
+		if len(filter.ExcludeDietaryTags) > 0 {
+			tagsQuery["$nin"] = filter.ExcludeDietaryTags
+		}
+		query["dietaryTags"] = tagsQuery
+	}
+
+	atMost := func(field string, max int) {
+		if max > 0 {
+			query[field] = bson.M{"$lte": max}
+		}
+	}
+	atLeast := func(field string, min int) {
+		if min > 0 {
+			query[field] = bson.M{"$gte": min}
+		}
+	}
+	atMost("prepTime", filter.MaxPrepTime)
+	atMost("cookTime", filter.MaxCookTime)
+	atLeast("nutrition.protein", filter.MinProtein)
+	atLeast("nutrition.fiber", filter.MinFiber)
+
+	if ratio := macroRatioQuery(filter); ratio != nil {
+		query["$expr"] = ratio
+	}
+
+	if filter.MaxGlycemicLoad > 0 {
+		query["glycemicLoad"] = bson.M{"$lte": filter.MaxGlycemicLoad}
+	}
+
+	if len(filter.ExcludeAllergens) > 0 {
+		query["allergens"] = bson.M{"$nin": filter.ExcludeAllergens}
+	}
+
+	if len(filter.DietRules) > 0 {
+		query["dietRules"] = bson.M{"$all": filter.DietRules}
 	}
 
 	return query
 }
 
+// facetClauses builds one clause per facet with a selection, matching any selected value
+func facetClauses(filter DishFilter) map[string]bson.M {
+	clauses := map[string]bson.M{}
+	anyOf := func(facet, field string, values []string) {
+		if len(values) > 0 {
+			clauses[facet] = bson.M{field: bson.M{"$in": values}}
+		}
+	}
+
+	anyOf(facetType, "type", filter.Type)
+	anyOf(facetCuisine, "cuisine", filter.Cuisine)
+	anyOf(facetDietaryTags, "dietaryTags", filter.DietaryTags)
+	anyOf(facetSpiceLevel, "spiceLevel", filter.SpiceLevel)
+	anyOf(facetDifficulty, "difficulty", filter.Difficulty)
+	if clause := rangeClause("calories", models.GetCalorieBuckets(), filter.CalorieRanges); clause != nil {
+		clauses[facetCalories] = clause
+	}
+	if clause := rangeClause("prepTime", models.GetPrepTimeBuckets(), filter.PrepTimeRanges); clause != nil {
+		clauses[facetPrepTime] = clause
+	}
+	return clauses
+}
+
+// rangeClause matches a field falling in any of the selected buckets; unknown keys are ignored
+func rangeClause(field string, buckets []models.RangeBucket, keys []string) bson.M {
+	var ranges bson.A
+	for _, key := range keys {
+		bucket, ok := models.FindBucket(buckets, key)
+		if !ok {
+			continue
+		}
+		bounds := bson.M{"$gte": bucket.Min}
+		if bucket.Max > 0 {
+			bounds["$lt"] = bucket.Max
+		}
+		ranges = append(ranges, bson.M{field: bounds})
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+	return bson.M{"$or": ranges}
+}
+
+// ingredientPattern matches an ingredient name as whole words in any case, so "paneer"
+// matches "Paneer" and "paneer cubes" but not "paneerless"
+func ingredientPattern(name string) primitive.Regex {
+	return primitive.Regex{Pattern: `\b` + regexp.QuoteMeta(strings.TrimSpace(name)) + `\b`, Options: "i"}
+}
+
+// macroCalories are the calories per gram of each macronutrient
+var macroCalories = map[string]int{"protein": 4, "carbs": 4, "fat": 9}
+
+// macroRatioQuery bounds the share of calories from each macronutrient, or returns nil
+// without bounds. Dishes without calories never match a bound.
+func macroRatioQuery(filter DishFilter) bson.M {
+	bounds := bson.A{}
+	share := func(op, nutrient string, limit float64) {
+		if limit > 0 {
+			bounds = append(bounds, bson.M{op: bson.A{
+				bson.M{"$multiply": bson.A{"$nutrition." + nutrient, macroCalories[nutrient]}},
+				bson.M{"$multiply": bson.A{"$calories", limit}},
+			}})
+		}
+	}
+	share("$gte", "protein", filter.MinProteinShare)
+	share("$lte", "carbs", filter.MaxCarbShare)
+	share("$lte", "fat", filter.MaxFatShare)
+	if len(bounds) == 0 {
+		return nil
+	}
+	return bson.M{"$and": append(bson.A{bson.M{"$gt": bson.A{"$calories", 0}}}, bounds...)}
+}
+
+// withFacetClauses adds the facet clauses to a query, leaving out the named facet's own
+func withFacetClauses(query bson.M, clauses map[string]bson.M, except string) bson.M {
+	and, _ := query["$and"].(bson.A)
+	for _, facet := range []string{facetType, facetCuisine, facetDietaryTags, facetSpiceLevel, facetDifficulty, facetCalories, facetPrepTime} {
+		if clause, ok := clauses[facet]; ok && facet != except {
+			and = append(and, clause)
+		}
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+	return query
+}
+
+// visibilityQuery matches the dishes a viewer may see: public dishes, including those
+// saved before visibility was tracked, plus the viewer's own and their household's
+func visibilityQuery(viewer *models.User) bson.A {
+	clauses := bson.A{
+		bson.M{"visibility": bson.M{"$exists": false}},
+		bson.M{"visibility": models.VisibilityPublic},
+	}
+	if viewer != nil {
+		clauses = append(clauses, bson.M{"createdBy": viewer.ID})
+		if viewer.HouseholdID != nil {
+			clauses = append(clauses, bson.M{
+				"visibility":  models.VisibilityHousehold,
+				"householdId": *viewer.HouseholdID,
+			})
+		}
+	}
+	return clauses
+}
+
 // buildSearchQuery builds MongoDB query for text search with filters
 func (r *dishRepository) buildSearchQuery(searchText string, filter DishFilter) bson.M {
 	query := bson.M{}
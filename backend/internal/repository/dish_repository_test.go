@@ -105,8 +105,8 @@ func TestDishRepository_GetAll(t *testing.T) {
 		// Arrange
 		repo := NewDishRepository(mt.DB)
 		filter := DishFilter{
-			Type:    "Veg",
-			Cuisine: "North Indian",
+			Type:    []string{"Veg"},
+			Cuisine: []string{"North Indian"},
 		}
 
 		dishID1 := primitive.NewObjectID()
@@ -280,7 +280,7 @@ func TestDishRepository_Search(t *testing.T) {
 		// Arrange
 		repo := NewDishRepository(mt.DB)
 		query := "chicken"
-		filter := DishFilter{Type: "Non-Veg"}
+		filter := DishFilter{Type: []string{"Non-Veg"}}
 
 		dishID := primitive.NewObjectID()
 
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"nourish-backend/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GoalVersionRepository interface defines nutrition goal history database operations
+type GoalVersionRepository interface {
+	Upsert(ctx context.Context, version *models.GoalVersion) error
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.GoalVersion, error)
+	DeleteAfter(ctx context.Context, userID primitive.ObjectID, effectiveFrom time.Time) error
+}
+
+// goalVersionRepository implements GoalVersionRepository interface
+type goalVersionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewGoalVersionRepository creates a new goal version repository
+func NewGoalVersionRepository(db *mongo.Database) GoalVersionRepository {
+	collection := db.Collection("goalversions")
+
+	// Create indexes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// One version per user and effective day
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"userId", 1}, {"effectiveFrom", 1}},
+		Options: options.Index().SetUnique(true),
+	})
+
+	return &goalVersionRepository{
+		collection: collection,
+	}
+}
+
+// Upsert saves a goal version, replacing any version effective from the same moment
+func (r *goalVersionRepository) Upsert(ctx context.Context, version *models.GoalVersion) error {
+	version.CreatedAt = time.Now()
+
+	filter := bson.M{"userId": version.UserID, "effectiveFrom": version.EffectiveFrom}
+	update := bson.M{"$set": bson.M{
+		"goals":     version.Goals,
+		"source":    version.Source,
+		"createdAt": version.CreatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	if id, ok := result.UpsertedID.(primitive.ObjectID); ok {
+		version.ID = id
+	}
+	return nil
+}
+
+// GetByUserID retrieves a user's goal versions, oldest first
+func (r *goalVersionRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.GoalVersion, error) {
+	opts := options.Find().SetSort(bson.D{{"effectiveFrom", 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []models.GoalVersion{}
+	if err = cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DeleteAfter removes versions that take effect after the given moment
+func (r *goalVersionRepository) DeleteAfter(ctx context.Context, userID primitive.ObjectID, effectiveFrom time.Time) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{
+		"userId":        userID,
+		"effectiveFrom": bson.M{"$gt": effectiveFrom},
+	})
+	return err
+}
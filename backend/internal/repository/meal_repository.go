@@ -17,6 +17,8 @@ type MealRepository interface {
 	Create(ctx context.Context, meal *models.Meal) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.Meal, error)
 	GetByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.Meal, int64, error)
+	ListPageByUserID(ctx context.Context, userID primitive.ObjectID, cursor *models.PageCursor, limit int) ([]*models.Meal, models.CursorPage, error)
+	CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
 	GetByUserAndDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.Meal, error)
 	Update(ctx context.Context, id primitive.ObjectID, meal *models.Meal) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
@@ -26,11 +28,15 @@ type MealRepository interface {
 	SoftDeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error
 	UndoDeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error
 	GetNutritionByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]NutritionSummary, error)
+	CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error)
+	DeleteByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error)
+	GetDishUsage(ctx context.Context) ([]DishUsage, error)
 }
 
 // NutritionSummary represents daily nutrition summary
 type NutritionSummary struct {
-	Date      time.Time `bson:"_id" json:"date"`
+	Day       string    `bson:"_id" json:"-"`
+	Date      time.Time `bson:"-" json:"date"`
 	Calories  int       `bson:"totalCalories" json:"calories"`
 	Protein   int       `bson:"totalProtein" json:"protein"`
 	Carbs     int       `bson:"totalCarbs" json:"carbs"`
@@ -38,6 +44,19 @@ type NutritionSummary struct {
 	Fiber     int       `bson:"totalFiber" json:"fiber"`
 	Sodium    int       `bson:"totalSodium" json:"sodium"`
 	MealCount int       `bson:"mealCount" json:"mealCount"`
+
+	// Micronutrient totals for the day, summed from the per-meal values
+	Micronutrients     map[string]float64   `bson:"-" json:"micronutrients,omitempty"`
+	MealsWithData      int                  `bson:"-" json:"mealsWithMicronutrients"`
+	MicronutrientsList []map[string]float64 `bson:"micronutrientsList" json:"-"`
+}
+
+// DishUsage counts the logged meals of one dish and their ratings
+type DishUsage struct {
+	DishID      primitive.ObjectID `bson:"_id"`
+	TimesLogged int                `bson:"timesLogged"`
+	RatingCount int                `bson:"ratingCount"`
+	RatingSum   int                `bson:"ratingSum"`
 }
 
 // mealRepository implements MealRepository interface
@@ -125,6 +144,35 @@ func (r *mealRepository) GetByUserID(ctx context.Context, userID primitive.Objec
 	return meals, total, nil
 }
 
+// mealSort orders a user's meals newest first
+const mealSort = "date"
+
+// mealSortKeys are the keys of mealSort, ending with the ID for a stable order
+var mealSortKeys = []sortKey{{Field: "date", Desc: true}, {Field: "_id", Desc: true}}
+
+// ListPageByUserID finds the page of a user's meals after or before the cursor, newest first
+func (r *mealRepository) ListPageByUserID(ctx context.Context, userID primitive.ObjectID, cursor *models.PageCursor, limit int) ([]*models.Meal, models.CursorPage, error) {
+	docs, page, err := findPage(ctx, r.collection, bson.M{"userId": userID}, mealSort, mealSortKeys, cursor, limit)
+	if err != nil {
+		return nil, page, err
+	}
+
+	meals := make([]*models.Meal, len(docs))
+	for i, doc := range docs {
+		var meal models.Meal
+		if err := bson.Unmarshal(doc, &meal); err != nil {
+			return nil, page, err
+		}
+		meals[i] = &meal
+	}
+	return meals, page, nil
+}
+
+// CountByUserID counts a user's meals
+func (r *mealRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"userId": userID})
+}
+
 // GetByUserAndDateRange retrieves meals for a user within a date range
 func (r *mealRepository) GetByUserAndDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.Meal, error) {
 	query := bson.M{
@@ -223,8 +271,51 @@ func (r *mealRepository) UndoDeleteByIDs(ctx context.Context, ids []primitive.Ob
 	return err
 }
 
-// GetNutritionByDateRange aggregates nutrition data for a user within a date range
+// CountByDishID counts meals, including soft-deleted ones, that refer to a dish
+func (r *mealRepository) CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"dishId": dishID})
+}
+
+// DeleteByDishID permanently deletes every meal that refers to a dish
+func (r *mealRepository) DeleteByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"dishId": dishID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// GetDishUsage counts the meals logged with each dish, leaving out deleted meals.
+// Only meals with a rating count towards the rating.
+func (r *mealRepository) GetDishUsage(ctx context.Context) ([]DishUsage, error) {
+	rated := bson.M{"$gt": bson.A{"$rating", 0}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deletedAt": bson.M{"$exists": false}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":         "$dishId",
+			"timesLogged": bson.M{"$sum": 1},
+			"ratingCount": bson.M{"$sum": bson.M{"$cond": bson.A{rated, 1, 0}}},
+			"ratingSum":   bson.M{"$sum": bson.M{"$cond": bson.A{rated, "$rating", 0}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var usage []DishUsage
+	if err = cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// GetNutritionByDateRange aggregates nutrition data for a user within a date range.
+// Meals are grouped by calendar day in the location of startDate.
 func (r *mealRepository) GetNutritionByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]NutritionSummary, error) {
+	loc := startDate.Location()
 	pipeline := []bson.M{
 		{
 			"$match": bson.M{
@@ -250,8 +341,9 @@ func (r *mealRepository) GetNutritionByDateRange(ctx context.Context, userID pri
 			"$group": bson.M{
 				"_id": bson.M{
 					"$dateToString": bson.M{
-						"format": "%Y-%m-%d",
-						"date":   "$date",
+						"format":   "%Y-%m-%d",
+						"date":     "$date",
+						"timezone": timezoneName(loc),
 					},
 				},
 				"totalCalories": bson.M{"$sum": "$dish.calories"},
@@ -261,6 +353,9 @@ func (r *mealRepository) GetNutritionByDateRange(ctx context.Context, userID pri
 				"totalFiber":    bson.M{"$sum": "$dish.nutrition.fiber"},
 				"totalSodium":   bson.M{"$sum": "$dish.nutrition.sodium"},
 				"mealCount":     bson.M{"$sum": 1},
+				"micronutrientsList": bson.M{"$push": bson.M{
+					"$ifNull": bson.A{"$dish.nutrition.micronutrients", bson.M{}},
+				}},
 			},
 		},
 		{
@@ -279,5 +374,26 @@ func (r *mealRepository) GetNutritionByDateRange(ctx context.Context, userID pri
 		return nil, err
 	}
 
+	for i := range results {
+		if day, err := time.ParseInLocation("2006-01-02", results[i].Day, loc); err == nil {
+			results[i].Date = day
+		}
+		for _, micronutrients := range results[i].MicronutrientsList {
+			if len(micronutrients) > 0 {
+				results[i].MealsWithData++
+				results[i].Micronutrients = models.AddMicronutrients(results[i].Micronutrients, micronutrients)
+			}
+		}
+		results[i].MicronutrientsList = nil
+	}
+
 	return results, nil
 }
+
+// timezoneName returns the IANA name of loc understood by MongoDB date operators
+func timezoneName(loc *time.Location) string {
+	if loc == nil || loc == time.Local || loc.String() == "" {
+		return "UTC"
+	}
+	return loc.String()
+}
@@ -248,15 +248,15 @@ func TestMealRepository_GetNutritionByDateRange(t *testing.T) {
 		// Arrange
 		repo := NewMealRepository(mt.DB)
 		userID := primitive.NewObjectID()
-		startDate := time.Now().AddDate(0, 0, -7)
-		endDate := time.Now()
+		startDate := time.Now().UTC().AddDate(0, 0, -7)
+		endDate := time.Now().UTC()
 		
 		date1 := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
 		date2 := time.Date(2023, 10, 16, 0, 0, 0, 0, time.UTC)
 
 		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.meals", mtest.FirstBatch,
 			bson.D{
-				{"_id", date1},
+				{"_id", "2023-10-15"},
 				{"totalCalories", 1200},
 				{"totalProtein", 60},
 				{"totalCarbs", 150},
@@ -267,7 +267,7 @@ func TestMealRepository_GetNutritionByDateRange(t *testing.T) {
 			}))
 		mt.AddMockResponses(mtest.CreateCursorResponse(1, "test.meals", mtest.NextBatch,
 			bson.D{
-				{"_id", date2},
+				{"_id", "2023-10-16"},
 				{"totalCalories", 1400},
 				{"totalProtein", 70},
 				{"totalCarbs", 180},
@@ -20,6 +20,8 @@ type MealPlanRepository interface {
 	Update(ctx context.Context, id primitive.ObjectID, mealPlan *models.MealPlan) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
 	GetActivePlans(ctx context.Context, userID primitive.ObjectID) ([]*models.MealPlan, error)
+	CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error)
+	RemoveDish(ctx context.Context, dishID primitive.ObjectID) error
 }
 
 // mealPlanRepository implements MealPlanRepository interface
@@ -132,6 +134,21 @@ func (r *mealPlanRepository) GetActivePlans(ctx context.Context, userID primitiv
 	return mealPlans, nil
 }
 
+// CountByDishID counts meal plans that include a dish
+func (r *mealPlanRepository) CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"meals.dishId": dishID})
+}
+
+// RemoveDish removes a dish from every meal plan that includes it
+func (r *mealPlanRepository) RemoveDish(ctx context.Context, dishID primitive.ObjectID) error {
+	update := bson.M{
+		"$pull": bson.M{"meals": bson.M{"dishId": dishID}},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+	_, err := r.collection.UpdateMany(ctx, bson.M{"meals.dishId": dishID}, update)
+	return err
+}
+
 // Update updates a meal plan
 func (r *mealPlanRepository) Update(ctx context.Context, id primitive.ObjectID, mealPlan *models.MealPlan) error {
 	mealPlan.UpdatedAt = time.Now()
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"nourish-backend/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// sortKey is one key of a listing's sort order. Computed keys are added to each document
+// before sorting, for values that are derived or may be missing.
+type sortKey struct {
+	Field string
+	Desc  bool
+	Expr  interface{} // aggregation expression for a computed key, nil for a stored field
+}
+
+// sortDocument returns the keys as a sort specification, reversed for a backward page
+func sortDocument(keys []sortKey, backward bool) bson.D {
+	sortSpec := make(bson.D, len(keys))
+	for i, key := range keys {
+		direction := 1
+		if key.Desc != backward {
+			direction = -1
+		}
+		sortSpec[i] = bson.E{Key: key.Field, Value: direction}
+	}
+	return sortSpec
+}
+
+// computedFields returns the computed keys as an $addFields specification, or nil without any
+func computedFields(keys []sortKey) bson.M {
+	var fields bson.M
+	for _, key := range keys {
+		if key.Expr != nil {
+			if fields == nil {
+				fields = bson.M{}
+			}
+			fields[key.Field] = key.Expr
+		}
+	}
+	return fields
+}
+
+// keysetQuery matches the documents after the boundary values in the sort order, or
+// before them for a backward page: those greater on the first key, or equal on it and
+// greater on the second, and so on
+func keysetQuery(keys []sortKey, values bson.A, backward bool) bson.M {
+	or := make(bson.A, len(keys))
+	for i, key := range keys {
+		clause := bson.M{}
+		for j := 0; j < i; j++ {
+			clause[keys[j].Field] = values[j]
+		}
+		op := "$gt"
+		if key.Desc != backward {
+			op = "$lt"
+		}
+		clause[key.Field] = bson.M{op: values[i]}
+		or[i] = clause
+	}
+	return bson.M{"$or": or}
+}
+
+// cursorValues reads the sort key values of a document
+func cursorValues(keys []sortKey, doc bson.Raw) (bson.A, error) {
+	values := make(bson.A, len(keys))
+	for i, key := range keys {
+		raw, err := doc.LookupErr(strings.Split(key.Field, ".")...)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if err := raw.Unmarshal(&value); err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// findPage finds the page of documents matching the query after or before the cursor,
+// fetching one extra document to tell whether another page follows. The documents are
+// returned in sort order with the cursors either side.
+func findPage(ctx context.Context, collection *mongo.Collection, query bson.M, sortName string, keys []sortKey, cursor *models.PageCursor, limit int) ([]bson.Raw, models.CursorPage, error) {
+	var page models.CursorPage
+	if cursor != nil && (cursor.Sort != sortName || cursor.Ranked || len(cursor.Values) != len(keys)) {
+		return nil, page, models.ErrInvalidCursor
+	}
+	backward := cursor != nil && cursor.Backward
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: query}}}
+	if fields := computedFields(keys); fields != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: fields}})
+	}
+	if cursor != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: keysetQuery(keys, cursor.Values, backward)}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: sortDocument(keys, backward)}},
+		bson.D{{Key: "$limit", Value: limit + 1}},
+	)
+
+	results, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, page, err
+	}
+	defer results.Close(ctx)
+
+	var docs []bson.Raw
+	if err = results.All(ctx, &docs); err != nil {
+		return nil, page, err
+	}
+
+	more := len(docs) > limit
+	if more {
+		docs = docs[:limit]
+	}
+	if backward {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+	if len(docs) == 0 {
+		return docs, page, nil
+	}
+
+	// Going forward there is a previous page whenever a cursor was followed; going back
+	// there is always a next page, the one the cursor came from
+	if (backward && more) || (!backward && cursor != nil) {
+		values, err := cursorValues(keys, docs[0])
+		if err != nil {
+			return nil, page, err
+		}
+		page.Prev = &models.PageCursor{Sort: sortName, Values: values, Backward: true}
+	}
+	if (!backward && more) || backward {
+		values, err := cursorValues(keys, docs[len(docs)-1])
+		if err != nil {
+			return nil, page, err
+		}
+		page.Next = &models.PageCursor{Sort: sortName, Values: values}
+	}
+	return docs, page, nil
+}
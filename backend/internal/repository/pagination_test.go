@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestKeysetQuery(t *testing.T) {
+	// Arrange
+	keys := []sortKey{{Field: "calories"}, {Field: "name"}, {Field: "_id"}}
+	values := bson.A{250, "Poha", "id1"}
+
+	// Act
+	forward := keysetQuery(keys, values, false)
+	backward := keysetQuery(keys, values, true)
+
+	// Assert
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"calories": bson.M{"$gt": 250}},
+		bson.M{"calories": 250, "name": bson.M{"$gt": "Poha"}},
+		bson.M{"calories": 250, "name": "Poha", "_id": bson.M{"$gt": "id1"}},
+	}}, forward)
+	assert.Equal(t, bson.M{"$lt": 250}, backward["$or"].(bson.A)[0].(bson.M)["calories"])
+}
+
+func TestKeysetQuery_Descending(t *testing.T) {
+	// Arrange
+	keys := []sortKey{{Field: "date", Desc: true}, {Field: "_id", Desc: true}}
+
+	// Act
+	query := keysetQuery(keys, bson.A{"2024-03-01", "id1"}, false)
+
+	// Assert
+	assert.Equal(t, bson.M{"$or": bson.A{
+		bson.M{"date": bson.M{"$lt": "2024-03-01"}},
+		bson.M{"date": "2024-03-01", "_id": bson.M{"$lt": "id1"}},
+	}}, query)
+}
+
+func TestSortDocument(t *testing.T) {
+	// Arrange
+	keys := []sortKey{{Field: "timesLogged", Desc: true, Expr: statOrZero("timesLogged")}, {Field: "name"}}
+
+	// Act & Assert
+	assert.Equal(t, bson.D{{Key: "timesLogged", Value: -1}, {Key: "name", Value: 1}}, sortDocument(keys, false))
+	assert.Equal(t, bson.D{{Key: "timesLogged", Value: 1}, {Key: "name", Value: -1}}, sortDocument(keys, true))
+	assert.Equal(t, bson.M{"timesLogged": statOrZero("timesLogged")}, computedFields(keys))
+	assert.Nil(t, computedFields(keys[1:]))
+}
+
+func TestCursorValues(t *testing.T) {
+	// Arrange
+	doc, _ := bson.Marshal(bson.M{"name": "Poha", "stats": bson.M{"timesLogged": 4}})
+	keys := []sortKey{{Field: "stats.timesLogged"}, {Field: "name"}}
+
+	// Act
+	values, err := cursorValues(keys, doc)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, bson.A{int32(4), "Poha"}, values)
+}
+
+func TestResolveDishSort(t *testing.T) {
+	tests := []struct {
+		sortBy, query, expected string
+	}{
+		{"", "", "name"},
+		{"", "dal", "relevance"},
+		{"relevance", "", "name"},
+		{"calories", "dal", "calories"},
+		{"unknown", "", "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy+"/"+tt.query, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tt.expected, resolveDishSort(tt.sortBy, tt.query))
+		})
+	}
+}
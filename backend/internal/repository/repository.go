@@ -11,6 +11,8 @@ type Repositories struct {
 	Meal     MealRepository
 	MealPlan MealPlanRepository
 	Undo     UndoRepository
+	Body     BodyMeasurementRepository
+	Goal     GoalVersionRepository
 }
 
 // NewRepositories creates and returns all repository instances
@@ -21,5 +23,7 @@ func NewRepositories(db *mongo.Database) *Repositories {
 		Meal:     NewMealRepository(db),
 		MealPlan: NewMealPlanRepository(db),
 		Undo:     NewUndoRepository(db),
+		Body:     NewBodyMeasurementRepository(db),
+		Goal:     NewGoalVersionRepository(db),
 	}
 }
@@ -23,6 +23,9 @@ type UserRepository interface {
 	RemoveFromFavorites(ctx context.Context, userID, dishID primitive.ObjectID) error
 	GetFavorites(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
+	CountFavoritesByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error)
+	RemoveDishFromAllFavorites(ctx context.Context, dishID primitive.ObjectID) error
+	SetHousehold(ctx context.Context, userID primitive.ObjectID, householdID *primitive.ObjectID) error
 }
 
 // userRepository implements UserRepository interface
@@ -44,6 +47,12 @@ func NewUserRepository(db *mongo.Database) UserRepository {
 		Options: options.Index().SetUnique(true),
 	})
 
+	// Household index for membership lookups
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "householdId", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	})
+
 	return &userRepository{
 		collection: collection,
 	}
@@ -136,6 +145,22 @@ func (r *userRepository) RemoveFromFavorites(ctx context.Context, userID, dishID
 	return err
 }
 
+// CountFavoritesByDishID counts users who have a dish in their favorites
+func (r *userRepository) CountFavoritesByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"favorites": dishID})
+}
+
+// RemoveDishFromAllFavorites removes a dish from every user's favorites
+func (r *userRepository) RemoveDishFromAllFavorites(ctx context.Context, dishID primitive.ObjectID) error {
+	update := bson.M{
+		"$pull": bson.M{"favorites": dishID},
+		"$set":  bson.M{"updatedAt": time.Now()},
+	}
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{"favorites": dishID}, update)
+	return err
+}
+
 // GetFavorites gets user's favorite dish IDs
 func (r *userRepository) GetFavorites(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
 	var user struct {
@@ -155,6 +180,20 @@ func (r *userRepository) GetFavorites(ctx context.Context, userID primitive.Obje
 	return user.Favorites, nil
 }
 
+// SetHousehold moves a user to a household, or out of their household when householdID is nil
+func (r *userRepository) SetHousehold(ctx context.Context, userID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	update := bson.M{"$set": bson.M{"householdId": householdID, "updatedAt": time.Now()}}
+	if householdID == nil {
+		update = bson.M{
+			"$unset": bson.M{"householdId": ""},
+			"$set":   bson.M{"updatedAt": time.Now()},
+		}
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	return err
+}
+
 // Delete deletes a user
 func (r *userRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
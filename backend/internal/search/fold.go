@@ -0,0 +1,97 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// spellingFolds reduce common transliteration variants of Indian dish names to one spelling,
+// e.g. "chhole" and "chole", "sambhar" and "sambar", "vada" and "wada"
+var spellingFolds = strings.NewReplacer(
+	"chh", "ch",
+	"bh", "b", "dh", "d", "gh", "g", "kh", "k", "th", "t", "ph", "f",
+	"ee", "i", "oo", "u",
+	"iy", "y",
+	"w", "v", "q", "k", "z", "j",
+)
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// fold reduces a lowercase word to its canonical spelling: transliteration variants are
+// folded, doubled letters collapsed and Tamil-style endings dropped ("dosai", "idly")
+func fold(word string) string {
+	word = spellingFolds.Replace(word)
+
+	var b strings.Builder
+	var previous rune
+	for _, r := range word {
+		if r != previous {
+			b.WriteRune(r)
+		}
+		previous = r
+	}
+	word = b.String()
+
+	switch {
+	case len(word) > 3 && strings.HasSuffix(word, "ai"):
+		word = strings.TrimSuffix(word, "i")
+	case len(word) > 3 && strings.HasSuffix(word, "y"):
+		word = strings.TrimSuffix(word, "y") + "i"
+	}
+	return word
+}
+
+// terms tokenizes and folds text
+func terms(text string) []string {
+	words := tokenize(text)
+	folded := make([]string, 0, len(words))
+	for _, word := range words {
+		if term := fold(word); term != "" {
+			folded = append(folded, term)
+		}
+	}
+	return folded
+}
+
+// trigrams returns the distinct three-letter sequences of a term padded with spaces,
+// so short terms and word starts and ends get their own trigrams
+func trigrams(term string) []string {
+	padded := []rune("  " + term + " ")
+	seen := map[string]bool{}
+	grams := make([]string, 0, len(padded))
+	for i := 0; i+3 <= len(padded); i++ {
+		gram := string(padded[i : i+3])
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// editDistance is the Levenshtein distance between two terms
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
@@ -0,0 +1,234 @@
+// Package search provides an in-process fuzzy index over dish names, aliases and ingredients.
+// Terms are folded to a canonical spelling and matched by shared trigrams, so misspellings
+// and transliteration variants still find the dish.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Fields a term can come from
+const (
+	FieldName       = "name"
+	FieldAlias      = "alias"
+	FieldIngredient = "ingredient"
+)
+
+// fieldWeights rank matches on a dish's own names above matches on its ingredients
+var fieldWeights = map[string]float64{
+	FieldName:       1.0,
+	FieldAlias:      0.9,
+	FieldIngredient: 0.6,
+}
+
+// Matching thresholds
+const (
+	minTermSimilarity = 0.6  // a query word must be at least this close to an indexed word
+	minScore          = 0.45 // a document must score at least this to match
+	minPrefixLength   = 2    // shortest word that is matched as a prefix
+	exactNameBonus    = 0.25 // added when the whole query is the name or an alias
+)
+
+// Document is one dish as seen by the index
+type Document struct {
+	ID          string
+	Name        string
+	Aliases     []string
+	Ingredients []string
+}
+
+// Match is a document matching a query, with the text it matched best
+type Match struct {
+	ID    string
+	Name  string
+	Score float64 // 0-1.25, higher is better
+	Field string  // field of the best matching text
+	Text  string  // the best matching name, alias or ingredient
+}
+
+// posting records where a term occurs
+type posting struct {
+	doc   int
+	field string
+	text  string
+}
+
+// Index is an immutable trigram index; build a new one when the documents change
+type Index struct {
+	docs     []Document
+	terms    []string
+	postings [][]posting
+	grams    map[string][]int // trigram to term positions
+	names    map[int][]string // folded name and aliases per document
+}
+
+// NewIndex indexes the documents
+func NewIndex(docs []Document) *Index {
+	idx := &Index{
+		docs:  docs,
+		grams: map[string][]int{},
+		names: map[int][]string{},
+	}
+	termIDs := map[string]int{}
+
+	add := func(doc int, field, text string) {
+		for _, term := range terms(text) {
+			id, ok := termIDs[term]
+			if !ok {
+				id = len(idx.terms)
+				termIDs[term] = id
+				idx.terms = append(idx.terms, term)
+				idx.postings = append(idx.postings, nil)
+				for _, gram := range trigrams(term) {
+					idx.grams[gram] = append(idx.grams[gram], id)
+				}
+			}
+			idx.postings[id] = append(idx.postings[id], posting{doc: doc, field: field, text: text})
+		}
+	}
+
+	for i, doc := range docs {
+		add(i, FieldName, doc.Name)
+		idx.names[i] = append(idx.names[i], joinTerms(doc.Name))
+		for _, alias := range doc.Aliases {
+			add(i, FieldAlias, alias)
+			idx.names[i] = append(idx.names[i], joinTerms(alias))
+		}
+		for _, ingredient := range doc.Ingredients {
+			add(i, FieldIngredient, ingredient)
+		}
+	}
+	return idx
+}
+
+// Len returns the number of indexed documents
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+// Search ranks documents by how closely their words match every word of the query
+func (idx *Index) Search(query string, limit int) []Match {
+	return idx.match(query, false, limit)
+}
+
+// Complete ranks documents for type-ahead: the last word of the query also matches
+// the start of longer words, so "bir" finds "Biryani"
+func (idx *Index) Complete(query string, limit int) []Match {
+	return idx.match(query, true, limit)
+}
+
+// best is the best match of one query word within a document
+type best struct {
+	score float64
+	field string
+	text  string
+}
+
+// match scores every document sharing trigrams with the query
+func (idx *Index) match(query string, prefix bool, limit int) []Match {
+	queryTerms := terms(query)
+	if len(queryTerms) == 0 || limit <= 0 {
+		return nil
+	}
+
+	found := map[int][]best{} // per document, the best match of each query word
+	for qi, queryTerm := range queryTerms {
+		asPrefix := prefix && qi == len(queryTerms)-1 && len([]rune(queryTerm)) >= minPrefixLength
+		for termID, similarity := range idx.similarTerms(queryTerm, asPrefix) {
+			for _, p := range idx.postings[termID] {
+				score := similarity * fieldWeights[p.field]
+				if found[p.doc] == nil {
+					found[p.doc] = make([]best, len(queryTerms))
+				}
+				if score > found[p.doc][qi].score {
+					found[p.doc][qi] = best{score: score, field: p.field, text: p.text}
+				}
+			}
+		}
+	}
+
+	folded := joinTerms(query)
+	matches := make([]Match, 0, len(found))
+	for doc, words := range found {
+		total := 0.0
+		top := best{}
+		for _, word := range words {
+			total += word.score
+			if word.score > top.score {
+				top = word
+			}
+		}
+		score := total / float64(len(queryTerms))
+		if score < minScore {
+			continue
+		}
+		for _, name := range idx.names[doc] {
+			if name == folded {
+				score += exactNameBonus
+				break
+			}
+		}
+		matches = append(matches, Match{
+			ID:    idx.docs[doc].ID,
+			Name:  idx.docs[doc].Name,
+			Score: math.Round(score*1000) / 1000,
+			Field: top.field,
+			Text:  top.text,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// similarTerms returns the indexed terms close enough to a query term, with their similarity
+func (idx *Index) similarTerms(queryTerm string, prefix bool) map[int]float64 {
+	queryGrams := trigrams(queryTerm)
+	shared := map[int]int{}
+	for _, gram := range queryGrams {
+		for _, termID := range idx.grams[gram] {
+			shared[termID]++
+		}
+	}
+
+	similar := map[int]float64{}
+	for termID, count := range shared {
+		term := idx.terms[termID]
+		similarity := termSimilarity(queryTerm, term, count, len(queryGrams), len(trigrams(term)))
+		if prefix && len(term) > len(queryTerm) && term[:len(queryTerm)] == queryTerm {
+			similarity = math.Max(similarity, 0.7+0.3*float64(len(queryTerm))/float64(len(term)))
+		}
+		if similarity >= minTermSimilarity {
+			similar[termID] = similarity
+		}
+	}
+	return similar
+}
+
+// termSimilarity is the better of the trigram Dice coefficient and the normalized edit
+// distance, which is kinder to single-letter slips in short words
+func termSimilarity(a, b string, shared, aGrams, bGrams int) float64 {
+	if a == b {
+		return 1
+	}
+	dice := 2 * float64(shared) / float64(aGrams+bGrams)
+
+	longest := math.Max(float64(len([]rune(a))), float64(len([]rune(b))))
+	edit := 1 - float64(editDistance(a, b))/longest
+	return math.Max(dice, edit)
+}
+
+// joinTerms folds text into a single comparable string
+func joinTerms(text string) string {
+	return strings.Join(terms(text), " ")
+}
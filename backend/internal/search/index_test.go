@@ -0,0 +1,118 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex() *Index {
+	return NewIndex([]Document{
+		{ID: "1", Name: "Chicken Biryani", Aliases: []string{"Dum Biryani"}, Ingredients: []string{"basmati rice", "chicken", "saffron", "fried onion"}},
+		{ID: "2", Name: "Palak Paneer", Ingredients: []string{"spinach", "paneer", "cream", "garlic"}},
+		{ID: "3", Name: "Chole Bhature", Aliases: []string{"Chhole Bhature"}, Ingredients: []string{"chickpeas", "maida", "onion", "tomato"}},
+		{ID: "4", Name: "Masala Dosa", Ingredients: []string{"rice", "urad dal", "potato"}},
+		{ID: "5", Name: "Idli Sambar", Ingredients: []string{"rice", "urad dal", "toor dal", "tamarind"}},
+		{ID: "6", Name: "Dal Tadka", Ingredients: []string{"toor dal", "ghee", "cumin"}},
+	})
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"chole", "chhole"},
+		{"biryani", "biriyani"},
+		{"paneer", "paneer"},
+		{"dosa", "dosai"},
+		{"idli", "idly"},
+		{"sambar", "sambhar"},
+		{"vada", "wada"},
+		{"dal", "daal"},
+		{"puri", "poori"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, fold(tt.a), fold(tt.b))
+		})
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string // ID of the top match
+	}{
+		{"biryani", "1"},
+		{"biriyani", "1"},
+		{"panner", "2"},
+		{"palak panner", "2"},
+		{"chhole", "3"},
+		{"chole bhatura", "3"},
+		{"dosai", "4"},
+		{"idly sambhar", "5"},
+		{"daal tadka", "6"},
+		{"dum biryani", "1"},
+	}
+
+	idx := testIndex()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Act
+			matches := idx.Search(tt.query, 10)
+
+			// Assert
+			require.NotEmpty(t, matches)
+			assert.Equal(t, tt.expected, matches[0].ID)
+		})
+	}
+}
+
+func TestIndexSearch_RanksNamesAboveIngredients(t *testing.T) {
+	// Act
+	matches := testIndex().Search("dal", 10)
+
+	// Assert
+	require.Len(t, matches, 3)
+	assert.Equal(t, "6", matches[0].ID)
+	assert.Equal(t, FieldName, matches[0].Field)
+	assert.Equal(t, FieldIngredient, matches[1].Field)
+	assert.Equal(t, "urad dal", matches[1].Text)
+}
+
+func TestIndexSearch_NoMatch(t *testing.T) {
+	// Act & Assert
+	assert.Empty(t, testIndex().Search("pizza", 10))
+	assert.Empty(t, testIndex().Search("  ", 10))
+}
+
+func TestIndexComplete(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"bir", []string{"1"}},
+		{"chicken bir", []string{"1"}},
+		{"pan", []string{"2"}},
+		{"mas", []string{"4"}},
+		{"b", nil}, // too short to match as a prefix
+	}
+
+	idx := testIndex()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// Act
+			matches := idx.Complete(tt.query, 5)
+
+			// Assert
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, match.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
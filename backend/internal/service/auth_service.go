@@ -7,6 +7,7 @@ import (
 
 	"nourish-backend/internal/config"
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/repository"
 	"nourish-backend/pkg/logger"
 
@@ -70,14 +71,9 @@ func (s *authService) Register(ctx context.Context, req models.UserRegistrationR
 			DietaryPreferences: []string{},
 			SpiceLevel:         "medium",
 			FavoriteRegions:    []string{},
-			NutritionGoals: models.NutritionGoals{
-				DailyCalories: 2000,
-				Protein:       150,
-				Carbs:         250,
-				Fat:           65,
-				Fiber:         25,
-				Sodium:        2300,
-			},
+			TimeZone:           req.TimeZone,
+			NutritionGoals:     nutrition.DefaultGoals(),
+			GoalsSource:        models.GoalsSourceDefault,
 		},
 		Favorites: []primitive.ObjectID{},
 	}
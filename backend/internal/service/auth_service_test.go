@@ -79,6 +79,21 @@ func (m *MockUserRepository) RemoveFromFavorites(ctx context.Context, userID, di
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CountFavoritesByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, dishID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) RemoveDishFromAllFavorites(ctx context.Context, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, dishID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetHousehold(ctx context.Context, userID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	args := m.Called(ctx, userID, householdID)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -98,7 +113,8 @@ func TestAuthService_Register_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
 	cfg := &config.Config{
-		JWTSecret: "test-secret",
+		JWTSecret:    "test-secret",
+		JWTExpiresIn: time.Hour,
 	}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
@@ -113,6 +129,8 @@ func TestAuthService_Register_Success(t *testing.T) {
 	mockRepo.On("GetByEmail", mock.Anything, req.Email).Return(nil, mongo.ErrNoDocuments)
 	// Mock: Create user succeeds
 	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+	// Mock: Last login is recorded
+	mockRepo.On("UpdateLastLogin", mock.Anything, mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 
 	// Act
 	result, err := service.Register(context.Background(), req)
@@ -129,7 +147,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 func TestAuthService_Register_UserExists(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -153,14 +171,14 @@ func TestAuthService_Register_UserExists(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "user already exists")
+	assert.Contains(t, err.Error(), "an account with this email address already exists")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestAuthService_Login_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -183,6 +201,8 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 	// Mock: User exists
 	mockRepo.On("GetByEmail", mock.Anything, req.Email).Return(user, nil)
+	// Mock: Last login is recorded
+	mockRepo.On("UpdateLastLogin", mock.Anything, user.ID).Return(nil)
 
 	// Act
 	result, err := service.Login(context.Background(), req)
@@ -199,7 +219,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 func TestAuthService_Login_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -217,14 +237,14 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid credentials")
+	assert.Contains(t, err.Error(), "invalid email or password")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -250,13 +270,13 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid credentials")
+	assert.Contains(t, err.Error(), "invalid email or password")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestAuthService_GenerateToken_Success(t *testing.T) {
 	// Arrange
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(nil, cfg, log)
 
@@ -278,12 +298,12 @@ func TestAuthService_GenerateToken_Success(t *testing.T) {
 
 	claims, ok := parsedToken.Claims.(jwt.MapClaims)
 	assert.True(t, ok)
-	assert.Equal(t, userID.Hex(), claims["user_id"])
+	assert.Equal(t, userID.Hex(), claims["userId"])
 }
 
 func TestAuthService_ValidateToken_Success(t *testing.T) {
 	// Arrange
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(nil, cfg, log)
 
@@ -301,7 +321,7 @@ func TestAuthService_ValidateToken_Success(t *testing.T) {
 
 func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 	// Arrange
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(nil, cfg, log)
 
@@ -318,7 +338,7 @@ func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 func TestAuthService_GetUserFromToken_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -349,7 +369,7 @@ func TestAuthService_GetUserFromToken_Success(t *testing.T) {
 func TestAuthService_GetUserFromToken_InvalidToken(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
@@ -367,7 +387,7 @@ func TestAuthService_GetUserFromToken_InvalidToken(t *testing.T) {
 func TestAuthService_GetUserFromToken_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepository)
-	cfg := &config.Config{JWTSecret: "test-secret"}
+	cfg := &config.Config{JWTSecret: "test-secret", JWTExpiresIn: time.Hour}
 	log := logger.New("info", "json")
 	service := NewAuthService(mockRepo, cfg, log)
 
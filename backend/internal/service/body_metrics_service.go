@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
+	"nourish-backend/internal/repository"
+	"nourish-backend/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BodyMetricsService interface defines body measurement operations
+type BodyMetricsService interface {
+	Create(ctx context.Context, userID primitive.ObjectID, req models.BodyMeasurementRequest) (*models.BodyMeasurement, error)
+	GetByID(ctx context.Context, userID, id primitive.ObjectID) (*models.BodyMeasurement, error)
+	GetByDateRange(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) ([]*models.BodyMeasurement, error)
+	Update(ctx context.Context, userID, id primitive.ObjectID, req models.BodyMeasurementRequest) (*models.BodyMeasurement, error)
+	Delete(ctx context.Context, userID, id primitive.ObjectID) error
+	GetTrend(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.BodyTrendResponse, error)
+}
+
+// bodyMetricsService implements BodyMetricsService interface
+type bodyMetricsService struct {
+	bodyRepo repository.BodyMeasurementRepository
+	mealRepo repository.MealRepository
+	userRepo repository.UserRepository
+	goalRepo repository.GoalVersionRepository
+	logger   *logger.Logger
+}
+
+// NewBodyMetricsService creates a new body metrics service
+func NewBodyMetricsService(bodyRepo repository.BodyMeasurementRepository, mealRepo repository.MealRepository, userRepo repository.UserRepository, goalRepo repository.GoalVersionRepository, log *logger.Logger) BodyMetricsService {
+	return &bodyMetricsService{
+		bodyRepo: bodyRepo,
+		mealRepo: mealRepo,
+		userRepo: userRepo,
+		goalRepo: goalRepo,
+		logger:   log,
+	}
+}
+
+// Create logs a new body measurement
+func (s *bodyMetricsService) Create(ctx context.Context, userID primitive.ObjectID, req models.BodyMeasurementRequest) (*models.BodyMeasurement, error) {
+	measurement := &models.BodyMeasurement{
+		UserID:         userID,
+		Date:           req.Date.Time,
+		WeightKg:       req.WeightKg,
+		WaistCm:        req.WaistCm,
+		BodyFatPercent: req.BodyFatPercent,
+		Notes:          req.Notes,
+	}
+
+	if err := s.bodyRepo.Create(ctx, measurement); err != nil {
+		s.logger.Error("Failed to create body measurement", "error", err, "userID", userID.Hex())
+		return nil, errors.New("failed to create body measurement")
+	}
+
+	s.syncProfileWeight(ctx, userID)
+	return measurement, nil
+}
+
+// GetByID retrieves one of the user's body measurements
+func (s *bodyMetricsService) GetByID(ctx context.Context, userID, id primitive.ObjectID) (*models.BodyMeasurement, error) {
+	measurement, err := s.bodyRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("body measurement not found")
+		}
+		s.logger.Error("Failed to get body measurement", "error", err, "measurementID", id.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	// Other users' entries are reported as missing
+	if measurement.UserID != userID {
+		return nil, errors.New("body measurement not found")
+	}
+
+	return measurement, nil
+}
+
+// GetByDateRange retrieves the user's body measurements within a date range
+func (s *bodyMetricsService) GetByDateRange(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) ([]*models.BodyMeasurement, error) {
+	measurements, err := s.bodyRepo.GetByDateRange(ctx, userID, dateRange.From, dateRange.To)
+	if err != nil {
+		s.logger.Error("Failed to get body measurements", "error", err, "userID", userID.Hex())
+		return nil, errors.New("failed to get body measurements")
+	}
+
+	return measurements, nil
+}
+
+// Update updates one of the user's body measurements
+func (s *bodyMetricsService) Update(ctx context.Context, userID, id primitive.ObjectID, req models.BodyMeasurementRequest) (*models.BodyMeasurement, error) {
+	measurement, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	measurement.Date = req.Date.Time
+	measurement.WeightKg = req.WeightKg
+	measurement.WaistCm = req.WaistCm
+	measurement.BodyFatPercent = req.BodyFatPercent
+	measurement.Notes = req.Notes
+
+	if err := s.bodyRepo.Update(ctx, id, measurement); err != nil {
+		s.logger.Error("Failed to update body measurement", "error", err, "measurementID", id.Hex())
+		return nil, errors.New("failed to update body measurement")
+	}
+
+	s.syncProfileWeight(ctx, userID)
+	return measurement, nil
+}
+
+// Delete deletes one of the user's body measurements
+func (s *bodyMetricsService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.bodyRepo.Delete(ctx, id); err != nil {
+		s.logger.Error("Failed to delete body measurement", "error", err, "measurementID", id.Hex())
+		return errors.New("failed to delete body measurement")
+	}
+
+	s.syncProfileWeight(ctx, userID)
+	return nil
+}
+
+// GetTrend returns the smoothed weight trend for a date range and correlates it with
+// logged intake to estimate the user's real-world TDEE
+func (s *bodyMetricsService) GetTrend(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.BodyTrendResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		s.logger.Error("Failed to get user for body trend", "error", err, "userID", userID.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	measurements, err := s.GetByDateRange(ctx, userID, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.mealRepo.GetNutritionByDateRange(ctx, userID, dateRange.From, dateRange.To)
+	if err != nil {
+		s.logger.Error("Failed to get intake for body trend", "error", err, "userID", userID.Hex())
+		return nil, errors.New("failed to get nutrition data")
+	}
+
+	intake := make([]models.DailyNutrition, 0, len(summaries))
+	for _, summary := range summaries {
+		intake = append(intake, models.DailyNutrition{
+			Date:      summary.Date,
+			Calories:  summary.Calories,
+			MealCount: summary.MealCount,
+		})
+	}
+
+	weightGoal := models.WeightGoalMaintain
+	if user.Profile.BodyMetrics != nil {
+		weightGoal = user.Profile.BodyMetrics.Goal
+	}
+	goals := nutrition.GoalsForProfile(user.Profile)
+
+	trend := nutrition.WeightTrend(measurements)
+	return &models.BodyTrendResponse{
+		Range:         dateRange,
+		Measurements:  measurements,
+		WeightTrend:   trend,
+		EnergyBalance: nutrition.EstimateEnergyBalance(trend, intake, weightGoal, goals.DailyCalories),
+	}, nil
+}
+
+// syncProfileWeight keeps the profile's body metrics at the latest logged weight, so that
+// calculated goals follow the user's weight. Failures are logged but do not fail the request.
+func (s *bodyMetricsService) syncProfileWeight(ctx context.Context, userID primitive.ObjectID) {
+	latest, err := s.bodyRepo.GetLatest(ctx, userID)
+	if err != nil || latest.WeightKg == nil {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || user.Profile.BodyMetrics == nil || user.Profile.BodyMetrics.WeightKg == *latest.WeightKg {
+		return
+	}
+
+	previous := user.Profile
+	metrics := *user.Profile.BodyMetrics
+	metrics.WeightKg = *latest.WeightKg
+	user.Profile.BodyMetrics = &metrics
+	if err := syncCalculatedGoals(&user.Profile); err != nil {
+		s.logger.Error("Failed to recalculate goals from logged weight", "error", err, "userID", userID.Hex())
+		return
+	}
+	if err := s.userRepo.Update(ctx, userID, user); err != nil {
+		s.logger.Error("Failed to sync profile weight", "error", err, "userID", userID.Hex())
+		return
+	}
+	if err := saveGoalVersion(ctx, s.goalRepo, user, previous, today(user.Profile)); err != nil {
+		s.logger.Error("Failed to record goal history", "error", err, "userID", userID.Hex())
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/repository"
+	"nourish-backend/internal/search"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fuzzy search limits
+const (
+	searchIndexMaxAge    = 5 * time.Minute // picks up dishes changed by other instances
+	maxSearchCandidates  = 200             // best matches the viewer may see that are loaded and filtered
+	suggestionCandidates = 4               // fetch this many candidates per suggestion to allow for filtering
+)
+
+// dishSearchIndex holds the fuzzy search index over unarchived dishes. It is rebuilt from
+// the repository on the first search after a dish changes or the index grows old.
+type dishSearchIndex struct {
+	mu      sync.Mutex
+	indexed *indexedDishes
+	builtAt time.Time
+}
+
+// indexedDishes is one build of the fuzzy index with the dishes it was built from
+type indexedDishes struct {
+	index  *search.Index
+	dishes map[string]*models.Dish
+}
+
+// invalidate marks the index for rebuilding on the next search
+func (i *dishSearchIndex) invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.indexed = nil
+}
+
+// get returns the current index, rebuilding it when needed
+func (i *dishSearchIndex) get(ctx context.Context, dishRepo repository.DishRepository) (*indexedDishes, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.indexed != nil && time.Since(i.builtAt) < searchIndexMaxAge {
+		return i.indexed, nil
+	}
+
+	dishes, err := dishRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]search.Document, 0, len(dishes))
+	byID := make(map[string]*models.Dish, len(dishes))
+	for _, dish := range dishes {
+		if dish.ArchivedAt != nil {
+			continue
+		}
+		docs = append(docs, search.Document{
+			ID:          dish.ID.Hex(),
+			Name:        dish.Name,
+			Aliases:     dish.Aliases,
+			Ingredients: dish.Ingredients,
+		})
+		byID[dish.ID.Hex()] = dish
+	}
+	i.indexed = &indexedDishes{index: search.NewIndex(docs), dishes: byID}
+	i.builtAt = time.Now()
+	return i.indexed, nil
+}
+
+// search returns the best matches for the query, up to maxSearchCandidates, and whether
+// more matches the viewer may see were left out
+func (d *indexedDishes) search(query string, filter repository.DishFilter) ([]search.Match, bool) {
+	matches := d.visible(d.index.Search(query, d.index.Len()), filter, maxSearchCandidates+1)
+	if len(matches) > maxSearchCandidates {
+		return matches[:maxSearchCandidates], true
+	}
+	return matches, false
+}
+
+// complete returns the best type-ahead matches for the query, up to the limit
+func (d *indexedDishes) complete(query string, filter repository.DishFilter, limit int) []search.Match {
+	return d.visible(d.index.Complete(query, d.index.Len()), filter, limit)
+}
+
+// visible keeps the matches the filter's viewer may see and that avoid the excluded
+// allergens, up to the limit, so dishes the repository would drop never take a place
+// among the candidates. The repository applies the remaining filters.
+func (d *indexedDishes) visible(matches []search.Match, filter repository.DishFilter, limit int) []search.Match {
+	kept := matches[:0]
+	for _, match := range matches {
+		if len(kept) == limit {
+			break
+		}
+		dish := d.dishes[match.ID]
+		if dish == nil || !dish.VisibleTo(filter.Viewer) || len(dish.AllergensFor(filter.ExcludeAllergens)) > 0 {
+			continue
+		}
+		kept = append(kept, match)
+	}
+	return kept
+}
+
+// fuzzySearch ranks the dishes matching the filter with the fuzzy index. It returns nil
+// dishes when the index is unavailable or finds nothing, so the caller can fall back.
+// The total counts the ranked dishes; it is an estimate, as reported, when the query
+// matches more dishes than maxSearchCandidates.
+func (s *dishService) fuzzySearch(ctx context.Context, query string, filter repository.DishFilter, page, limit int) ([]*models.Dish, int64, bool, error) {
+	dishes, estimate, err := s.rankedSearch(ctx, query, filter)
+	if err != nil || dishes == nil {
+		return nil, 0, false, err
+	}
+
+	total := int64(len(dishes))
+	start := (page - 1) * limit
+	if start >= len(dishes) {
+		return []*models.Dish{}, total, estimate, nil
+	}
+	end := start + limit
+	if end > len(dishes) {
+		end = len(dishes)
+	}
+	return dishes[start:end], total, estimate, nil
+}
+
+// rankedSearch returns the dishes the fuzzy index matches that pass the filter, ranked or
+// in the filter's sort order, and whether matches were left out. Only the best
+// maxSearchCandidates matches the viewer may see are considered. It returns nil when the
+// index is unavailable or finds nothing.
+func (s *dishService) rankedSearch(ctx context.Context, query string, filter repository.DishFilter) ([]*models.Dish, bool, error) {
+	indexed, err := s.search.get(ctx, s.dishRepo)
+	if err != nil {
+		s.logger.Warn("Search index unavailable, using text search", "error", err)
+		return nil, false, nil
+	}
+
+	matches, truncated := indexed.search(query, filter)
+	dishes, err := s.rankedMatches(ctx, matches, filter)
+	if err != nil || len(dishes) == 0 {
+		return nil, false, err
+	}
+	models.SortDishes(dishes, filter.SortBy)
+	return dishes, truncated, nil
+}
+
+// rankedSortName names the order of a ranked search for its cursors
+func rankedSortName(sortBy string) string {
+	if sortBy == "" {
+		return models.DishSortRelevance
+	}
+	return sortBy
+}
+
+// GetFacets counts the dishes matching the query and filter by each facet. A query is
+// scoped to the fuzzy index's candidates, or to the $text index when it has none.
+func (s *dishService) GetFacets(ctx context.Context, query string, filter DishFilter, userID *primitive.ObjectID) (*models.DishFacets, error) {
+	repoFilter := filter.repositoryFilter(loadViewer(ctx, s.userRepo, userID))
+
+	if query != "" {
+		if indexed, err := s.search.get(ctx, s.dishRepo); err == nil {
+			matches, _ := indexed.search(query, repoFilter)
+			for _, match := range matches {
+				if id, err := primitive.ObjectIDFromHex(match.ID); err == nil {
+					repoFilter.IDs = append(repoFilter.IDs, id)
+				}
+			}
+		}
+		if repoFilter.IDs != nil {
+			query = ""
+		}
+	}
+
+	facets, err := s.dishRepo.Facets(ctx, query, repoFilter)
+	if err != nil {
+		s.logger.Error("Failed to count dish facets", "error", err, "query", query)
+		return nil, errors.New("failed to count dish facets")
+	}
+	return facets, nil
+}
+
+// rankedMatches loads the matched dishes that pass the filter, in match order
+func (s *dishService) rankedMatches(ctx context.Context, matches []search.Match, filter repository.DishFilter) ([]*models.Dish, error) {
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	ids := make([]primitive.ObjectID, 0, len(matches))
+	for _, match := range matches {
+		if id, err := primitive.ObjectIDFromHex(match.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	found, err := s.dishRepo.FindByIDs(ctx, ids, filter)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]*models.Dish, len(found))
+	for _, dish := range found {
+		byID[dish.ID] = dish
+	}
+
+	dishes := make([]*models.Dish, 0, len(found))
+	for _, id := range ids {
+		if dish, ok := byID[id]; ok {
+			dishes = append(dishes, dish)
+		}
+	}
+	return dishes, nil
+}
+
+// Autocomplete suggests dishes for a partly typed query, matching names, aliases and
+// ingredients by prefix and despite misspellings
+func (s *dishService) Autocomplete(ctx context.Context, query string, limit int, userID *primitive.ObjectID) ([]models.DishSuggestion, error) {
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	filter := repository.DishFilter{Viewer: viewer, ExcludeAllergens: viewerAllergies(viewer)}
+
+	suggestions := []models.DishSuggestion{}
+	indexed, err := s.search.get(ctx, s.dishRepo)
+	if err != nil {
+		s.logger.Warn("Search index unavailable, using text search", "error", err)
+	} else {
+		matches := indexed.complete(query, filter, limit*suggestionCandidates)
+		dishes, err := s.rankedMatches(ctx, matches, filter)
+		if err != nil {
+			s.logger.Error("Failed to load suggested dishes", "error", err, "query", query)
+			return nil, errors.New("failed to search dishes")
+		}
+
+		byID := make(map[string]search.Match, len(matches))
+		for _, match := range matches {
+			byID[match.ID] = match
+		}
+		for _, dish := range dishes {
+			if len(suggestions) == limit {
+				break
+			}
+			match := byID[dish.ID.Hex()]
+			suggestion := suggestionFor(dish, match.Score)
+			if match.Field != search.FieldName {
+				suggestion.Matched = match.Text
+			}
+			suggestions = append(suggestions, suggestion)
+		}
+		if len(suggestions) > 0 {
+			return suggestions, nil
+		}
+	}
+
+	// Fall back to the $text index
+	dishes, _, err := s.dishRepo.Search(ctx, query, filter, 1, limit)
+	if err != nil {
+		s.logger.Error("Failed to search dishes", "error", err, "query", query)
+		return nil, errors.New("failed to search dishes")
+	}
+	for _, dish := range dishes {
+		suggestions = append(suggestions, suggestionFor(dish, 0))
+	}
+	return suggestions, nil
+}
+
+// suggestionFor summarizes a dish as a suggestion
+func suggestionFor(dish *models.Dish, score float64) models.DishSuggestion {
+	return models.DishSuggestion{
+		ID:      dish.ID.Hex(),
+		Name:    dish.Name,
+		Type:    dish.Type,
+		Cuisine: dish.Cuisine,
+		Image:   dish.Image,
+		Score:   score,
+	}
+}
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"time"
 
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/repository"
 	"nourish-backend/pkg/logger"
 
@@ -15,53 +19,142 @@ import (
 // DishService interface defines dish operations
 type DishService interface {
 	GetByID(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.DishResponse, error)
+	GetCookMode(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.CookMode, error)
+	Scale(ctx context.Context, id primitive.ObjectID, servings int, units string, userID *primitive.ObjectID) (*models.ScaledDish, error)
+	Substitute(ctx context.Context, id primitive.ObjectID, goals []string, userID *primitive.ObjectID) (*models.SubstitutedDish, error)
+	Compare(ctx context.Context, ids []primitive.ObjectID, userID *primitive.ObjectID) (*models.DishComparison, error)
+	GetDietRules(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) ([]models.DietRuleResult, error)
 	GetAll(ctx context.Context, filter DishFilter, page, limit int, userID *primitive.ObjectID) ([]*models.DishResponse, *models.PaginationResponse, error)
+	List(ctx context.Context, query string, filter DishFilter, req models.CursorRequest, userID *primitive.ObjectID) ([]*models.DishResponse, *models.CursorPagination, error)
 	Search(ctx context.Context, query string, filter DishFilter, page, limit int, userID *primitive.ObjectID) ([]*models.DishResponse, *models.PaginationResponse, error)
+	Autocomplete(ctx context.Context, query string, limit int, userID *primitive.ObjectID) ([]models.DishSuggestion, error)
+	GetFacets(ctx context.Context, query string, filter DishFilter, userID *primitive.ObjectID) (*models.DishFacets, error)
+	GetSimilar(ctx context.Context, id primitive.ObjectID, filter DishFilter, limit int, userID *primitive.ObjectID) ([]*models.SimilarDish, error)
 	GetFavorites(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.DishResponse, *models.PaginationResponse, error)
-	Create(ctx context.Context, dish *models.Dish) error
-	Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish) error
-	Delete(ctx context.Context, id primitive.ObjectID) error
+	Create(ctx context.Context, dish *models.Dish, actor *models.User) error
+	Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish, actor *models.User) error
+	Patch(ctx context.Context, id primitive.ObjectID, patch models.DishPatchRequest, actor *models.User) (*models.Dish, error)
+	Delete(ctx context.Context, id primitive.ObjectID, policy string, actor *models.User) (*models.DishDeleteResult, error)
+	Submit(ctx context.Context, id primitive.ObjectID, actor *models.User) (*models.Dish, error)
+	GetPendingReviews(ctx context.Context, page, limit int) ([]*models.DishResponse, *models.PaginationResponse, error)
+	Review(ctx context.Context, id primitive.ObjectID, req models.DishReviewRequest, reviewer *models.User) (*models.Dish, error)
+	MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error
+	RefreshStats(ctx context.Context) error
+	RefreshNeighbors(ctx context.Context) error
 }
 
-// DishFilter represents filters for dish queries
+// DishFilter represents filters for dish queries. Multi-select fields match any of their
+// values; different fields must all match.
 type DishFilter struct {
-	Type        string
-	Cuisine     string
-	DietaryTags []string
-	SpiceLevel  string
-	MaxCalories int
-	MinCalories int
-	Ingredients []string
+	Type           []string
+	Cuisine        []string
+	DietaryTags    []string
+	SpiceLevel     []string
+	Difficulty     []string
+	MaxCalories    int
+	MinCalories    int
+	CalorieRanges  []string // keys of models.GetCalorieBuckets
+	PrepTimeRanges []string // keys of models.GetPrepTimeBuckets
+	Ingredients    []string
+	DietRules      []string // must comply with all of these rule sets
+
+	AllDietaryTags     []string
+	ExcludeDietaryTags []string
+	AllIngredients     []string
+	ExcludeIngredients []string
+	ExcludeDisliked    bool // also exclude the viewer's disliked ingredients
+
+	MaxPrepTime int
+	MaxCookTime int
+	MinProtein  int
+	MinFiber    int
+
+	// Macro ratio bounds as shares of calories, 0 for no bound
+	MinProteinShare float64
+	MaxCarbShare    float64
+	MaxFatShare     float64
+
+	MaxGlycemicLoad float64 // per serving
+
+	SortBy string // one of models.GetDishSorts
+}
+
+// repositoryFilter converts the filter for the repository, limited to what the viewer
+// may see and free of their allergens, and of their dislikes when asked
+func (f DishFilter) repositoryFilter(viewer *models.User) repository.DishFilter {
+	filter := repository.DishFilter{
+		Type:             f.Type,
+		Cuisine:          f.Cuisine,
+		DietaryTags:      f.DietaryTags,
+		SpiceLevel:       f.SpiceLevel,
+		Difficulty:       f.Difficulty,
+		MaxCalories:      f.MaxCalories,
+		MinCalories:      f.MinCalories,
+		CalorieRanges:    f.CalorieRanges,
+		PrepTimeRanges:   f.PrepTimeRanges,
+		Ingredients:      f.Ingredients,
+		DietRules:        f.DietRules,
+		MaxGlycemicLoad:  f.MaxGlycemicLoad,
+		Viewer:           viewer,
+		ExcludeAllergens: viewerAllergies(viewer),
+		SortBy:           f.SortBy,
+
+		AllDietaryTags:     f.AllDietaryTags,
+		ExcludeDietaryTags: f.ExcludeDietaryTags,
+		AllIngredients:     f.AllIngredients,
+		ExcludeIngredients: f.ExcludeIngredients,
+		MaxPrepTime:        f.MaxPrepTime,
+		MaxCookTime:        f.MaxCookTime,
+		MinProtein:         f.MinProtein,
+		MinFiber:           f.MinFiber,
+		MinProteinShare:    f.MinProteinShare,
+		MaxCarbShare:       f.MaxCarbShare,
+		MaxFatShare:        f.MaxFatShare,
+	}
+	if f.ExcludeDisliked && viewer != nil {
+		filter.ExcludeIngredients = append(append([]string{}, f.ExcludeIngredients...), viewer.Profile.DislikedIngredients...)
+	}
+	return filter
 }
 
 // dishService implements DishService interface
 type dishService struct {
-	dishRepo repository.DishRepository
-	userRepo repository.UserRepository
-	logger   *logger.Logger
+	dishRepo     repository.DishRepository
+	userRepo     repository.UserRepository
+	mealRepo     repository.MealRepository
+	mealPlanRepo repository.MealPlanRepository
+	validation   DishValidationService
+	search       *dishSearchIndex
+	neighbors    *dishNeighborIndex
+	logger       *logger.Logger
 }
 
 // NewDishService creates a new dish service
-func NewDishService(dishRepo repository.DishRepository, userRepo repository.UserRepository, log *logger.Logger) DishService {
+func NewDishService(dishRepo repository.DishRepository, userRepo repository.UserRepository, mealRepo repository.MealRepository, mealPlanRepo repository.MealPlanRepository, log *logger.Logger) DishService {
 	return &dishService{
-		dishRepo: dishRepo,
-		userRepo: userRepo,
-		logger:   log,
+		dishRepo:     dishRepo,
+		userRepo:     userRepo,
+		mealRepo:     mealRepo,
+		mealPlanRepo: mealPlanRepo,
+		validation:   NewDishValidationService(dishRepo, log),
+		search:       &dishSearchIndex{},
+		neighbors:    &dishNeighborIndex{},
+		logger:       log,
 	}
 }
 
-// GetByID retrieves a dish by ID with favorite status
+// GetByID retrieves a dish by ID with favorite status. Dishes the user may not see are reported as not found.
 func (s *dishService) GetByID(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.DishResponse, error) {
-	dish, err := s.dishRepo.GetByID(ctx, id)
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	dish, err := s.getVisibleTo(ctx, id, viewer)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("dish not found")
-		}
-		s.logger.Error("Failed to get dish by ID", "error", err, "dishID", id.Hex())
-		return nil, errors.New("internal server error")
+		return nil, err
 	}
 
 	dishResponse := dish.ToResponse()
+	if viewer != nil {
+		dishResponse.SuggestedSwaps = nutrition.SuggestSwaps(nutrition.DefaultCatalog(), dish, viewer.Profile.DietaryPreferences)
+	}
 
 	// Set favorite status if user is provided
 	if userID != nil {
@@ -74,18 +167,115 @@ func (s *dishService) GetByID(ctx context.Context, id primitive.ObjectID, userID
 	return &dishResponse, nil
 }
 
+// GetCookMode returns a dish's steps as a timeline for cooking along
+func (s *dishService) GetCookMode(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.CookMode, error) {
+	dish, err := s.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.BuildCookMode(dish)
+}
+
+// Scale returns a dish's ingredient quantities and nutrition recalculated for a number of servings
+func (s *dishService) Scale(ctx context.Context, id primitive.ObjectID, servings int, units string, userID *primitive.ObjectID) (*models.ScaledDish, error) {
+	dish, err := s.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nutrition.ScaleDish(nutrition.DefaultCatalog(), dish, servings, units)
+}
+
+// GetDietRules checks a dish's ingredients against each religious and traditional diet rule set
+func (s *dishService) GetDietRules(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) ([]models.DietRuleResult, error) {
+	dish, err := s.getVisible(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nutrition.EvaluateDietRules(nutrition.DefaultCatalog(), dish), nil
+}
+
+// Substitute returns a dish with the ingredients that conflict with the dietary goals swapped out.
+// Without goals, the user's own dietary preferences are used.
+func (s *dishService) Substitute(ctx context.Context, id primitive.ObjectID, goals []string, userID *primitive.ObjectID) (*models.SubstitutedDish, error) {
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	dish, err := s.getVisibleTo(ctx, id, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goals) == 0 && viewer != nil {
+		for _, preference := range viewer.Profile.DietaryPreferences {
+			if parsed, err := nutrition.ParseSubstitutionGoals(preference); err == nil {
+				goals = append(goals, parsed...)
+			}
+		}
+	}
+	if len(goals) == 0 {
+		return nil, errors.New("choose a dietary goal to substitute for")
+	}
+
+	return nutrition.SubstituteDish(nutrition.DefaultCatalog(), dish, goals), nil
+}
+
+// Compare lines up to models.MaxComparedDishes dishes side by side, in the order given, and
+// judges which is healthier against the user's goals tightened for their health conditions.
+// Anonymous users are judged against the default goals.
+func (s *dishService) Compare(ctx context.Context, ids []primitive.ObjectID, userID *primitive.ObjectID) (*models.DishComparison, error) {
+	var unique []primitive.ObjectID
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, errors.New("choose dishes to compare")
+	}
+	if len(unique) > models.MaxComparedDishes {
+		return nil, fmt.Errorf("compare at most %d dishes", models.MaxComparedDishes)
+	}
+
+	found, err := s.dishRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		s.logger.Error("Failed to get dishes to compare", "error", err)
+		return nil, errors.New("failed to compare dishes")
+	}
+	byID := make(map[primitive.ObjectID]*models.Dish, len(found))
+	for _, dish := range found {
+		byID[dish.ID] = dish
+	}
+
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	dishes := make([]*models.Dish, len(unique))
+	for i, id := range unique {
+		dish, ok := byID[id]
+		if !ok || !dish.VisibleTo(viewer) {
+			return nil, errors.New("dish not found")
+		}
+		dishes[i] = dish
+	}
+
+	var goals models.NutritionGoals
+	if viewer != nil {
+		goals = viewer.Profile.NutritionGoals
+		if len(viewer.Profile.HealthConditions) > 0 {
+			limits := nutrition.ConditionLimitsFor(viewer.Profile.HealthConditions)
+			goals = nutrition.ApplyConditionLimits(nutrition.WithDefaults(goals), limits)
+		}
+	}
+
+	return nutrition.CompareDishes(nutrition.DefaultCatalog(), dishes, goals), nil
+}
+
 // GetAll retrieves dishes with pagination and filtering
 func (s *dishService) GetAll(ctx context.Context, filter DishFilter, page, limit int, userID *primitive.ObjectID) ([]*models.DishResponse, *models.PaginationResponse, error) {
 	// Convert service filter to repository filter
-	repoFilter := repository.DishFilter{
-		Type:        filter.Type,
-		Cuisine:     filter.Cuisine,
-		DietaryTags: filter.DietaryTags,
-		SpiceLevel:  filter.SpiceLevel,
-		MaxCalories: filter.MaxCalories,
-		MinCalories: filter.MinCalories,
-		Ingredients: filter.Ingredients,
-	}
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	repoFilter := filter.repositoryFilter(viewer)
 
 	dishes, total, err := s.dishRepo.GetAll(ctx, repoFilter, page, limit)
 	if err != nil {
@@ -93,21 +283,7 @@ func (s *dishService) GetAll(ctx context.Context, filter DishFilter, page, limit
 		return nil, nil, errors.New("failed to get dishes")
 	}
 
-	// Get user favorites if user is provided
-	var favorites []primitive.ObjectID
-	if userID != nil {
-		favorites, _ = s.userRepo.GetFavorites(ctx, *userID)
-	}
-
-	// Convert to response format
-	dishResponses := make([]*models.DishResponse, len(dishes))
-	for i, dish := range dishes {
-		dishResponse := dish.ToResponse()
-		if userID != nil {
-			dishResponse.IsFavorite = s.isDishInFavorites(dish.ID, favorites)
-		}
-		dishResponses[i] = &dishResponse
-	}
+	dishResponses := s.toResponses(ctx, dishes, userID)
 
 	// Create pagination response
 	totalPages := int(total) / limit
@@ -130,37 +306,21 @@ func (s *dishService) GetAll(ctx context.Context, filter DishFilter, page, limit
 // Search searches dishes with text search and filtering
 func (s *dishService) Search(ctx context.Context, query string, filter DishFilter, page, limit int, userID *primitive.ObjectID) ([]*models.DishResponse, *models.PaginationResponse, error) {
 	// Convert service filter to repository filter
-	repoFilter := repository.DishFilter{
-		Type:        filter.Type,
-		Cuisine:     filter.Cuisine,
-		DietaryTags: filter.DietaryTags,
-		SpiceLevel:  filter.SpiceLevel,
-		MaxCalories: filter.MaxCalories,
-		MinCalories: filter.MinCalories,
-		Ingredients: filter.Ingredients,
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	repoFilter := filter.repositoryFilter(viewer)
+
+	// Rank with the fuzzy index, falling back to the $text index when it finds nothing
+	dishes, total, estimate, err := s.fuzzySearch(ctx, query, repoFilter, page, limit)
+	if err != nil || dishes == nil {
+		estimate = false
+		dishes, total, err = s.dishRepo.Search(ctx, query, repoFilter, page, limit)
 	}
-
-	dishes, total, err := s.dishRepo.Search(ctx, query, repoFilter, page, limit)
 	if err != nil {
 		s.logger.Error("Failed to search dishes", "error", err, "query", query)
 		return nil, nil, errors.New("failed to search dishes")
 	}
 
-	// Get user favorites if user is provided
-	var favorites []primitive.ObjectID
-	if userID != nil {
-		favorites, _ = s.userRepo.GetFavorites(ctx, *userID)
-	}
-
-	// Convert to response format
-	dishResponses := make([]*models.DishResponse, len(dishes))
-	for i, dish := range dishes {
-		dishResponse := dish.ToResponse()
-		if userID != nil {
-			dishResponse.IsFavorite = s.isDishInFavorites(dish.ID, favorites)
-		}
-		dishResponses[i] = &dishResponse
-	}
+	dishResponses := s.toResponses(ctx, dishes, userID)
 
 	// Create pagination response
 	totalPages := int(total) / limit
@@ -175,11 +335,75 @@ func (s *dishService) Search(ctx context.Context, query string, filter DishFilte
 		TotalPages: totalPages,
 		HasNext:    page < totalPages,
 		HasPrev:    page > 1,
+
+		TotalIsEstimate: estimate,
 	}
 
 	return dishResponses, pagination, nil
 }
 
+// List pages through the dishes matching the query and filter with cursors. Searches the
+// fuzzy index can rank are paged through that ranking; everything else is keyed on the
+// sort order in the repository.
+func (s *dishService) List(ctx context.Context, query string, filter DishFilter, req models.CursorRequest, userID *primitive.ObjectID) ([]*models.DishResponse, *models.CursorPagination, error) {
+	cursor, err := models.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+	repoFilter := filter.repositoryFilter(loadViewer(ctx, s.userRepo, userID))
+
+	var dishes []*models.Dish
+	var page models.CursorPage
+	var total *int
+	var estimate bool
+	if query != "" && (cursor == nil || cursor.Ranked) {
+		ranked, truncated, err := s.rankedSearch(ctx, query, repoFilter)
+		if err != nil {
+			s.logger.Error("Failed to search dishes", "error", err, "query", query)
+			return nil, nil, errors.New("failed to search dishes")
+		}
+		if ranked != nil {
+			sortName := rankedSortName(filter.SortBy)
+			if cursor != nil && cursor.Sort != sortName {
+				return nil, nil, models.ErrInvalidCursor
+			}
+			start, end, rankedPage := models.OffsetPage(sortName, len(ranked), cursor, req.Limit)
+			dishes, page = ranked[start:end], rankedPage
+			if req.IncludeTotal {
+				count := len(ranked)
+				total, estimate = &count, truncated
+			}
+		} else if cursor != nil {
+			// The ranking the cursor points into is gone
+			return nil, nil, models.ErrInvalidCursor
+		}
+	}
+
+	if dishes == nil {
+		dishes, page, err = s.dishRepo.ListPage(ctx, query, repoFilter, cursor, req.Limit)
+		if errors.Is(err, models.ErrInvalidCursor) {
+			return nil, nil, err
+		}
+		if err != nil {
+			s.logger.Error("Failed to list dishes", "error", err, "query", query)
+			return nil, nil, errors.New("failed to get dishes")
+		}
+		if req.IncludeTotal {
+			count, err := s.dishRepo.CountMatching(ctx, query, repoFilter)
+			if err != nil {
+				s.logger.Error("Failed to count dishes", "error", err, "query", query)
+				return nil, nil, errors.New("failed to get dishes")
+			}
+			n := int(count)
+			total = &n
+		}
+	}
+
+	pagination := models.NewCursorPagination(req.Limit, page, total)
+	pagination.TotalIsEstimate = estimate
+	return s.toResponses(ctx, dishes, userID), pagination, nil
+}
+
 // GetFavorites retrieves user's favorite dishes
 func (s *dishService) GetFavorites(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.DishResponse, *models.PaginationResponse, error) {
 	// Get user's favorite dish IDs
@@ -200,8 +424,32 @@ func (s *dishService) GetFavorites(ctx context.Context, userID primitive.ObjectI
 		}, nil
 	}
 
+	// Get dishes by IDs
+	dishes, err := s.dishRepo.GetByIDs(ctx, favoriteIDs)
+	if err != nil {
+		s.logger.Error("Failed to get favorite dishes", "error", err, "userID", userID.Hex())
+		return nil, nil, errors.New("failed to get favorite dishes")
+	}
+
+	// Leave out favorites that are no longer shared with the user before paginating,
+	// so the totals only count dishes the user can see
+	viewer := loadViewer(ctx, s.userRepo, &userID)
+	byID := make(map[primitive.ObjectID]*models.Dish, len(dishes))
+	for _, dish := range dishes {
+		if dish.VisibleTo(viewer) {
+			byID[dish.ID] = dish
+		}
+	}
+	visible := make([]*models.Dish, 0, len(byID))
+	for _, id := range favoriteIDs {
+		if dish, ok := byID[id]; ok {
+			visible = append(visible, dish)
+			delete(byID, id)
+		}
+	}
+
 	// Calculate pagination for favorites
-	total := len(favoriteIDs)
+	total := len(visible)
 	start := (page - 1) * limit
 	end := start + limit
 	if end > total {
@@ -211,21 +459,12 @@ func (s *dishService) GetFavorites(ctx context.Context, userID primitive.ObjectI
 		start = total
 	}
 
-	paginatedIDs := favoriteIDs[start:end]
-
-	// Get dishes by IDs
-	dishes, err := s.dishRepo.GetByIDs(ctx, paginatedIDs)
-	if err != nil {
-		s.logger.Error("Failed to get favorite dishes", "error", err, "userID", userID.Hex())
-		return nil, nil, errors.New("failed to get favorite dishes")
-	}
-
 	// Convert to response format
-	dishResponses := make([]*models.DishResponse, len(dishes))
-	for i, dish := range dishes {
+	dishResponses := make([]*models.DishResponse, 0, end-start)
+	for _, dish := range visible[start:end] {
 		dishResponse := dish.ToResponse()
 		dishResponse.IsFavorite = true // All dishes here are favorites
-		dishResponses[i] = &dishResponse
+		dishResponses = append(dishResponses, &dishResponse)
 	}
 
 	// Create pagination response
@@ -246,56 +485,379 @@ func (s *dishService) GetFavorites(ctx context.Context, userID primitive.ObjectI
 	return dishResponses, pagination, nil
 }
 
-// Create creates a new dish
-func (s *dishService) Create(ctx context.Context, dish *models.Dish) error {
+// Create creates a new dish owned by the actor. Dishes are private unless another visibility
+// is requested; admins' dishes default to public.
+func (s *dishService) Create(ctx context.Context, dish *models.Dish, actor *models.User) error {
+	if actor == nil {
+		return errors.New("not authorized to modify this dish")
+	}
+
+	visibility := dish.Visibility
+	if visibility == "" && actor.IsAdmin() {
+		visibility = models.VisibilityPublic
+	}
+	dish.CreatedBy = &actor.ID
+	dish.Visibility = models.VisibilityPrivate
+	if err := dish.SetVisibility(visibility, actor, time.Now()); err != nil {
+		return err
+	}
+
+	if err := nutrition.ApplyDishNutrition(nutrition.DefaultCatalog(), dish, time.Now()); err != nil {
+		return err
+	}
+	nutrition.ApplyIngredientFacts(nutrition.DefaultCatalog(), dish, time.Now())
+	if err := s.validation.Validate(dish); err != nil {
+		return err
+	}
+
 	if err := s.dishRepo.Create(ctx, dish); err != nil {
 		s.logger.Error("Failed to create dish", "error", err)
 		return errors.New("failed to create dish")
 	}
+	s.search.invalidate()
 
 	return nil
 }
 
-// Update updates a dish
-func (s *dishService) Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish) error {
-	// Check if dish exists
-	_, err := s.dishRepo.GetByID(ctx, id)
+// Update replaces a dish's editable fields. Only the dish's creator or an admin may update it.
+func (s *dishService) Update(ctx context.Context, id primitive.ObjectID, dish *models.Dish, actor *models.User) error {
+	existing, err := s.getForModification(ctx, id, actor)
+	if err != nil {
+		return err
+	}
+
+	visibility := dish.Visibility
+	dish.ID = existing.ID
+	dish.CreatedBy = existing.CreatedBy
+	dish.ArchivedAt = existing.ArchivedAt
+	dish.CreatedAt = existing.CreatedAt
+	dish.Visibility = existing.Visibility
+	dish.HouseholdID = existing.HouseholdID
+	dish.Review = existing.Review
+	dish.Stats = existing.Stats
+	return s.save(ctx, dish, visibility, actor)
+}
+
+// Patch changes the fields present in the patch. Only the dish's creator or an admin may patch it.
+func (s *dishService) Patch(ctx context.Context, id primitive.ObjectID, patch models.DishPatchRequest, actor *models.User) (*models.Dish, error) {
+	dish, err := s.getForModification(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(dish)
+	visibility := ""
+	if patch.Visibility != nil {
+		visibility = *patch.Visibility
+	}
+	if err := s.save(ctx, dish, visibility, actor); err != nil {
+		return nil, err
+	}
+	return dish, nil
+}
+
+// Delete removes a dish according to the delete policy. Referenced dishes are refused
+// under restrict, hidden under archive, and removed with their references under cascade.
+func (s *dishService) Delete(ctx context.Context, id primitive.ObjectID, policy string, actor *models.User) (*models.DishDeleteResult, error) {
+	if policy == "" {
+		policy = models.DeletePolicyRestrict
+	}
+	if policy == models.DeletePolicyCascade && (actor == nil || !actor.IsAdmin()) {
+		return nil, errors.New("only admins can cascade dish deletion")
+	}
+
+	if _, err := s.getForModification(ctx, id, actor); err != nil {
+		return nil, err
+	}
+
+	refs, err := s.references(ctx, id)
+	if err != nil {
+		s.logger.Error("Failed to count dish references", "error", err, "dishID", id.Hex())
+		return nil, errors.New("internal server error")
+	}
+	result := &models.DishDeleteResult{Policy: policy, References: refs}
+
+	switch policy {
+	case models.DeletePolicyRestrict:
+		if refs.Total() > 0 {
+			return nil, &models.DishInUseError{References: refs}
+		}
+	case models.DeletePolicyArchive:
+		if err := s.dishRepo.Archive(ctx, id); err != nil {
+			s.logger.Error("Failed to archive dish", "error", err, "dishID", id.Hex())
+			return nil, errors.New("failed to delete dish")
+		}
+		s.search.invalidate()
+		result.Archived = true
+		return result, nil
+	case models.DeletePolicyCascade:
+		if err := s.removeReferences(ctx, id); err != nil {
+			s.logger.Error("Failed to remove dish references", "error", err, "dishID", id.Hex())
+			return nil, errors.New("failed to delete dish")
+		}
+	default:
+		return nil, errors.New("invalid delete policy")
+	}
+
+	if err := s.dishRepo.Delete(ctx, id); err != nil {
+		s.logger.Error("Failed to delete dish", "error", err, "dishID", id.Hex())
+		return nil, errors.New("failed to delete dish")
+	}
+	s.search.invalidate()
+
+	return result, nil
+}
+
+// Submit asks for the actor's dish to be added to the public catalog
+func (s *dishService) Submit(ctx context.Context, id primitive.ObjectID, actor *models.User) (*models.Dish, error) {
+	dish, err := s.getForModification(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dish.SetVisibility(models.VisibilityPublic, actor, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.dishRepo.Update(ctx, id, dish); err != nil {
+		s.logger.Error("Failed to submit dish for review", "error", err, "dishID", id.Hex())
+		return nil, errors.New("failed to update dish")
+	}
+	s.search.invalidate()
+
+	return dish, nil
+}
+
+// GetPendingReviews lists the dishes awaiting review, oldest submission first
+func (s *dishService) GetPendingReviews(ctx context.Context, page, limit int) ([]*models.DishResponse, *models.PaginationResponse, error) {
+	dishes, total, err := s.dishRepo.GetByReviewStatus(ctx, models.ReviewPending, page, limit)
+	if err != nil {
+		s.logger.Error("Failed to get dishes awaiting review", "error", err)
+		return nil, nil, errors.New("failed to get dishes")
+	}
+
+	dishResponses := make([]*models.DishResponse, len(dishes))
+	for i, dish := range dishes {
+		dishResponse := dish.ToResponse()
+		dishResponses[i] = &dishResponse
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	pagination := &models.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+
+	return dishResponses, pagination, nil
+}
+
+// Review records an admin's decision on a dish awaiting review
+func (s *dishService) Review(ctx context.Context, id primitive.ObjectID, req models.DishReviewRequest, reviewer *models.User) (*models.Dish, error) {
+	if reviewer == nil || !reviewer.IsAdmin() {
+		return nil, errors.New("only admins can review dishes")
+	}
+
+	dish, err := s.dishRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return errors.New("dish not found")
+			return nil, errors.New("dish not found")
 		}
-		s.logger.Error("Failed to get dish for update", "error", err, "dishID", id.Hex())
-		return errors.New("internal server error")
+		s.logger.Error("Failed to get dish for review", "error", err, "dishID", id.Hex())
+		return nil, errors.New("internal server error")
 	}
 
+	if err := dish.ApplyReview(req, reviewer.ID, time.Now()); err != nil {
+		return nil, err
+	}
 	if err := s.dishRepo.Update(ctx, id, dish); err != nil {
-		s.logger.Error("Failed to update dish", "error", err, "dishID", id.Hex())
-		return errors.New("failed to update dish")
+		s.logger.Error("Failed to save dish review", "error", err, "dishID", id.Hex())
+		return nil, errors.New("failed to update dish")
+	}
+	s.search.invalidate()
+
+	return dish, nil
+}
+
+// MoveHouseholdDishes moves the owner's household dishes to their new household, or makes
+// them private when householdID is nil
+func (s *dishService) MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	if err := s.dishRepo.MoveHouseholdDishes(ctx, ownerID, householdID); err != nil {
+		s.logger.Error("Failed to move household dishes", "error", err, "userID", ownerID.Hex())
+		return errors.New("failed to update household")
+	}
+	s.search.invalidate()
+
+	return nil
+}
+
+// RefreshStats recounts how often each dish is logged and how it is rated, for the
+// popularity and rating sorts
+func (s *dishService) RefreshStats(ctx context.Context) error {
+	usage, err := s.mealRepo.GetDishUsage(ctx)
+	if err != nil {
+		s.logger.Error("Failed to count dish usage", "error", err)
+		return errors.New("failed to refresh dish stats")
 	}
 
+	now := time.Now()
+	stats := make(map[primitive.ObjectID]models.DishStats, len(usage))
+	for _, u := range usage {
+		dishStats := models.DishStats{TimesLogged: u.TimesLogged, RatingCount: u.RatingCount, UpdatedAt: now}
+		if u.RatingCount > 0 {
+			dishStats.AvgRating = math.Round(float64(u.RatingSum)/float64(u.RatingCount)*100) / 100
+		}
+		stats[u.DishID] = dishStats
+	}
+
+	if err := s.dishRepo.UpdateStats(ctx, stats); err != nil {
+		s.logger.Error("Failed to update dish stats", "error", err)
+		return errors.New("failed to refresh dish stats")
+	}
 	return nil
 }
 
-// Delete deletes a dish
-func (s *dishService) Delete(ctx context.Context, id primitive.ObjectID) error {
-	// Check if dish exists
-	_, err := s.dishRepo.GetByID(ctx, id)
+// getVisible loads a dish, reporting dishes the user may not see as not found
+func (s *dishService) getVisible(ctx context.Context, id primitive.ObjectID, userID *primitive.ObjectID) (*models.Dish, error) {
+	return s.getVisibleTo(ctx, id, loadViewer(ctx, s.userRepo, userID))
+}
+
+// getVisibleTo loads a dish the already-loaded viewer may see
+func (s *dishService) getVisibleTo(ctx context.Context, id primitive.ObjectID, viewer *models.User) (*models.Dish, error) {
+	dish, err := s.dishRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return errors.New("dish not found")
+			return nil, errors.New("dish not found")
 		}
-		s.logger.Error("Failed to get dish for deletion", "error", err, "dishID", id.Hex())
-		return errors.New("internal server error")
+		s.logger.Error("Failed to get dish by ID", "error", err, "dishID", id.Hex())
+		return nil, errors.New("internal server error")
 	}
 
-	if err := s.dishRepo.Delete(ctx, id); err != nil {
-		s.logger.Error("Failed to delete dish", "error", err, "dishID", id.Hex())
-		return errors.New("failed to delete dish")
+	if !dish.VisibleTo(viewer) {
+		return nil, errors.New("dish not found")
 	}
+	return dish, nil
+}
+
+// getForModification loads a dish and checks the actor may change it
+func (s *dishService) getForModification(ctx context.Context, id primitive.ObjectID, actor *models.User) (*models.Dish, error) {
+	dish, err := s.dishRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("dish not found")
+		}
+		s.logger.Error("Failed to get dish for modification", "error", err, "dishID", id.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	// Dishes the actor cannot see are not found, so their existence is not revealed
+	if !dish.VisibleTo(actor) {
+		return nil, errors.New("dish not found")
+	}
+	if !dish.CanBeModifiedBy(actor) {
+		return nil, errors.New("not authorized to modify this dish")
+	}
+	return dish, nil
+}
+
+// save applies the requested visibility, recalculates nutrition, validates and stores an
+// existing dish. A user's edits to a published dish are sent back for review.
+func (s *dishService) save(ctx context.Context, dish *models.Dish, visibility string, actor *models.User) error {
+	now := time.Now()
+	dish.ReviewAfterEdit(actor, now)
+	if err := dish.SetVisibility(visibility, actor, now); err != nil {
+		return err
+	}
+
+	if err := nutrition.ApplyDishNutrition(nutrition.DefaultCatalog(), dish, now); err != nil {
+		return err
+	}
+	nutrition.ApplyIngredientFacts(nutrition.DefaultCatalog(), dish, now)
+	if err := s.validation.Validate(dish); err != nil {
+		return err
+	}
+
+	if err := s.dishRepo.Update(ctx, dish.ID, dish); err != nil {
+		s.logger.Error("Failed to update dish", "error", err, "dishID", dish.ID.Hex())
+		return errors.New("failed to update dish")
+	}
+	s.search.invalidate()
 
 	return nil
 }
 
+// references counts the meals, meal plans and favorites that refer to a dish
+func (s *dishService) references(ctx context.Context, id primitive.ObjectID) (models.DishReferences, error) {
+	var refs models.DishReferences
+	var err error
+
+	if refs.Meals, err = s.mealRepo.CountByDishID(ctx, id); err != nil {
+		return refs, err
+	}
+	if refs.MealPlans, err = s.mealPlanRepo.CountByDishID(ctx, id); err != nil {
+		return refs, err
+	}
+	if refs.Favorites, err = s.userRepo.CountFavoritesByDishID(ctx, id); err != nil {
+		return refs, err
+	}
+	return refs, nil
+}
+
+// removeReferences deletes the meals that log a dish and removes it from plans and favorites
+func (s *dishService) removeReferences(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := s.mealRepo.DeleteByDishID(ctx, id); err != nil {
+		return err
+	}
+	if err := s.mealPlanRepo.RemoveDish(ctx, id); err != nil {
+		return err
+	}
+	return s.userRepo.RemoveDishFromAllFavorites(ctx, id)
+}
+
+// loadViewer loads the user a dish query is made for, or nil when anonymous or unknown
+func loadViewer(ctx context.Context, userRepo repository.UserRepository, userID *primitive.ObjectID) *models.User {
+	if userID == nil {
+		return nil
+	}
+	user, err := userRepo.GetByID(ctx, *userID)
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// viewerAllergies returns the allergies of a signed-in viewer; dishes containing them are never listed
+func viewerAllergies(viewer *models.User) []string {
+	if viewer == nil {
+		return nil
+	}
+	return viewer.Profile.Allergies
+}
+
+// toResponses converts dishes to responses, marking the user's favorites
+func (s *dishService) toResponses(ctx context.Context, dishes []*models.Dish, userID *primitive.ObjectID) []*models.DishResponse {
+	var favorites []primitive.ObjectID
+	if userID != nil {
+		favorites, _ = s.userRepo.GetFavorites(ctx, *userID)
+	}
+
+	dishResponses := make([]*models.DishResponse, len(dishes))
+	for i, dish := range dishes {
+		dishResponse := dish.ToResponse()
+		if userID != nil {
+			dishResponse.IsFavorite = s.isDishInFavorites(dish.ID, favorites)
+		}
+		dishResponses[i] = &dishResponse
+	}
+	return dishResponses
+}
+
 // isDishInFavorites checks if a dish ID is in the favorites list
 func (s *dishService) isDishInFavorites(dishID primitive.ObjectID, favorites []primitive.ObjectID) bool {
 	for _, fav := range favorites {
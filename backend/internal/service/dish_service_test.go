@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"nourish-backend/internal/models"
 	"nourish-backend/internal/repository"
@@ -71,12 +72,126 @@ func (m *MockDishRepository) GetByIDs(ctx context.Context, ids []primitive.Objec
 	return args.Get(0).([]*models.Dish), args.Error(1)
 }
 
+func (m *MockDishRepository) Archive(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Mock MealPlanRepository
+type MockMealPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockMealPlanRepository) Create(ctx context.Context, mealPlan *models.MealPlan) error {
+	args := m.Called(ctx, mealPlan)
+	return args.Error(0)
+}
+
+func (m *MockMealPlanRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.MealPlan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MealPlan), args.Error(1)
+}
+
+func (m *MockMealPlanRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.MealPlan, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*models.MealPlan), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMealPlanRepository) Update(ctx context.Context, id primitive.ObjectID, mealPlan *models.MealPlan) error {
+	args := m.Called(ctx, id, mealPlan)
+	return args.Error(0)
+}
+
+func (m *MockMealPlanRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMealPlanRepository) GetActivePlans(ctx context.Context, userID primitive.ObjectID) ([]*models.MealPlan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MealPlan), args.Error(1)
+}
+
+func (m *MockMealPlanRepository) CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, dishID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMealPlanRepository) RemoveDish(ctx context.Context, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, dishID)
+	return args.Error(0)
+}
+
+func (m *MockDishRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID, filter repository.DishFilter) ([]*models.Dish, error) {
+	args := m.Called(ctx, ids, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Dish), args.Error(1)
+}
+
+func (m *MockDishRepository) Facets(ctx context.Context, query string, filter repository.DishFilter) (*models.DishFacets, error) {
+	args := m.Called(ctx, query, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DishFacets), args.Error(1)
+}
+
+func (m *MockDishRepository) ListAll(ctx context.Context) ([]*models.Dish, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Dish), args.Error(1)
+}
+
+func (m *MockDishRepository) GetByReviewStatus(ctx context.Context, status string, page, limit int) ([]*models.Dish, int64, error) {
+	args := m.Called(ctx, status, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*models.Dish), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDishRepository) MoveHouseholdDishes(ctx context.Context, ownerID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	args := m.Called(ctx, ownerID, householdID)
+	return args.Error(0)
+}
+
+func (m *MockDishRepository) ListPage(ctx context.Context, query string, filter repository.DishFilter, cursor *models.PageCursor, limit int) ([]*models.Dish, models.CursorPage, error) {
+	args := m.Called(ctx, query, filter, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(models.CursorPage), args.Error(2)
+	}
+	return args.Get(0).([]*models.Dish), args.Get(1).(models.CursorPage), args.Error(2)
+}
+
+func (m *MockDishRepository) CountMatching(ctx context.Context, query string, filter repository.DishFilter) (int64, error) {
+	args := m.Called(ctx, query, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDishRepository) UpdateStats(ctx context.Context, stats map[primitive.ObjectID]models.DishStats) error {
+	args := m.Called(ctx, stats)
+	return args.Error(0)
+}
+
 func TestDishService_GetByID_Success(t *testing.T) {
 	// Arrange
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishID := primitive.NewObjectID()
 	userID := primitive.NewObjectID()
@@ -89,6 +204,7 @@ func TestDishService_GetByID_Success(t *testing.T) {
 	}
 
 	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(dish, nil)
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
 	mockUserRepo.On("GetFavorites", mock.Anything, userID).Return([]primitive.ObjectID{dishID}, nil)
 
 	// Act
@@ -109,7 +225,7 @@ func TestDishService_GetByID_DishNotFound(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishID := primitive.NewObjectID()
 
@@ -130,7 +246,7 @@ func TestDishService_GetByID_WithoutUser(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishID := primitive.NewObjectID()
 	dish := &models.Dish{
@@ -161,7 +277,7 @@ func TestDishService_GetAll_Success(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishes := []*models.Dish{
 		{
@@ -178,7 +294,7 @@ func TestDishService_GetAll_Success(t *testing.T) {
 		},
 	}
 
-	filter := DishFilter{Type: "Veg"}
+	filter := DishFilter{Type: []string{"Veg"}}
 	page := 1
 	limit := 10
 
@@ -203,7 +319,7 @@ func TestDishService_Search_Success(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishes := []*models.Dish{
 		{
@@ -218,7 +334,8 @@ func TestDishService_Search_Success(t *testing.T) {
 	page := 1
 	limit := 10
 
-	mockDishRepo.On("Search", mock.Anything, query, page, limit).Return(dishes, int64(1), nil)
+	mockDishRepo.On("ListAll", mock.Anything).Return([]*models.Dish{}, nil) // empty fuzzy index, so $text is used
+	mockDishRepo.On("Search", mock.Anything, query, mock.AnythingOfType("repository.DishFilter"), page, limit).Return(dishes, int64(1), nil)
 
 	// Act
 	result, paginationResult, err := service.Search(context.Background(), query, filter, page, limit, nil)
@@ -232,12 +349,109 @@ func TestDishService_Search_Success(t *testing.T) {
 	mockDishRepo.AssertExpectations(t)
 }
 
+func TestDishService_Search_SkipsHiddenCandidates(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	// More hidden matches than the candidate limit, all ranked above the public dish
+	ownerID := primitive.NewObjectID()
+	indexed := []*models.Dish{}
+	for i := 0; i < maxSearchCandidates; i++ {
+		indexed = append(indexed, &models.Dish{ID: primitive.NewObjectID(), Name: "Paneer", Visibility: models.VisibilityPrivate, CreatedBy: &ownerID})
+	}
+	archivedAt := time.Now()
+	indexed = append(indexed, &models.Dish{ID: primitive.NewObjectID(), Name: "Paneer", Visibility: models.VisibilityPublic, ArchivedAt: &archivedAt})
+	public := &models.Dish{ID: primitive.NewObjectID(), Name: "Paneer Tikka", Visibility: models.VisibilityPublic}
+	indexed = append(indexed, public)
+
+	mockDishRepo.On("ListAll", mock.Anything).Return(indexed, nil)
+	mockDishRepo.On("FindByIDs", mock.Anything, []primitive.ObjectID{public.ID}, mock.AnythingOfType("repository.DishFilter")).Return([]*models.Dish{public}, nil)
+
+	// Act
+	result, pagination, err := service.Search(context.Background(), "paneer", DishFilter{}, 1, 10, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Paneer Tikka", result[0].Name)
+	assert.Equal(t, 1, pagination.Total)
+	assert.False(t, pagination.TotalIsEstimate)
+	mockDishRepo.AssertExpectations(t)
+}
+
+func TestDishService_Search_ReportsEstimatedTotal(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	// More visible matches than the candidate limit
+	indexed := []*models.Dish{}
+	for i := 0; i <= maxSearchCandidates; i++ {
+		indexed = append(indexed, &models.Dish{ID: primitive.NewObjectID(), Name: "Paneer", Visibility: models.VisibilityPublic})
+	}
+
+	mockDishRepo.On("ListAll", mock.Anything).Return(indexed, nil)
+	mockDishRepo.On("FindByIDs", mock.Anything, mock.AnythingOfType("[]primitive.ObjectID"), mock.AnythingOfType("repository.DishFilter")).Return(indexed, nil)
+
+	// Act
+	result, pagination, err := service.Search(context.Background(), "paneer", DishFilter{}, 1, 10, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 10)
+	assert.Equal(t, maxSearchCandidates, pagination.Total)
+	assert.True(t, pagination.TotalIsEstimate)
+	mockDishRepo.AssertExpectations(t)
+}
+
+func TestDishService_Review_RefreshesSearchIndex(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	ownerID, dishID := primitive.NewObjectID(), primitive.NewObjectID()
+	pending := func() *models.Dish {
+		return &models.Dish{ID: dishID, Name: "Paneer Tikka", Visibility: models.VisibilityPrivate, CreatedBy: &ownerID,
+			Review: &models.DishReview{Status: models.ReviewPending}}
+	}
+	approved := pending()
+	approved.Visibility = models.VisibilityPublic
+
+	mockDishRepo.On("ListAll", mock.Anything).Return([]*models.Dish{pending()}, nil).Once()
+	mockDishRepo.On("ListAll", mock.Anything).Return([]*models.Dish{approved}, nil).Once()
+	mockDishRepo.On("Search", mock.Anything, "paneer", mock.AnythingOfType("repository.DishFilter"), 1, 10).Return([]*models.Dish{}, int64(0), nil).Once()
+	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(pending(), nil)
+	mockDishRepo.On("Update", mock.Anything, dishID, mock.AnythingOfType("*models.Dish")).Return(nil)
+	mockDishRepo.On("FindByIDs", mock.Anything, []primitive.ObjectID{dishID}, mock.AnythingOfType("repository.DishFilter")).Return([]*models.Dish{approved}, nil)
+
+	before, _, err := service.Search(context.Background(), "paneer", DishFilter{}, 1, 10, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, before)
+
+	// Act
+	_, err = service.Review(context.Background(), dishID, models.DishReviewRequest{Decision: models.ReviewDecisionApprove}, &models.User{ID: primitive.NewObjectID(), Role: models.RoleAdmin})
+	assert.NoError(t, err)
+	after, _, err := service.Search(context.Background(), "paneer", DishFilter{}, 1, 10, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, after, 1)
+	mockDishRepo.AssertExpectations(t)
+}
+
 func TestDishService_GetFavorites_Success(t *testing.T) {
 	// Arrange
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	userID := primitive.NewObjectID()
 	dishID1 := primitive.NewObjectID()
@@ -259,6 +473,7 @@ func TestDishService_GetFavorites_Success(t *testing.T) {
 	limit := 10
 
 	mockUserRepo.On("GetFavorites", mock.Anything, userID).Return(favoriteIDs, nil)
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
 	mockDishRepo.On("GetByIDs", mock.Anything, favoriteIDs).Return(dishes, nil)
 
 	// Act
@@ -275,26 +490,117 @@ func TestDishService_GetFavorites_Success(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestDishService_GetFavorites_CountsVisibleDishes(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	userID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+	public1 := &models.Dish{ID: primitive.NewObjectID(), Name: "Dal Makhani", Visibility: models.VisibilityPublic}
+	hidden := &models.Dish{ID: primitive.NewObjectID(), Name: "Made Private", Visibility: models.VisibilityPrivate, CreatedBy: &otherID}
+	public2 := &models.Dish{ID: primitive.NewObjectID(), Name: "Aloo Gobi", Visibility: models.VisibilityPublic}
+	favoriteIDs := []primitive.ObjectID{public1.ID, hidden.ID, public2.ID}
+
+	mockUserRepo.On("GetFavorites", mock.Anything, userID).Return(favoriteIDs, nil)
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
+	mockDishRepo.On("GetByIDs", mock.Anything, favoriteIDs).Return([]*models.Dish{public2, hidden, public1}, nil)
+
+	// Act
+	result, pagination, err := service.GetFavorites(context.Background(), userID, 2, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Aloo Gobi", result[0].Name)
+	assert.Equal(t, 2, pagination.Total)
+	assert.Equal(t, 2, pagination.TotalPages)
+	assert.False(t, pagination.HasNext)
+}
+
 func TestDishService_Create_Success(t *testing.T) {
 	// Arrange
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dish := &models.Dish{
-		Name:    "New Dish",
-		Type:    "Veg",
-		Cuisine: "Indian",
+		Name:        "New Dish",
+		Type:        "Veg",
+		Cuisine:     "Indian",
+		Ingredients: []string{"paneer", "spinach"},
+		Servings:    2,
+		Calories:    260,
+		Nutrition:   models.Nutrition{Protein: 12, Carbs: 8, Fat: 20},
 	}
 
+	user := &models.User{ID: primitive.NewObjectID()}
+
 	mockDishRepo.On("Create", mock.Anything, dish).Return(nil)
 
 	// Act
-	err := service.Create(context.Background(), dish)
+	err := service.Create(context.Background(), dish, user)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &user.ID, dish.CreatedBy)
+	assert.Equal(t, models.VisibilityPrivate, dish.Visibility)
+	mockDishRepo.AssertExpectations(t)
+}
+
+func TestDishService_GetByID_HidesPrivateDish(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	ownerID := primitive.NewObjectID()
+	viewerID := primitive.NewObjectID()
+	dishID := primitive.NewObjectID()
+	dish := &models.Dish{ID: dishID, Name: "Family Rasam", CreatedBy: &ownerID, Visibility: models.VisibilityPrivate}
+
+	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(dish, nil)
+	mockUserRepo.On("GetByID", mock.Anything, viewerID).Return(&models.User{ID: viewerID}, nil)
+
+	// Act
+	result, err := service.GetByID(context.Background(), dishID, &viewerID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "dish not found", err.Error())
+}
+
+func TestDishService_Review_ApprovesPendingDish(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, new(MockUserRepositoryForUserService), new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	ownerID := primitive.NewObjectID()
+	dishID := primitive.NewObjectID()
+	admin := &models.User{ID: primitive.NewObjectID(), Role: models.RoleAdmin}
+	dish := &models.Dish{
+		ID:         dishID,
+		CreatedBy:  &ownerID,
+		Visibility: models.VisibilityPrivate,
+		Review:     &models.DishReview{Status: models.ReviewPending},
+	}
+
+	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(dish, nil)
+	mockDishRepo.On("Update", mock.Anything, dishID, dish).Return(nil)
+
+	// Act
+	result, err := service.Review(context.Background(), dishID, models.DishReviewRequest{Decision: models.ReviewDecisionApprove}, admin)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, models.VisibilityPublic, result.Visibility)
+	assert.Equal(t, models.ReviewApproved, result.Review.Status)
 	mockDishRepo.AssertExpectations(t)
 }
 
@@ -303,25 +609,36 @@ func TestDishService_Update_Success(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
+	owner := &models.User{ID: primitive.NewObjectID()}
 	dishID := primitive.NewObjectID()
 	existingDish := &models.Dish{
-		ID:   dishID,
-		Name: "Old Name",
+		ID:        dishID,
+		Name:      "Old Name",
+		CreatedBy: &owner.ID,
 	}
 	updatedDish := &models.Dish{
-		Name: "New Name",
+		Name:        "New Name",
+		Type:        "Veg",
+		Cuisine:     "North Indian",
+		Image:       "dish.jpg",
+		Ingredients: []string{"paneer"},
+		Calories:    300,
+		Nutrition:   models.Nutrition{Protein: 15, Carbs: 20, Fat: 18},
+		Servings:    2,
 	}
 
 	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(existingDish, nil)
 	mockDishRepo.On("Update", mock.Anything, dishID, updatedDish).Return(nil)
 
 	// Act
-	err := service.Update(context.Background(), dishID, updatedDish)
+	err := service.Update(context.Background(), dishID, updatedDish, owner)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, dishID, updatedDish.ID)
+	assert.Equal(t, &owner.ID, updatedDish.CreatedBy)
 	mockDishRepo.AssertExpectations(t)
 }
 
@@ -330,7 +647,7 @@ func TestDishService_Update_DishNotFound(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishID := primitive.NewObjectID()
 	updatedDish := &models.Dish{
@@ -340,7 +657,7 @@ func TestDishService_Update_DishNotFound(t *testing.T) {
 	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(nil, mongo.ErrNoDocuments)
 
 	// Act
-	err := service.Update(context.Background(), dishID, updatedDish)
+	err := service.Update(context.Background(), dishID, updatedDish, &models.User{Role: models.RoleAdmin})
 
 	// Assert
 	assert.Error(t, err)
@@ -349,27 +666,74 @@ func TestDishService_Update_DishNotFound(t *testing.T) {
 	mockDishRepo.AssertNotCalled(t, "Update")
 }
 
+func TestDishService_Update_NotCreator(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	creatorID := primitive.NewObjectID()
+	dishID := primitive.NewObjectID()
+	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(&models.Dish{ID: dishID, CreatedBy: &creatorID}, nil)
+
+	// Act
+	err := service.Update(context.Background(), dishID, &models.Dish{Name: "New Name"}, &models.User{ID: primitive.NewObjectID()})
+
+	// Assert
+	assert.EqualError(t, err, "not authorized to modify this dish")
+	mockDishRepo.AssertNotCalled(t, "Update")
+}
+
+func TestDishService_Update_HiddenDish(t *testing.T) {
+	// Arrange
+	mockDishRepo := new(MockDishRepository)
+	mockUserRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
+
+	creatorID := primitive.NewObjectID()
+	dishID := primitive.NewObjectID()
+	privateDish := &models.Dish{ID: dishID, CreatedBy: &creatorID, Visibility: models.VisibilityPrivate}
+	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(privateDish, nil)
+
+	// Act
+	err := service.Update(context.Background(), dishID, &models.Dish{Name: "New Name"}, &models.User{ID: primitive.NewObjectID()})
+
+	// Assert
+	assert.EqualError(t, err, "dish not found")
+	mockDishRepo.AssertNotCalled(t, "Update")
+}
+
 func TestDishService_Delete_Success(t *testing.T) {
 	// Arrange
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
+	mockMealRepo := new(MockMealRepository)
+	mockMealPlanRepo := new(MockMealPlanRepository)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, mockMealRepo, mockMealPlanRepo, log)
 
+	owner := &models.User{ID: primitive.NewObjectID()}
 	dishID := primitive.NewObjectID()
 	existingDish := &models.Dish{
-		ID:   dishID,
-		Name: "Dish to Delete",
+		ID:        dishID,
+		Name:      "Dish to Delete",
+		CreatedBy: &owner.ID,
 	}
 
 	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(existingDish, nil)
+	mockMealRepo.On("CountByDishID", mock.Anything, dishID).Return(int64(0), nil)
+	mockMealPlanRepo.On("CountByDishID", mock.Anything, dishID).Return(int64(0), nil)
+	mockUserRepo.On("CountFavoritesByDishID", mock.Anything, dishID).Return(int64(0), nil)
 	mockDishRepo.On("Delete", mock.Anything, dishID).Return(nil)
 
 	// Act
-	err := service.Delete(context.Background(), dishID)
+	result, err := service.Delete(context.Background(), dishID, models.DeletePolicyRestrict, owner)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.False(t, result.Archived)
 	mockDishRepo.AssertExpectations(t)
 }
 
@@ -378,14 +742,14 @@ func TestDishService_Delete_DishNotFound(t *testing.T) {
 	mockDishRepo := new(MockDishRepository)
 	mockUserRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewDishService(mockDishRepo, mockUserRepo, log)
+	service := NewDishService(mockDishRepo, mockUserRepo, new(MockMealRepository), new(MockMealPlanRepository), log)
 
 	dishID := primitive.NewObjectID()
 
 	mockDishRepo.On("GetByID", mock.Anything, dishID).Return(nil, mongo.ErrNoDocuments)
 
 	// Act
-	err := service.Delete(context.Background(), dishID)
+	_, err := service.Delete(context.Background(), dishID, models.DeletePolicyRestrict, &models.User{Role: models.RoleAdmin})
 
 	// Assert
 	assert.Error(t, err)
@@ -393,3 +757,64 @@ func TestDishService_Delete_DishNotFound(t *testing.T) {
 	mockDishRepo.AssertExpectations(t)
 	mockDishRepo.AssertNotCalled(t, "Delete")
 }
+
+func TestDishService_Delete_Policies(t *testing.T) {
+	admin := &models.User{ID: primitive.NewObjectID(), Role: models.RoleAdmin}
+	user := &models.User{ID: primitive.NewObjectID()}
+
+	tests := []struct {
+		name        string
+		policy      string
+		actor       *models.User
+		expectErr   string
+		expectCalls []string
+	}{
+		{"restrict refuses referenced dish", models.DeletePolicyRestrict, admin, "dish is referenced by meals, meal plans or favorites", nil},
+		{"archive keeps the dish", models.DeletePolicyArchive, admin, "", []string{"Archive"}},
+		{"cascade removes references", models.DeletePolicyCascade, admin, "", []string{"DeleteByDishID", "RemoveDish", "RemoveDishFromAllFavorites", "Delete"}},
+		{"cascade needs admin", models.DeletePolicyCascade, user, "only admins can cascade dish deletion", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockDishRepo := new(MockDishRepository)
+			mockUserRepo := new(MockUserRepositoryForUserService)
+			mockMealRepo := new(MockMealRepository)
+			mockMealPlanRepo := new(MockMealPlanRepository)
+			service := NewDishService(mockDishRepo, mockUserRepo, mockMealRepo, mockMealPlanRepo, logger.New("info", "json"))
+
+			dishID := primitive.NewObjectID()
+			mockDishRepo.On("GetByID", mock.Anything, dishID).Return(&models.Dish{ID: dishID, CreatedBy: &user.ID}, nil)
+			mockMealRepo.On("CountByDishID", mock.Anything, dishID).Return(int64(3), nil)
+			mockMealPlanRepo.On("CountByDishID", mock.Anything, dishID).Return(int64(1), nil)
+			mockUserRepo.On("CountFavoritesByDishID", mock.Anything, dishID).Return(int64(2), nil)
+			mockDishRepo.On("Archive", mock.Anything, dishID).Return(nil)
+			mockDishRepo.On("Delete", mock.Anything, dishID).Return(nil)
+			mockMealRepo.On("DeleteByDishID", mock.Anything, dishID).Return(int64(3), nil)
+			mockMealPlanRepo.On("RemoveDish", mock.Anything, dishID).Return(nil)
+			mockUserRepo.On("RemoveDishFromAllFavorites", mock.Anything, dishID).Return(nil)
+
+			// Act
+			result, err := service.Delete(context.Background(), dishID, tt.policy, tt.actor)
+
+			// Assert
+			if tt.expectErr != "" {
+				assert.EqualError(t, err, tt.expectErr)
+				mockDishRepo.AssertNotCalled(t, "Delete", mock.Anything, dishID)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, models.DishReferences{Meals: 3, MealPlans: 1, Favorites: 2}, result.References)
+			for _, call := range tt.expectCalls {
+				called := false
+				for _, m := range []*mock.Mock{&mockDishRepo.Mock, &mockMealRepo.Mock, &mockMealPlanRepo.Mock, &mockUserRepo.Mock} {
+					for _, c := range m.Calls {
+						called = called || c.Method == call
+					}
+				}
+				assert.True(t, called, call)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
+	"nourish-backend/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxStoredNeighbors is how many neighbors are kept per dish, enough to fill a page once
+// the viewer's filters drop some
+const maxStoredNeighbors = 50
+
+// dishNeighbors is one build of the neighbor lists
+type dishNeighbors struct {
+	profiles []nutrition.DishProfile
+	byDish   map[primitive.ObjectID][]nutrition.DishNeighbor
+}
+
+// dishNeighborIndex holds every dish's most similar dishes. A background job rebuilds it;
+// the first request builds it when the job has not run yet.
+type dishNeighborIndex struct {
+	mu        sync.Mutex
+	neighbors *dishNeighbors
+}
+
+// get returns the current neighbor lists, building them when needed
+func (i *dishNeighborIndex) get(ctx context.Context, dishRepo repository.DishRepository) (*dishNeighbors, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.neighbors != nil {
+		return i.neighbors, nil
+	}
+
+	neighbors, err := buildDishNeighbors(ctx, dishRepo)
+	if err != nil {
+		return nil, err
+	}
+	i.neighbors = neighbors
+	return neighbors, nil
+}
+
+// rebuild replaces the neighbor lists, serving the old ones while the new are built
+func (i *dishNeighborIndex) rebuild(ctx context.Context, dishRepo repository.DishRepository) error {
+	neighbors, err := buildDishNeighbors(ctx, dishRepo)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.neighbors = neighbors
+	return nil
+}
+
+// buildDishNeighbors ranks every unarchived dish against every other
+func buildDishNeighbors(ctx context.Context, dishRepo repository.DishRepository) (*dishNeighbors, error) {
+	dishes, err := dishRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := nutrition.DefaultCatalog()
+	profiles := make([]nutrition.DishProfile, 0, len(dishes))
+	for _, dish := range dishes {
+		if dish.ArchivedAt == nil {
+			profiles = append(profiles, nutrition.NewDishProfile(catalog, dish))
+		}
+	}
+
+	byDish := make(map[primitive.ObjectID][]nutrition.DishNeighbor, len(profiles))
+	for _, profile := range profiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		byDish[profile.ID] = nutrition.SimilarDishes(profile, profiles, maxStoredNeighbors)
+	}
+	return &dishNeighbors{profiles: profiles, byDish: byDish}, nil
+}
+
+// RefreshNeighbors recomputes the similar dishes of every dish
+func (s *dishService) RefreshNeighbors(ctx context.Context) error {
+	if err := s.neighbors.rebuild(ctx, s.dishRepo); err != nil {
+		s.logger.Error("Failed to compute similar dishes", "error", err)
+		return errors.New("failed to refresh similar dishes")
+	}
+	return nil
+}
+
+// GetSimilar returns the dishes most like a dish, best first, that the viewer may see and
+// that pass the filter, e.g. leaving out an ingredient the viewer has run out of. Dishes
+// added since the last refresh are ranked on the spot.
+func (s *dishService) GetSimilar(ctx context.Context, id primitive.ObjectID, filter DishFilter, limit int, userID *primitive.ObjectID) ([]*models.SimilarDish, error) {
+	viewer := loadViewer(ctx, s.userRepo, userID)
+	dish, err := s.getVisibleTo(ctx, id, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := s.neighbors.get(ctx, s.dishRepo)
+	if err != nil {
+		s.logger.Error("Failed to compute similar dishes", "error", err, "dishID", id.Hex())
+		return nil, errors.New("failed to find similar dishes")
+	}
+	ranked, ok := index.byDish[id]
+	if !ok {
+		profile := nutrition.NewDishProfile(nutrition.DefaultCatalog(), dish)
+		ranked = nutrition.SimilarDishes(profile, index.profiles, maxStoredNeighbors)
+	}
+	if len(ranked) == 0 {
+		return []*models.SimilarDish{}, nil
+	}
+
+	ids := make([]primitive.ObjectID, len(ranked))
+	for i, neighbor := range ranked {
+		ids[i] = neighbor.ID
+	}
+	found, err := s.dishRepo.FindByIDs(ctx, ids, filter.repositoryFilter(viewer))
+	if err != nil {
+		s.logger.Error("Failed to load similar dishes", "error", err, "dishID", id.Hex())
+		return nil, errors.New("failed to find similar dishes")
+	}
+	byID := make(map[primitive.ObjectID]*models.Dish, len(found))
+	for _, candidate := range found {
+		byID[candidate.ID] = candidate
+	}
+
+	var dishes []*models.Dish
+	var neighbors []nutrition.DishNeighbor
+	for _, neighbor := range ranked {
+		if len(dishes) == limit {
+			break
+		}
+		if candidate, ok := byID[neighbor.ID]; ok {
+			dishes = append(dishes, candidate)
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	responses := s.toResponses(ctx, dishes, userID)
+	similar := make([]*models.SimilarDish, len(responses))
+	for i, response := range responses {
+		similar[i] = &models.SimilarDish{
+			Dish:              response,
+			Similarity:        neighbors[i].Similarity,
+			SharedIngredients: neighbors[i].SharedIngredients,
+		}
+	}
+	return similar, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"nourish-backend/internal/models"
+	"nourish-backend/internal/repository"
+	"nourish-backend/pkg/logger"
+)
+
+// DishValidationService interface defines dish data-quality operations
+type DishValidationService interface {
+	Validate(dish *models.Dish) error
+	CatalogReport(ctx context.Context) (*models.CatalogReport, error)
+}
+
+// dishValidationService implements DishValidationService interface
+type dishValidationService struct {
+	dishRepo repository.DishRepository
+	logger   *logger.Logger
+}
+
+// NewDishValidationService creates a new dish validation service
+func NewDishValidationService(dishRepo repository.DishRepository, log *logger.Logger) DishValidationService {
+	return &dishValidationService{
+		dishRepo: dishRepo,
+		logger:   log,
+	}
+}
+
+// Validate checks a dish before it is saved. Errors are returned as a
+// *models.DishValidationError; warnings are attached to the dish.
+func (s *dishValidationService) Validate(dish *models.Dish) error {
+	validation := models.ValidateDish(dish)
+	dish.Warnings = validation.Warnings
+	if !validation.Valid() {
+		return &models.DishValidationError{Issues: validation.Errors}
+	}
+	return nil
+}
+
+// CatalogReport scans every dish and reports data-quality issues
+func (s *dishValidationService) CatalogReport(ctx context.Context) (*models.CatalogReport, error) {
+	dishes, err := s.dishRepo.ListAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list dishes for catalog report", "error", err)
+		return nil, errors.New("failed to build catalog report")
+	}
+
+	report := models.BuildCatalogReport(dishes, time.Now())
+	return &report, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"nourish-backend/pkg/logger"
+)
+
+// Background job intervals
+const (
+	dishStatsInterval     = 15 * time.Minute
+	dishNeighborsInterval = time.Hour // ranks every dish against every other
+)
+
+// StartBackgroundJobs runs the periodic jobs until ctx is cancelled
+func (s *Services) StartBackgroundJobs(ctx context.Context, log *logger.Logger) {
+	go runPeriodically(ctx, "dish stats", dishStatsInterval, s.Dish.RefreshStats, log)
+	go runPeriodically(ctx, "dish neighbors", dishNeighborsInterval, s.Dish.RefreshNeighbors, log)
+}
+
+// runPeriodically runs a job straight away and then at every interval until ctx is cancelled
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func(context.Context) error, log *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			log.Warn("Background job failed", "job", name, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
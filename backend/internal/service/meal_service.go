@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/repository"
 	"nourish-backend/pkg/logger"
 
@@ -20,6 +22,7 @@ type MealService interface {
 	Create(ctx context.Context, userID primitive.ObjectID, req models.MealRequest) (*models.MealWithDish, error)
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.MealWithDish, error)
 	GetByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.MealWithDish, *models.PaginationResponse, error)
+	ListByUserID(ctx context.Context, userID primitive.ObjectID, req models.CursorRequest) ([]*models.MealWithDish, *models.CursorPagination, error)
 	GetByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.MealWithDish, error)
 	Update(ctx context.Context, id primitive.ObjectID, req models.MealRequest) (*models.MealWithDish, error)
 	Delete(ctx context.Context, id primitive.ObjectID) error
@@ -32,10 +35,11 @@ type MealService interface {
 	// Undo a soft-delete using a token
 	UndoByToken(ctx context.Context, token string) error
 	GetNutritionSummary(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]repository.NutritionSummary, error)
-	GetAnalytics(ctx context.Context, userID primitive.ObjectID, period int) (*models.AnalyticsResponse, error)
+	GetMicronutrientIntake(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.MicronutrientIntake, error)
+	GetAnalytics(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions) (*models.AnalyticsResponse, error)
 	GetShoppingList(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) (*models.ShoppingListResponse, error)
 	GetRecommendations(ctx context.Context, userID primitive.ObjectID, mealType string, date time.Time) (*models.RecommendationsResponse, error)
-	GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, period int) (*models.NutritionProgressResponse, error)
+	GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions, goals models.GoalTimeline) (*models.NutritionProgressResponse, error)
 	GetNutritionGoals(ctx context.Context, userID primitive.ObjectID) (*models.NutritionGoals, error)
 	UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, req models.NutritionGoalsRequest) (*models.NutritionGoals, error)
 }
@@ -46,14 +50,16 @@ type mealService struct {
 	dishRepo repository.DishRepository
 	logger   *logger.Logger
 	undoRepo repository.UndoRepository
+	userRepo repository.UserRepository
 }
 
 // NewMealService creates a new meal service
-func NewMealService(mealRepo repository.MealRepository, dishRepo repository.DishRepository, undoRepo repository.UndoRepository, log *logger.Logger) MealService {
+func NewMealService(mealRepo repository.MealRepository, dishRepo repository.DishRepository, undoRepo repository.UndoRepository, userRepo repository.UserRepository, log *logger.Logger) MealService {
 	return &mealService{
 		mealRepo: mealRepo,
 		dishRepo: dishRepo,
 		undoRepo: undoRepo,
+		userRepo: userRepo,
 		logger:   log,
 	}
 }
@@ -137,14 +143,11 @@ func (s *mealService) Create(ctx context.Context, userID primitive.ObjectID, req
 		return nil, errors.New("invalid dish ID")
 	}
 
-	// Check if dish exists
-	dish, err := s.dishRepo.GetByID(ctx, dishID)
+	// Check if dish exists and is shared with the user
+	viewer := loadViewer(ctx, s.userRepo, &userID)
+	dish, err := s.getVisibleDish(ctx, dishID, viewer)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("dish not found")
-		}
-		s.logger.Error("Failed to get dish", "error", err, "dishID", req.DishID)
-		return nil, errors.New("internal server error")
+		return nil, err
 	}
 
 	// Create meal
@@ -162,7 +165,7 @@ func (s *mealService) Create(ctx context.Context, userID primitive.ObjectID, req
 		return nil, errors.New("failed to create meal")
 	}
 
-	// Return meal with dish info
+	// Return meal with dish info, warning when the dish contains the user's allergens
 	return &models.MealWithDish{
 		ID:        meal.ID.Hex(),
 		Date:      meal.Date,
@@ -172,6 +175,7 @@ func (s *mealService) Create(ctx context.Context, userID primitive.ObjectID, req
 		Notes:     meal.Notes,
 		Rating:    meal.Rating,
 		CreatedAt: meal.CreatedAt,
+		Warnings:  allergenWarnings(dish, viewer),
 	}, nil
 }
 
@@ -213,26 +217,7 @@ func (s *mealService) GetByUserID(ctx context.Context, userID primitive.ObjectID
 		return nil, nil, errors.New("failed to get meals")
 	}
 
-	// Get dish information for each meal
-	mealsWithDish := make([]*models.MealWithDish, len(meals))
-	for i, meal := range meals {
-		dish, err := s.dishRepo.GetByID(ctx, meal.DishID)
-		if err != nil {
-			s.logger.Error("Failed to get dish for meal", "error", err, "dishID", meal.DishID.Hex())
-			continue
-		}
-
-		mealsWithDish[i] = &models.MealWithDish{
-			ID:        meal.ID.Hex(),
-			Date:      meal.Date,
-			MealType:  meal.MealType,
-			Dish:      dish.ToResponse(),
-			User:      meal.UserID.Hex(),
-			Notes:     meal.Notes,
-			Rating:    meal.Rating,
-			CreatedAt: meal.CreatedAt,
-		}
-	}
+	mealsWithDish := s.withDishes(ctx, meals)
 
 	// Create pagination response
 	totalPages := int(total) / limit
@@ -252,6 +237,60 @@ func (s *mealService) GetByUserID(ctx context.Context, userID primitive.ObjectID
 	return mealsWithDish, pagination, nil
 }
 
+// ListByUserID pages through a user's meals with cursors, newest first
+func (s *mealService) ListByUserID(ctx context.Context, userID primitive.ObjectID, req models.CursorRequest) ([]*models.MealWithDish, *models.CursorPagination, error) {
+	cursor, err := models.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meals, page, err := s.mealRepo.ListPageByUserID(ctx, userID, cursor, req.Limit)
+	if errors.Is(err, models.ErrInvalidCursor) {
+		return nil, nil, err
+	}
+	if err != nil {
+		s.logger.Error("Failed to list meals by user ID", "error", err, "userID", userID.Hex())
+		return nil, nil, errors.New("failed to get meals")
+	}
+
+	var total *int
+	if req.IncludeTotal {
+		count, err := s.mealRepo.CountByUserID(ctx, userID)
+		if err != nil {
+			s.logger.Error("Failed to count meals by user ID", "error", err, "userID", userID.Hex())
+			return nil, nil, errors.New("failed to get meals")
+		}
+		n := int(count)
+		total = &n
+	}
+
+	return s.withDishes(ctx, meals), models.NewCursorPagination(req.Limit, page, total), nil
+}
+
+// withDishes attaches each meal's dish, leaving out meals whose dish cannot be loaded
+func (s *mealService) withDishes(ctx context.Context, meals []*models.Meal) []*models.MealWithDish {
+	mealsWithDish := make([]*models.MealWithDish, 0, len(meals))
+	for _, meal := range meals {
+		dish, err := s.dishRepo.GetByID(ctx, meal.DishID)
+		if err != nil {
+			s.logger.Error("Failed to get dish for meal", "error", err, "dishID", meal.DishID.Hex())
+			continue
+		}
+
+		mealsWithDish = append(mealsWithDish, &models.MealWithDish{
+			ID:        meal.ID.Hex(),
+			Date:      meal.Date,
+			MealType:  meal.MealType,
+			Dish:      dish.ToResponse(),
+			User:      meal.UserID.Hex(),
+			Notes:     meal.Notes,
+			Rating:    meal.Rating,
+			CreatedAt: meal.CreatedAt,
+		})
+	}
+	return mealsWithDish
+}
+
 // GetByDateRange retrieves meals for a user within a date range
 func (s *mealService) GetByDateRange(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time) ([]*models.MealWithDish, error) {
 	meals, err := s.mealRepo.GetByUserAndDateRange(ctx, userID, startDate, endDate)
@@ -302,14 +341,21 @@ func (s *mealService) Update(ctx context.Context, id primitive.ObjectID, req mod
 		return nil, errors.New("invalid dish ID")
 	}
 
-	// Check if dish exists
-	dish, err := s.dishRepo.GetByID(ctx, dishID)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("dish not found")
+	// Check if dish exists. A new dish must be shared with the user; the dish already
+	// logged stays usable even if its owner has since made it private.
+	viewer := loadViewer(ctx, s.userRepo, &existingMeal.UserID)
+	var dish *models.Dish
+	if dishID == existingMeal.DishID {
+		dish, err = s.dishRepo.GetByID(ctx, dishID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, errors.New("dish not found")
+			}
+			s.logger.Error("Failed to get dish", "error", err, "dishID", req.DishID)
+			return nil, errors.New("internal server error")
 		}
-		s.logger.Error("Failed to get dish", "error", err, "dishID", req.DishID)
-		return nil, errors.New("internal server error")
+	} else if dish, err = s.getVisibleDish(ctx, dishID, viewer); err != nil {
+		return nil, err
 	}
 
 	// Update meal
@@ -334,6 +380,7 @@ func (s *mealService) Update(ctx context.Context, id primitive.ObjectID, req mod
 		Notes:     existingMeal.Notes,
 		Rating:    existingMeal.Rating,
 		CreatedAt: existingMeal.CreatedAt,
+		Warnings:  allergenWarnings(dish, viewer),
 	}, nil
 }
 
@@ -407,24 +454,73 @@ func (s *mealService) GetNutritionSummary(ctx context.Context, userID primitive.
 	return summary, nil
 }
 
-// GetAnalytics gets meal analytics for a user for the specified period
-func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectID, period int) (*models.AnalyticsResponse, error) {
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -period)
+// GetMicronutrientIntake averages a user's daily micronutrient intake over the days with logged meals
+func (s *mealService) GetMicronutrientIntake(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.MicronutrientIntake, error) {
+	summaries, err := s.GetNutritionSummary(ctx, userID, dateRange.From, dateRange.To)
+	if err != nil {
+		return nil, err
+	}
 
+	intake := &models.MicronutrientIntake{
+		Range:    dateRange,
+		Averages: map[string]float64{},
+	}
+	var totals map[string]float64
+	for _, day := range summaries {
+		if day.MealCount == 0 {
+			continue
+		}
+		intake.DaysLogged++
+		intake.MealCount += day.MealCount
+		intake.MealsWithData += day.MealsWithData
+		totals = models.AddMicronutrients(totals, day.Micronutrients)
+	}
+
+	for key, total := range totals {
+		intake.Averages[key] = total / float64(intake.DaysLogged)
+	}
+	return intake, nil
+}
+
+// GetAnalytics gets meal analytics for a user for the specified date range,
+// compared against the previous equivalent range
+func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions) (*models.AnalyticsResponse, error) {
+	analytics, err := s.buildAnalytics(ctx, userID, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Rollup != "" {
+		analytics.TrendRollups = models.RollupMealCounts(analytics.WeeklyTrend, opts.Rollup)
+	}
+	if opts.MovingAverage {
+		analytics.TrendMovingAverages = models.MealCountMovingAverages(analytics.WeeklyTrend)
+	}
+
+	previous, err := s.buildAnalytics(ctx, userID, dateRange.Previous())
+	if err != nil {
+		return nil, err
+	}
+
+	analytics.Comparison = models.CompareAnalytics(analytics, previous)
+	return analytics, nil
+}
+
+// buildAnalytics calculates meal analytics for a single date range
+func (s *mealService) buildAnalytics(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange) (*models.AnalyticsResponse, error) {
 	// Get meals for the period
-	meals, err := s.GetByDateRange(ctx, userID, startDate, endDate)
+	meals, err := s.GetByDateRange(ctx, userID, dateRange.From, dateRange.To)
 	if err != nil {
 		s.logger.Error("Failed to get meals for analytics", "error", err, "userID", userID.Hex())
 		return nil, errors.New("failed to get analytics data")
 	}
 
 	analytics := &models.AnalyticsResponse{
-		TotalMeals:           len(meals),
 		MealTypeDistribution: make(map[string]int),
 		CuisineDistribution:  make(map[string]int),
 		TopDishes:            []models.DishPopularity{},
-		Period:               period,
+		Period:               dateRange.Days(),
+		Range:                dateRange,
 	}
 
 	// Calculate distributions and analytics
@@ -437,6 +533,12 @@ func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectI
 	dailyCounts := make(map[string]*models.DailyMealCount)
 
 	for _, meal := range meals {
+		// Skip meals whose dish could not be loaded
+		if meal == nil {
+			continue
+		}
+		analytics.TotalMeals++
+
 		// Meal type distribution
 		analytics.MealTypeDistribution[meal.MealType]++
 
@@ -465,7 +567,7 @@ func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectI
 		totalFiber += meal.Dish.Nutrition.Fiber
 
 		// Daily counts
-		dateKey := meal.Date.Format("2006-01-02")
+		dateKey := models.DayKey(meal.Date, dateRange.From.Location())
 		if daily, exists := dailyCounts[dateKey]; exists {
 			daily.MealCount++
 			daily.Calories += meal.Dish.Calories
@@ -497,9 +599,10 @@ func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectI
 		analytics.TopDishes = analytics.TopDishes[:10]
 	}
 
-	// Calculate averages
-	if period > 0 {
-		analytics.AvgCaloriesPerDay = float64(totalCalories) / float64(period)
+	// Average over the days actually logged rather than the length of the period
+	analytics.DaysLogged = len(dailyCounts)
+	if analytics.DaysLogged > 0 {
+		analytics.AvgCaloriesPerDay = float64(totalCalories) / float64(analytics.DaysLogged)
 	}
 
 	analytics.NutritionSummary = models.NutritionAnalytics{
@@ -508,16 +611,21 @@ func (s *mealService) GetAnalytics(ctx context.Context, userID primitive.ObjectI
 		TotalCarbs:    totalCarbs,
 		TotalFat:      totalFat,
 		TotalFiber:    totalFiber,
-		AvgCalories:   float64(totalCalories) / float64(len(meals)),
-		AvgProtein:    float64(totalProtein) / float64(len(meals)),
-		AvgCarbs:      float64(totalCarbs) / float64(len(meals)),
-		AvgFat:        float64(totalFat) / float64(len(meals)),
+	}
+	if analytics.TotalMeals > 0 {
+		mealCount := float64(analytics.TotalMeals)
+		analytics.NutritionSummary.AvgCalories = float64(totalCalories) / mealCount
+		analytics.NutritionSummary.AvgProtein = float64(totalProtein) / mealCount
+		analytics.NutritionSummary.AvgCarbs = float64(totalCarbs) / mealCount
+		analytics.NutritionSummary.AvgFat = float64(totalFat) / mealCount
 	}
 
-	// Convert daily counts to slice
+	// Convert daily counts to a dense, chronologically ordered series
+	trend := make([]models.DailyMealCount, 0, len(dailyCounts))
 	for _, daily := range dailyCounts {
-		analytics.WeeklyTrend = append(analytics.WeeklyTrend, *daily)
+		trend = append(trend, *daily)
 	}
+	analytics.WeeklyTrend = models.FillMealCountSeries(dateRange, trend)
 
 	return analytics, nil
 }
@@ -573,7 +681,6 @@ func (s *mealService) GetShoppingList(ctx context.Context, userID primitive.Obje
 // categorizeIngredient provides basic categorization for ingredients
 func categorizeIngredient(ingredient string) string {
 	// Basic categorization logic - can be enhanced
-	ingredient = ingredient
 	switch {
 	case contains(ingredient, "rice", "wheat", "flour", "bread"):
 		return "Grains"
@@ -590,6 +697,35 @@ func categorizeIngredient(ingredient string) string {
 	}
 }
 
+// getVisibleDish loads a dish the user may log, reporting dishes not shared with them as not found
+func (s *mealService) getVisibleDish(ctx context.Context, dishID primitive.ObjectID, viewer *models.User) (*models.Dish, error) {
+	dish, err := s.dishRepo.GetByID(ctx, dishID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("dish not found")
+		}
+		s.logger.Error("Failed to get dish", "error", err, "dishID", dishID.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	if !dish.VisibleTo(viewer) {
+		return nil, errors.New("dish not found")
+	}
+	return dish, nil
+}
+
+// allergenWarnings describes the allergens in a dish that the user has flagged
+func allergenWarnings(dish *models.Dish, user *models.User) []string {
+	if user == nil {
+		return nil
+	}
+	var warnings []string
+	for _, allergen := range dish.AllergensFor(user.Profile.Allergies) {
+		warnings = append(warnings, fmt.Sprintf("%s contains %s, which is in your allergy profile", dish.Name, allergen))
+	}
+	return warnings
+}
+
 // GetRecommendations gets meal recommendations based on user preferences and history
 func (s *mealService) GetRecommendations(ctx context.Context, userID primitive.ObjectID, mealType string, date time.Time) (*models.RecommendationsResponse, error) {
 	// Get user's recent meals to understand preferences
@@ -601,13 +737,30 @@ func (s *mealService) GetRecommendations(ctx context.Context, userID primitive.O
 		return nil, err
 	}
 
-	// Get all available dishes with empty filter
-	filter := repository.DishFilter{}
+	// Get the dishes available to the user, leaving out any containing their allergens or
+	// breaking their diet rules, including fasts that fall on the date
+	viewer := loadViewer(ctx, s.userRepo, &userID)
+	filter := repository.DishFilter{Viewer: viewer, ExcludeAllergens: viewerAllergies(viewer)}
+	if viewer != nil {
+		filter.DietRules = viewer.Profile.ActiveDietRules(date)
+	}
 	dishes, _, err := s.dishRepo.GetAll(ctx, filter, 1, 20) // Get top 20 dishes
 	if err != nil {
 		return nil, err
 	}
 
+	// Leave out dishes that alone would use too much of a health condition's daily limits
+	if viewer != nil && len(viewer.Profile.HealthConditions) > 0 {
+		limits := nutrition.ConditionLimitsFor(viewer.Profile.HealthConditions)
+		suitable := dishes[:0]
+		for _, dish := range dishes {
+			if len(nutrition.DishConditionConflicts(limits, viewer.Profile.NutritionGoals, dish)) == 0 {
+				suitable = append(suitable, dish)
+			}
+		}
+		dishes = suitable
+	}
+
 	// Analyze user preferences
 	preferredCuisines := make(map[string]int)
 	preferredDishIDs := make(map[primitive.ObjectID]int)
@@ -715,20 +868,67 @@ func (s *mealService) GetRecommendations(ctx context.Context, userID primitive.O
 	}, nil
 }
 
-// GetNutritionProgress gets nutrition progress for a user over a period
-func (s *mealService) GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, period int) (*models.NutritionProgressResponse, error) {
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -period)
+// GetNutritionProgress gets nutrition progress for a user over a date range,
+// compared against the previous equivalent range. Each day is graded against
+// the goals in force on that day.
+func (s *mealService) GetNutritionProgress(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, opts models.SeriesOptions, goals models.GoalTimeline) (*models.NutritionProgressResponse, error) {
+	// Health conditions tighten every day's goals and add limits of their own
+	var conditions []string
+	if viewer := loadViewer(ctx, s.userRepo, &userID); viewer != nil {
+		conditions = viewer.Profile.HealthConditions
+	}
+	limits := nutrition.ConditionLimitsFor(conditions)
 
-	meals, err := s.GetByDateRange(ctx, userID, startDate, endDate)
+	progressData, summary, err := s.buildNutritionProgress(ctx, userID, dateRange, goals, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	previousRange := dateRange.Previous()
+	_, previousSummary, err := s.buildNutritionProgress(ctx, userID, previousRange, goals, limits)
 	if err != nil {
 		return nil, err
 	}
 
+	response := &models.NutritionProgressResponse{
+		Period:     dateRange.Days(),
+		Range:      dateRange,
+		Progress:   progressData,
+		Goals:      nutrition.ApplyConditionLimits(goals.At(dateRange.To), limits),
+		Summary:    summary,
+		Comparison: models.CompareNutritionSummary(summary, previousSummary, previousRange),
+	}
+	if !limits.IsZero() {
+		response.ConditionLimits = &limits
+	}
+
+	if opts.Rollup != "" {
+		response.Rollups = models.RollupNutrition(progressData, opts.Rollup)
+	}
+	if opts.MovingAverage {
+		response.MovingAverages = models.NutritionMovingAverages(progressData)
+	}
+
+	return response, nil
+}
+
+// buildNutritionProgress calculates daily nutrition and its summary for a single date range,
+// checking each logged day against the health condition limits
+func (s *mealService) buildNutritionProgress(ctx context.Context, userID primitive.ObjectID, dateRange models.DateRange, goals models.GoalTimeline, limits models.ConditionLimits) ([]models.DailyNutrition, models.NutritionSummary, error) {
+	meals, err := s.GetByDateRange(ctx, userID, dateRange.From, dateRange.To)
+	if err != nil {
+		return nil, models.NutritionSummary{}, err
+	}
+
 	// Group meals by date and calculate daily nutrition
 	dailyNutrition := make(map[string]*models.DailyNutrition)
+	dailyMeals := make(map[string][]models.MealIntake)
 	for _, mealWithDish := range meals {
-		dateStr := mealWithDish.Date.Format("2006-01-02")
+		// Skip meals whose dish could not be loaded
+		if mealWithDish == nil {
+			continue
+		}
+		dateStr := models.DayKey(mealWithDish.Date, dateRange.From.Location())
 
 		if _, exists := dailyNutrition[dateStr]; !exists {
 			dailyNutrition[dateStr] = &models.DailyNutrition{
@@ -739,6 +939,7 @@ func (s *mealService) GetNutritionProgress(ctx context.Context, userID primitive
 				Fat:       0,
 				Fiber:     0,
 				Sodium:    0,
+				Sugar:     0,
 				MealCount: 0,
 			}
 		}
@@ -750,88 +951,120 @@ func (s *mealService) GetNutritionProgress(ctx context.Context, userID primitive
 		dailyNutrition[dateStr].Fat += mealWithDish.Dish.Nutrition.Fat
 		dailyNutrition[dateStr].Fiber += mealWithDish.Dish.Nutrition.Fiber
 		dailyNutrition[dateStr].Sodium += mealWithDish.Dish.Nutrition.Sodium
+		dailyNutrition[dateStr].Sugar += mealWithDish.Dish.Nutrition.Sugar
+		if mealWithDish.Dish.GlycemicLoad != nil {
+			dailyNutrition[dateStr].GlycemicLoad += *mealWithDish.Dish.GlycemicLoad
+		}
+		dailyNutrition[dateStr].Micronutrients = models.AddMicronutrients(dailyNutrition[dateStr].Micronutrients, mealWithDish.Dish.Nutrition.Micronutrients)
 		dailyNutrition[dateStr].MealCount++
+		dailyMeals[dateStr] = addMealIntake(dailyMeals[dateStr], mealWithDish)
 	}
 
-	// Convert map to slice
-	var progressData []models.DailyNutrition
-	for _, daily := range dailyNutrition {
-		progressData = append(progressData, *daily)
+	// Convert map to a dense, chronologically ordered series
+	logged := make([]models.DailyNutrition, 0, len(dailyNutrition))
+	for dateStr, daily := range dailyNutrition {
+		daily.GlycemicLoad = math.Round(daily.GlycemicLoad*10) / 10
+		daily.PeakGlycemicLoad = peakGlycemicLoad(dailyMeals[dateStr])
+		logged = append(logged, *daily)
 	}
+	progressData := models.FillNutritionSeries(dateRange, logged)
+	for i := range progressData {
+		progressData[i].Goals = nutrition.ApplyConditionLimits(goals.At(progressData[i].Date), limits)
+		if progressData[i].HasData {
+			adherence := models.EvaluateDay(progressData[i], progressData[i].Goals)
+			progressData[i].Adherence = &adherence
 
-	// Get user's nutrition goals - we'll use defaults here since the handler will override them
-	goals := &models.NutritionGoals{
-		DailyCalories: 2000,
-		Protein:       150,
-		Carbs:         250,
-		Fat:           65,
-		Fiber:         25,
-		Sodium:        2300,
+			dateStr := models.DayKey(progressData[i].Date, dateRange.From.Location())
+			progressData[i].ConditionWarnings = nutrition.EvaluateConditions(limits.Conditions, progressData[i], dailyMeals[dateStr])
+		}
 	}
 
-	// Calculate summary
-	var totalCalories, totalProtein, totalCarbs, totalFat, totalFiber, totalSodium int
-	for _, daily := range progressData {
+	// Calculate summary over the days with logged meals
+	var totalCalories, totalProtein, totalCarbs, totalFat, totalFiber, totalSodium, totalSugar int
+	var totalMicronutrients map[string]float64
+	var totalGlycemicLoad float64
+	for _, daily := range logged {
+		totalMicronutrients = models.AddMicronutrients(totalMicronutrients, daily.Micronutrients)
+		totalGlycemicLoad += daily.GlycemicLoad
 		totalCalories += daily.Calories
 		totalProtein += daily.Protein
 		totalCarbs += daily.Carbs
 		totalFat += daily.Fat
 		totalFiber += daily.Fiber
 		totalSodium += daily.Sodium
+		totalSugar += daily.Sugar
 	}
 
-	days := len(progressData)
-	if days == 0 {
-		days = 1 // Avoid division by zero
+	divisor := float64(len(logged))
+	if divisor == 0 {
+		divisor = 1 // Avoid division by zero
 	}
 
 	summary := models.NutritionSummary{
-		AvgCalories:    float64(totalCalories) / float64(days),
-		AvgProtein:     float64(totalProtein) / float64(days),
-		AvgCarbs:       float64(totalCarbs) / float64(days),
-		AvgFat:         float64(totalFat) / float64(days),
-		AvgFiber:       float64(totalFiber) / float64(days),
-		TotalDays:      days,
-		CalorieGoalMet: 0,
-		ProteinGoalMet: 0,
-		GoalPercentage: 0,
-	}
-
-	// Calculate goal achievement
-	for _, daily := range progressData {
-		if daily.Calories >= goals.DailyCalories {
-			summary.CalorieGoalMet++
+		AvgCalories: float64(totalCalories) / divisor,
+		AvgProtein:  float64(totalProtein) / divisor,
+		AvgCarbs:    float64(totalCarbs) / divisor,
+		AvgFat:      float64(totalFat) / divisor,
+		AvgFiber:    float64(totalFiber) / divisor,
+		AvgSodium:   float64(totalSodium) / divisor,
+		AvgSugar:    float64(totalSugar) / divisor,
+		TotalDays:   len(logged),
+
+		AvgGlycemicLoad: math.Round(totalGlycemicLoad/divisor*10) / 10,
+	}
+	if len(totalMicronutrients) > 0 {
+		summary.AvgMicronutrients = make(map[string]float64, len(totalMicronutrients))
+		for key, total := range totalMicronutrients {
+			summary.AvgMicronutrients[key] = total / divisor
 		}
-		if daily.Protein >= goals.Protein {
-			summary.ProteinGoalMet++
+	}
+
+	// Calculate per-nutrient adherence against the goals in force on each day
+	summary.Nutrients, summary.GoalPercentage = models.SummarizeAdherence(progressData)
+	summary.CalorieGoalMet = summary.Nutrients[models.NutrientCalories].DaysMet
+	summary.ProteinGoalMet = summary.Nutrients[models.NutrientProtein].DaysMet
+	for _, daily := range progressData {
+		if len(daily.ConditionWarnings) > 0 {
+			summary.ConditionWarningDays++
 		}
 	}
 
-	if days > 0 {
-		summary.GoalPercentage = float64(summary.CalorieGoalMet) / float64(days) * 100
+	return progressData, summary, nil
+}
+
+// addMealIntake adds a logged meal to the day's meals, combining meals of the same type
+func addMealIntake(meals []models.MealIntake, meal *models.MealWithDish) []models.MealIntake {
+	glycemicLoad := 0.0
+	if meal.Dish.GlycemicLoad != nil {
+		glycemicLoad = *meal.Dish.GlycemicLoad
+	}
+	for i := range meals {
+		if meals[i].MealType == meal.MealType {
+			meals[i].Carbs += meal.Dish.Nutrition.Carbs
+			meals[i].GlycemicLoad += glycemicLoad
+			return meals
+		}
 	}
+	return append(meals, models.MealIntake{MealType: meal.MealType, Carbs: meal.Dish.Nutrition.Carbs, GlycemicLoad: glycemicLoad})
+}
 
-	return &models.NutritionProgressResponse{
-		Period:   period,
-		Progress: progressData,
-		Goals:    *goals,
-		Summary:  summary,
-	}, nil
+// peakGlycemicLoad returns the meal with the highest glycemic load, nil when no meal has one
+func peakGlycemicLoad(meals []models.MealIntake) *models.MealGlycemicLoad {
+	var peak *models.MealGlycemicLoad
+	for _, meal := range meals {
+		if meal.GlycemicLoad > 0 && (peak == nil || meal.GlycemicLoad > peak.GlycemicLoad) {
+			peak = &models.MealGlycemicLoad{MealType: meal.MealType, GlycemicLoad: math.Round(meal.GlycemicLoad*10) / 10}
+		}
+	}
+	return peak
 }
 
 // GetNutritionGoals gets nutrition goals for a user
 func (s *mealService) GetNutritionGoals(ctx context.Context, userID primitive.ObjectID) (*models.NutritionGoals, error) {
-	// This method should delegate to the user service to get the actual user's goals
-	// For now, we'll return default goals but this needs to be fixed by using the user service
-	// TODO: This should get the actual user's nutrition goals from their profile
-	return &models.NutritionGoals{
-		DailyCalories: 2000,
-		Protein:       150,
-		Carbs:         250,
-		Fat:           65,
-		Fiber:         25,
-		Sodium:        2300,
-	}, nil
+	// The meal service does not depend on the user service; the nutrition handler
+	// resolves the user's own goals from their profile
+	goals := nutrition.DefaultGoals()
+	return &goals, nil
 }
 
 // UpdateNutritionGoals updates nutrition goals for a user
@@ -845,6 +1078,8 @@ func (s *mealService) UpdateNutritionGoals(ctx context.Context, userID primitive
 		Fat:           req.Fat,
 		Fiber:         req.Fiber,
 		Sodium:        req.Sodium,
+		Sugar:         req.Sugar,
+		Semantics:     req.Semantics,
 	}
 	return goals, nil
 }
@@ -49,6 +49,37 @@ func (m *MockMealRepository) GetNutritionByDateRange(ctx context.Context, userID
 	return args.Get(0).([]repository.NutritionSummary), args.Error(1)
 }
 
+func (m *MockMealRepository) ListPageByUserID(ctx context.Context, userID primitive.ObjectID, cursor *models.PageCursor, limit int) ([]*models.Meal, models.CursorPage, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(models.CursorPage), args.Error(2)
+	}
+	return args.Get(0).([]*models.Meal), args.Get(1).(models.CursorPage), args.Error(2)
+}
+
+func (m *MockMealRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMealRepository) GetDishUsage(ctx context.Context) ([]repository.DishUsage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.DishUsage), args.Error(1)
+}
+
+func (m *MockMealRepository) CountByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, dishID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMealRepository) DeleteByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, dishID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockMealRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*models.Meal, int64, error) {
 	args := m.Called(ctx, userID, page, limit)
 	if args.Get(0) == nil {
@@ -75,6 +106,31 @@ func (m *MockMealRepository) Delete(ctx context.Context, id primitive.ObjectID)
 	return args.Error(0)
 }
 
+func (m *MockMealRepository) DeleteMany(ctx context.Context, ids []primitive.ObjectID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockMealRepository) DeleteByUserDateAndDish(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, userID, startDate, endDate, dishID)
+	return args.Error(0)
+}
+
+func (m *MockMealRepository) UndoDeleteByUserDateAndDish(ctx context.Context, userID primitive.ObjectID, startDate, endDate time.Time, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, userID, startDate, endDate, dishID)
+	return args.Error(0)
+}
+
+func (m *MockMealRepository) SoftDeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockMealRepository) UndoDeleteByIDs(ctx context.Context, ids []primitive.ObjectID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
 func (m *MockMealRepository) Count(ctx context.Context, userID primitive.ObjectID) (int64, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(int64), args.Error(1)
@@ -88,12 +144,20 @@ func (m *MockMealRepository) GetNutritionSummary(ctx context.Context, userID pri
 	return args.Get(0).([]repository.NutritionSummary), args.Error(1)
 }
 
+// usersWithoutProfile returns a user repository that finds no user, so meals are checked
+// without allergies or health conditions
+func usersWithoutProfile() *MockUserRepositoryForUserService {
+	userRepo := new(MockUserRepositoryForUserService)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, mongo.ErrNoDocuments).Maybe()
+	return userRepo
+}
+
 func TestMealService_Create_Success(t *testing.T) {
 	// Arrange
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -135,7 +199,7 @@ func TestMealService_Create_DishNotFound(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -159,20 +223,19 @@ func TestMealService_Create_DishNotFound(t *testing.T) {
 	mockMealRepo.AssertNotCalled(t, "Create")
 }
 
-func TestMealService_Create_InvalidDate(t *testing.T) {
+func TestMealService_Create_InvalidDishID(t *testing.T) {
 	// Arrange
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
-	dishID := primitive.NewObjectID()
 
 	req := models.MealRequest{
-		DishID:   dishID.Hex(),
+		DishID:   "not-a-dish-id",
 		MealType: "breakfast",
-		Date:     models.FlexibleDate{},
+		Date:     models.FlexibleDate{Time: time.Now()},
 	}
 
 	// Act
@@ -181,7 +244,7 @@ func TestMealService_Create_InvalidDate(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "invalid date format")
+	assert.Contains(t, err.Error(), "invalid dish ID")
 	mockDishRepo.AssertNotCalled(t, "GetByID")
 	mockMealRepo.AssertNotCalled(t, "Create")
 }
@@ -191,7 +254,7 @@ func TestMealService_GetByID_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	mealID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -222,7 +285,7 @@ func TestMealService_GetByID_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, meal.ID, result.ID)
+	assert.Equal(t, meal.ID.Hex(), result.ID)
 	assert.Equal(t, meal.UserID.Hex(), result.User)
 	assert.Equal(t, dish.Name, result.Dish.Name)
 	mockMealRepo.AssertExpectations(t)
@@ -234,7 +297,7 @@ func TestMealService_GetByID_MealNotFound(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	mealID := primitive.NewObjectID()
 
@@ -256,7 +319,7 @@ func TestMealService_GetByUserID_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -289,7 +352,7 @@ func TestMealService_GetByUserID_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 1)
-	assert.Equal(t, meals[0].ID, result[0].ID)
+	assert.Equal(t, meals[0].ID.Hex(), result[0].ID)
 	assert.Equal(t, dish.Name, result[0].Dish.Name)
 	assert.NotNil(t, pagination)
 	assert.Equal(t, page, pagination.Page)
@@ -302,7 +365,7 @@ func TestMealService_GetByDateRange_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -334,7 +397,7 @@ func TestMealService_GetByDateRange_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 1)
-	assert.Equal(t, meals[0].ID, result[0].ID)
+	assert.Equal(t, meals[0].ID.Hex(), result[0].ID)
 	assert.Equal(t, dish.Name, result[0].Dish.Name)
 	mockMealRepo.AssertExpectations(t)
 	mockDishRepo.AssertExpectations(t)
@@ -345,7 +408,7 @@ func TestMealService_Update_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	mealID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
@@ -394,7 +457,7 @@ func TestMealService_Delete_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	mealID := primitive.NewObjectID()
 	existingMeal := &models.Meal{
@@ -417,7 +480,7 @@ func TestMealService_Delete_MealNotFound(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	mealID := primitive.NewObjectID()
 
@@ -438,7 +501,7 @@ func TestMealService_GetNutritionSummary_Success(t *testing.T) {
 	mockMealRepo := new(MockMealRepository)
 	mockDishRepo := new(MockDishRepository)
 	log := logger.New("info", "json")
-	service := NewMealService(mockMealRepo, mockDishRepo, log)
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
 
 	userID := primitive.NewObjectID()
 	startDate := time.Now().AddDate(0, 0, -7)
@@ -469,3 +532,26 @@ func TestMealService_GetNutritionSummary_Success(t *testing.T) {
 	assert.Equal(t, nutritionSummary[0].Fat, result[0].Fat)
 	mockMealRepo.AssertExpectations(t)
 }
+
+func TestMealService_GetNutritionProgress_NoLoggedDays(t *testing.T) {
+	// Arrange
+	mockMealRepo := new(MockMealRepository)
+	mockDishRepo := new(MockDishRepository)
+	log := logger.New("info", "json")
+	service := NewMealService(mockMealRepo, mockDishRepo, nil, usersWithoutProfile(), log)
+
+	userID := primitive.NewObjectID()
+	day := time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)
+	dateRange := models.NewDateRange(day.AddDate(0, 0, -6), day)
+
+	mockMealRepo.On("GetByUserAndDateRange", mock.Anything, userID, mock.Anything, mock.Anything).Return([]*models.Meal{}, nil)
+
+	// Act
+	result, err := service.GetNutritionProgress(context.Background(), userID, dateRange, models.SeriesOptions{}, models.GoalTimeline{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Summary.TotalDays)
+	assert.Equal(t, 0.0, result.Summary.AvgCalories)
+	mockMealRepo.AssertExpectations(t)
+}
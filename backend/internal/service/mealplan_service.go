@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/repository"
 	"nourish-backend/pkg/logger"
 
@@ -26,14 +28,16 @@ type MealPlanService interface {
 type mealPlanService struct {
 	mealPlanRepo repository.MealPlanRepository
 	dishRepo     repository.DishRepository
+	userRepo     repository.UserRepository
 	logger       *logger.Logger
 }
 
 // NewMealPlanService creates a new meal plan service
-func NewMealPlanService(mealPlanRepo repository.MealPlanRepository, dishRepo repository.DishRepository, log *logger.Logger) MealPlanService {
+func NewMealPlanService(mealPlanRepo repository.MealPlanRepository, dishRepo repository.DishRepository, userRepo repository.UserRepository, log *logger.Logger) MealPlanService {
 	return &mealPlanService{
 		mealPlanRepo: mealPlanRepo,
 		dishRepo:     dishRepo,
+		userRepo:     userRepo,
 		logger:       log,
 	}
 }
@@ -74,6 +78,9 @@ func (s *mealPlanService) GetByID(ctx context.Context, id primitive.ObjectID) (*
 		return nil, errors.New("internal server error")
 	}
 
+	if err := s.filterPlannedMeals(ctx, mealPlan); err != nil {
+		return nil, err
+	}
 	return mealPlan, nil
 }
 
@@ -84,6 +91,11 @@ func (s *mealPlanService) GetByUserID(ctx context.Context, userID primitive.Obje
 		s.logger.Error("Failed to get meal plans by user ID", "error", err, "userID", userID.Hex())
 		return nil, nil, errors.New("failed to get meal plans")
 	}
+	for _, mealPlan := range mealPlans {
+		if err := s.filterPlannedMeals(ctx, mealPlan); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// Create pagination response
 	totalPages := int(total) / limit
@@ -110,6 +122,11 @@ func (s *mealPlanService) GetActivePlans(ctx context.Context, userID primitive.O
 		s.logger.Error("Failed to get active meal plans", "error", err, "userID", userID.Hex())
 		return nil, errors.New("failed to get active meal plans")
 	}
+	for _, mealPlan := range mealPlans {
+		if err := s.filterPlannedMeals(ctx, mealPlan); err != nil {
+			return nil, err
+		}
+	}
 
 	return mealPlans, nil
 }
@@ -142,6 +159,9 @@ func (s *mealPlanService) Update(ctx context.Context, id primitive.ObjectID, req
 		return nil, errors.New("failed to update meal plan")
 	}
 
+	if err := s.filterPlannedMeals(ctx, existingPlan); err != nil {
+		return nil, err
+	}
 	return existingPlan, nil
 }
 
@@ -164,3 +184,56 @@ func (s *mealPlanService) Delete(ctx context.Context, id primitive.ObjectID) err
 
 	return nil
 }
+
+// filterPlannedMeals leaves out the planned meals whose dish the plan's owner may not see,
+// contains their allergens, breaks the diet rules they keep on the meal's date or alone
+// uses too much of their health conditions' daily limits.
+// Plans are stored as saved and filtered when read, so a dish that becomes visible again
+// reappears in the plan.
+func (s *mealPlanService) filterPlannedMeals(ctx context.Context, plan *models.MealPlan) error {
+	if len(plan.Meals) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, len(plan.Meals))
+	for i, meal := range plan.Meals {
+		ids[i] = meal.DishID
+	}
+	dishes, err := s.dishRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("Failed to get planned dishes", "error", err, "mealPlanID", plan.ID.Hex())
+		return errors.New("failed to get meal plan dishes")
+	}
+	byID := make(map[primitive.ObjectID]*models.Dish, len(dishes))
+	for _, dish := range dishes {
+		byID[dish.ID] = dish
+	}
+
+	owner := loadViewer(ctx, s.userRepo, &plan.UserID)
+	var limits models.ConditionLimits
+	if owner != nil {
+		limits = nutrition.ConditionLimitsFor(owner.Profile.HealthConditions)
+	}
+	meals := make([]models.MealPlanMeal, 0, len(plan.Meals))
+	for _, meal := range plan.Meals {
+		if dish := byID[meal.DishID]; dish != nil && suitsPlan(owner, limits, dish, meal.Date) {
+			meals = append(meals, meal)
+		}
+	}
+	plan.Meals = meals
+	return nil
+}
+
+// suitsPlan reports whether the dish may stay in the owner's plan on the date: they must be
+// able to see it, it must not contain their allergens, it must keep their diet rules,
+// including fasts that fall on the date, and stay within their condition limits
+func suitsPlan(owner *models.User, limits models.ConditionLimits, dish *models.Dish, date time.Time) bool {
+	if !dish.VisibleTo(owner) || len(dish.AllergensFor(viewerAllergies(owner))) > 0 {
+		return false
+	}
+	if owner == nil {
+		return true
+	}
+	return dish.CompliesWith(owner.Profile.ActiveDietRules(date)) &&
+		len(nutrition.DishConditionConflicts(limits, owner.Profile.NutritionGoals, dish)) == 0
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nourish-backend/internal/models"
+	"nourish-backend/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMealPlanService_GetByID_LeavesOutUnsuitableDishes(t *testing.T) {
+	ownerID, strangerID := primitive.NewObjectID(), primitive.NewObjectID()
+	day := time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)
+	kept := []string{models.DietRuleSatvik, models.DietRuleNavratri}
+	navratri := models.FastingPeriod{RuleSet: models.DietRuleNavratri, StartDate: day, EndDate: day.AddDate(0, 0, 8)}
+
+	tests := []struct {
+		name       string
+		dish       models.Dish
+		expectKept bool
+	}{
+		{"public dish", models.Dish{Visibility: models.VisibilityPublic, DietRules: kept}, true},
+		{"owner's private dish", models.Dish{Visibility: models.VisibilityPrivate, CreatedBy: &ownerID, DietRules: kept}, true},
+		{"someone else's private dish", models.Dish{Visibility: models.VisibilityPrivate, CreatedBy: &strangerID, DietRules: kept}, false},
+		{"dish with the owner's allergen", models.Dish{Visibility: models.VisibilityPublic, Allergens: []string{models.AllergenPeanut}, DietRules: kept}, false},
+		{"dish with another allergen", models.Dish{Visibility: models.VisibilityPublic, Allergens: []string{models.AllergenDairy}, DietRules: kept}, true},
+		{"dish breaking a standing rule", models.Dish{Visibility: models.VisibilityPublic, DietRules: []string{models.DietRuleNavratri}}, false},
+		{"dish breaking the fast on the day", models.Dish{Visibility: models.VisibilityPublic, DietRules: []string{models.DietRuleSatvik}}, false},
+		{"dish keeping the rules and the fast", models.Dish{Visibility: models.VisibilityPublic, DietRules: kept}, true},
+		{"dish over the sodium ceiling", models.Dish{Visibility: models.VisibilityPublic, DietRules: kept, Nutrition: models.Nutrition{Sodium: 1800}}, false},
+		{"dish within the sodium ceiling", models.Dish{Visibility: models.VisibilityPublic, DietRules: kept, Nutrition: models.Nutrition{Sodium: 300}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockPlanRepo := new(MockMealPlanRepository)
+			mockDishRepo := new(MockDishRepository)
+			mockUserRepo := new(MockUserRepositoryForUserService)
+			log := logger.New("info", "json")
+			service := NewMealPlanService(mockPlanRepo, mockDishRepo, mockUserRepo, log)
+
+			dish := tt.dish
+			dish.ID = primitive.NewObjectID()
+			plan := &models.MealPlan{
+				ID:     primitive.NewObjectID(),
+				UserID: ownerID,
+				Meals:  []models.MealPlanMeal{{Date: day, MealType: "lunch", DishID: dish.ID}},
+			}
+			owner := &models.User{ID: ownerID, Profile: models.UserProfile{
+				Allergies:        []string{models.AllergenPeanut},
+				DietRules:        []string{models.DietRuleSatvik},
+				FastingPeriods:   []models.FastingPeriod{navratri},
+				HealthConditions: []string{models.HealthConditionHypertension},
+			}}
+
+			mockPlanRepo.On("GetByID", mock.Anything, plan.ID).Return(plan, nil)
+			mockDishRepo.On("GetByIDs", mock.Anything, []primitive.ObjectID{dish.ID}).Return([]*models.Dish{&dish}, nil)
+			mockUserRepo.On("GetByID", mock.Anything, ownerID).Return(owner, nil)
+
+			// Act
+			result, err := service.GetByID(context.Background(), plan.ID)
+
+			// Assert
+			assert.NoError(t, err)
+			if tt.expectKept {
+				assert.Len(t, result.Meals, 1)
+			} else {
+				assert.Empty(t, result.Meals)
+			}
+			mockPlanRepo.AssertExpectations(t)
+		})
+	}
+}
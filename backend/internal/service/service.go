@@ -13,15 +13,23 @@ type Services struct {
 	Dish     DishService
 	Meal     MealService
 	MealPlan MealPlanService
+	Body     BodyMetricsService
+
+	DishValidation DishValidationService
 }
 
 // NewServices creates and returns all service instances
 func NewServices(repos *repository.Repositories, cfg *config.Config, log *logger.Logger) *Services {
+	dishes := NewDishService(repos.Dish, repos.User, repos.Meal, repos.MealPlan, log)
+
 	return &Services{
 		Auth:     NewAuthService(repos.User, cfg, log),
-		User:     NewUserService(repos.User, log),
-		Dish:     NewDishService(repos.Dish, repos.User, log),
-		Meal:     NewMealService(repos.Meal, repos.Dish, repos.Undo, log),
-		MealPlan: NewMealPlanService(repos.MealPlan, repos.Dish, log),
+		User:     NewUserService(repos.User, repos.Goal, dishes, cfg, log),
+		Dish:     dishes,
+		Meal:     NewMealService(repos.Meal, repos.Dish, repos.Undo, repos.User, log),
+		MealPlan: NewMealPlanService(repos.MealPlan, repos.Dish, repos.User, log),
+		Body:     NewBodyMetricsService(repos.Body, repos.Meal, repos.User, repos.Goal, log),
+
+		DishValidation: NewDishValidationService(repos.Dish, log),
 	}
 }
@@ -3,11 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
+	"nourish-backend/internal/config"
 	"nourish-backend/internal/models"
+	"nourish-backend/internal/nutrition"
 	"nourish-backend/internal/repository"
 	"nourish-backend/pkg/logger"
 
+	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -15,24 +19,36 @@ import (
 // UserService interface defines user operations
 type UserService interface {
 	GetByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
-	UpdateProfile(ctx context.Context, userID primitive.ObjectID, profile models.UserProfile) error
+	UpdateProfile(ctx context.Context, userID primitive.ObjectID, patch models.ProfilePatch) error
 	UpdateUserProfile(ctx context.Context, userID primitive.ObjectID, req models.ProfileUpdateRequest) error
 	AddToFavorites(ctx context.Context, userID, dishID primitive.ObjectID) error
 	RemoveFromFavorites(ctx context.Context, userID, dishID primitive.ObjectID) error
 	GetFavorites(ctx context.Context, userID primitive.ObjectID) ([]primitive.ObjectID, error)
 	Delete(ctx context.Context, userID primitive.ObjectID) error
+	UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, goals models.NutritionGoals, source string, effectiveFrom *time.Time) (*models.User, error)
+	GetGoalTimeline(ctx context.Context, userID primitive.ObjectID) (models.GoalTimeline, error)
+	CreateHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+	CreateHouseholdInvite(ctx context.Context, userID primitive.ObjectID) (*models.HouseholdInvite, error)
+	JoinHousehold(ctx context.Context, userID primitive.ObjectID, inviteToken string) (*models.User, error)
+	LeaveHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
 }
 
 // userService implements UserService interface
 type userService struct {
 	userRepo repository.UserRepository
+	goalRepo repository.GoalVersionRepository
+	dishes   DishService
+	config   *config.Config
 	logger   *logger.Logger
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo repository.UserRepository, log *logger.Logger) UserService {
+func NewUserService(userRepo repository.UserRepository, goalRepo repository.GoalVersionRepository, dishes DishService, cfg *config.Config, log *logger.Logger) UserService {
 	return &userService{
 		userRepo: userRepo,
+		goalRepo: goalRepo,
+		dishes:   dishes,
+		config:   cfg,
 		logger:   log,
 	}
 }
@@ -52,26 +68,8 @@ func (s *userService) GetByID(ctx context.Context, id primitive.ObjectID) (*mode
 }
 
 // UpdateProfile updates user profile
-func (s *userService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, profile models.UserProfile) error {
-	// Get existing user
-	user, err := s.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return errors.New("user not found")
-		}
-		s.logger.Error("Failed to get user for profile update", "error", err, "userID", userID.Hex())
-		return errors.New("internal server error")
-	}
-
-	// Update profile
-	user.Profile = profile
-
-	if err := s.userRepo.Update(ctx, userID, user); err != nil {
-		s.logger.Error("Failed to update user profile", "error", err, "userID", userID.Hex())
-		return errors.New("failed to update profile")
-	}
-
-	return nil
+func (s *userService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, patch models.ProfilePatch) error {
+	return s.UpdateUserProfile(ctx, userID, models.ProfileUpdateRequest{Profile: patch})
 }
 
 // UpdateUserProfile updates user profile and optionally the name
@@ -86,8 +84,12 @@ func (s *userService) UpdateUserProfile(ctx context.Context, userID primitive.Ob
 		return errors.New("internal server error")
 	}
 
-	// Update profile
-	user.Profile = req.Profile
+	// Update the fields present, keeping the rest of the profile
+	previous := user.Profile
+	req.Profile.Apply(&user.Profile)
+	if err := syncCalculatedGoals(&user.Profile); err != nil {
+		return err
+	}
 
 	// Update name if provided
 	if req.Name != "" {
@@ -99,6 +101,7 @@ func (s *userService) UpdateUserProfile(ctx context.Context, userID primitive.Ob
 		return errors.New("failed to update profile")
 	}
 
+	s.recordGoalChange(ctx, user, previous, today(user.Profile))
 	return nil
 }
 
@@ -142,3 +145,253 @@ func (s *userService) Delete(ctx context.Context, userID primitive.ObjectID) err
 
 	return nil
 }
+
+// householdInviteTTL is how long a household invite can be used
+const householdInviteTTL = 7 * 24 * time.Hour
+
+// householdInvitePurpose marks invite tokens so they can't be confused with login tokens
+const householdInvitePurpose = "household-invite"
+
+// CreateHousehold starts a new household with the user as its first member.
+// Others join with an invite from a member.
+func (s *userService) CreateHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	householdID := primitive.NewObjectID()
+	return s.setHousehold(ctx, userID, &householdID)
+}
+
+// CreateHouseholdInvite issues an invite to the user's household. The invite expires after
+// householdInviteTTL and stops working as soon as the member who issued it leaves.
+func (s *userService) CreateHouseholdInvite(ctx context.Context, userID primitive.ObjectID) (*models.HouseholdInvite, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.HouseholdID == nil {
+		return nil, errors.New("not in a household")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(householdInviteTTL)
+	claims := jwt.MapClaims{
+		"purpose":     householdInvitePurpose,
+		"householdId": user.HouseholdID.Hex(),
+		"invitedBy":   userID.Hex(),
+		"exp":         expiresAt.Unix(),
+		"iat":         now.Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.config.JWTSecret))
+	if err != nil {
+		s.logger.Error("Failed to sign household invite", "error", err, "userID", userID.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	return &models.HouseholdInvite{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// JoinHousehold moves the user into the household a member invited them to
+func (s *userService) JoinHousehold(ctx context.Context, userID primitive.ObjectID, inviteToken string) (*models.User, error) {
+	householdID, err := s.verifyHouseholdInvite(ctx, inviteToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.setHousehold(ctx, userID, &householdID)
+}
+
+// verifyHouseholdInvite returns the household an invite is for, provided the invite is
+// signed, unexpired and its issuer still belongs to that household
+func (s *userService) verifyHouseholdInvite(ctx context.Context, inviteToken string) (primitive.ObjectID, error) {
+	invalid := errors.New("invalid or expired invite")
+
+	token, err := jwt.Parse(inviteToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(s.config.JWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return primitive.NilObjectID, invalid
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != householdInvitePurpose {
+		return primitive.NilObjectID, invalid
+	}
+	householdIDStr, _ := claims["householdId"].(string)
+	inviterIDStr, _ := claims["invitedBy"].(string)
+	householdID, err := primitive.ObjectIDFromHex(householdIDStr)
+	if err != nil {
+		return primitive.NilObjectID, invalid
+	}
+	inviterID, err := primitive.ObjectIDFromHex(inviterIDStr)
+	if err != nil {
+		return primitive.NilObjectID, invalid
+	}
+
+	inviter, err := s.userRepo.GetByID(ctx, inviterID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return primitive.NilObjectID, invalid
+		}
+		s.logger.Error("Failed to get household invite issuer", "error", err, "userID", inviterID.Hex())
+		return primitive.NilObjectID, errors.New("internal server error")
+	}
+	if inviter.HouseholdID == nil || *inviter.HouseholdID != householdID {
+		return primitive.NilObjectID, invalid
+	}
+
+	return householdID, nil
+}
+
+// LeaveHousehold removes the user from their household. Their household dishes become private.
+func (s *userService) LeaveHousehold(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	return s.setHousehold(ctx, userID, nil)
+}
+
+// setHousehold stores the user's household and moves their household dishes with them
+func (s *userService) setHousehold(ctx context.Context, userID primitive.ObjectID, householdID *primitive.ObjectID) (*models.User, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.SetHousehold(ctx, userID, householdID); err != nil {
+		s.logger.Error("Failed to set household", "error", err, "userID", userID.Hex())
+		return nil, errors.New("failed to update household")
+	}
+	if err := s.dishes.MoveHouseholdDishes(ctx, userID, householdID); err != nil {
+		return nil, err
+	}
+
+	user.HouseholdID = householdID
+	return user, nil
+}
+
+// syncCalculatedGoals recalculates goals from body metrics when the user has accepted calculated goals
+func syncCalculatedGoals(profile *models.UserProfile) error {
+	if profile.GoalsSource != models.GoalsSourceCalculated || profile.BodyMetrics == nil {
+		return nil
+	}
+	suggestion, err := nutrition.Suggest(*profile.BodyMetrics)
+	if err != nil {
+		return err
+	}
+	profile.NutritionGoals = suggestion.Goals
+	return nil
+}
+
+// UpdateNutritionGoals sets the user's goals from effectiveFrom (today when nil) onwards.
+// Goal versions that took effect after that day are replaced.
+func (s *userService) UpdateNutritionGoals(ctx context.Context, userID primitive.ObjectID, goals models.NutritionGoals, source string, effectiveFrom *time.Time) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("user not found")
+		}
+		s.logger.Error("Failed to get user for goals update", "error", err, "userID", userID.Hex())
+		return nil, errors.New("internal server error")
+	}
+
+	from := today(user.Profile)
+	if effectiveFrom != nil {
+		day := models.NewDateRange(*effectiveFrom, *effectiveFrom).From
+		if day.After(from) {
+			return nil, errors.New("effective date must not be in the future")
+		}
+		from = day
+	}
+
+	previous := user.Profile
+	user.Profile.NutritionGoals = goals
+	user.Profile.GoalsSource = source
+
+	if err := s.userRepo.Update(ctx, userID, user); err != nil {
+		s.logger.Error("Failed to update nutrition goals", "error", err, "userID", userID.Hex())
+		return nil, errors.New("failed to update nutrition goals")
+	}
+
+	s.recordGoalChange(ctx, user, previous, from)
+	return user, nil
+}
+
+// GetGoalTimeline returns the user's goal history, falling back to the current goals
+// for users whose goals have never changed
+func (s *userService) GetGoalTimeline(ctx context.Context, userID primitive.ObjectID) (models.GoalTimeline, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return models.GoalTimeline{}, err
+	}
+
+	versions, err := s.goalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get goal history", "error", err, "userID", userID.Hex())
+		return models.GoalTimeline{}, errors.New("failed to get goal history")
+	}
+
+	if len(versions) == 0 {
+		versions = append(versions, models.GoalVersion{
+			UserID:        userID,
+			Goals:         nutrition.GoalsForProfile(user.Profile),
+			Source:        user.Profile.ResolvedGoalsSource(),
+			EffectiveFrom: signUpDay(user),
+			CreatedAt:     user.CreatedAt,
+		})
+	}
+	return models.NewGoalTimeline(versions, nutrition.GoalsForProfile(user.Profile)), nil
+}
+
+// recordGoalChange versions the user's goals when they differ from the previous profile.
+// The first change also records the goals that applied before it, from the sign-up day.
+// Failures are logged but do not fail the profile update.
+func (s *userService) recordGoalChange(ctx context.Context, user *models.User, previous models.UserProfile, effectiveFrom time.Time) {
+	if err := saveGoalVersion(ctx, s.goalRepo, user, previous, effectiveFrom); err != nil {
+		s.logger.Error("Failed to record goal history", "error", err, "userID", user.ID.Hex())
+	}
+}
+
+// saveGoalVersion stores a new goal version effective from the given day
+func saveGoalVersion(ctx context.Context, goalRepo repository.GoalVersionRepository, user *models.User, previous models.UserProfile, effectiveFrom time.Time) error {
+	before := nutrition.GoalsForProfile(previous)
+	after := nutrition.GoalsForProfile(user.Profile)
+	if before == after && previous.ResolvedGoalsSource() == user.Profile.ResolvedGoalsSource() {
+		return nil
+	}
+
+	versions, err := goalRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if initial := signUpDay(user); len(versions) == 0 && initial.Before(effectiveFrom) {
+		if err := goalRepo.Upsert(ctx, &models.GoalVersion{
+			UserID:        user.ID,
+			Goals:         before,
+			Source:        previous.ResolvedGoalsSource(),
+			EffectiveFrom: initial,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := goalRepo.DeleteAfter(ctx, user.ID, effectiveFrom); err != nil {
+		return err
+	}
+	return goalRepo.Upsert(ctx, &models.GoalVersion{
+		UserID:        user.ID,
+		Goals:         after,
+		Source:        user.Profile.ResolvedGoalsSource(),
+		EffectiveFrom: effectiveFrom,
+	})
+}
+
+// today returns the start of the current day in the profile's time zone
+func today(profile models.UserProfile) time.Time {
+	now := time.Now().In(profile.Location())
+	return models.NewDateRange(now, now).From
+}
+
+// signUpDay returns the start of the day the user registered, in their time zone
+func signUpDay(user *models.User) time.Time {
+	created := user.CreatedAt.In(user.Profile.Location())
+	return models.NewDateRange(created, created).From
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
+	"nourish-backend/internal/config"
 	"nourish-backend/internal/models"
 	"nourish-backend/pkg/logger"
 
@@ -75,6 +77,21 @@ func (m *MockUserRepositoryForUserService) RemoveFromFavorites(ctx context.Conte
 	return args.Error(0)
 }
 
+func (m *MockUserRepositoryForUserService) CountFavoritesByDishID(ctx context.Context, dishID primitive.ObjectID) (int64, error) {
+	args := m.Called(ctx, dishID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepositoryForUserService) RemoveDishFromAllFavorites(ctx context.Context, dishID primitive.ObjectID) error {
+	args := m.Called(ctx, dishID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepositoryForUserService) SetHousehold(ctx context.Context, userID primitive.ObjectID, householdID *primitive.ObjectID) error {
+	args := m.Called(ctx, userID, householdID)
+	return args.Error(0)
+}
+
 func (m *MockUserRepositoryForUserService) UpdateLastLogin(ctx context.Context, id primitive.ObjectID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -94,7 +111,7 @@ func TestUserService_GetProfile_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 	user := &models.User{
@@ -115,7 +132,7 @@ func TestUserService_GetProfile_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, user.ID.Hex(), result.ID)
+	assert.Equal(t, user.ID, result.ID)
 	assert.Equal(t, user.Name, result.Name)
 	assert.Equal(t, user.Email, result.Email)
 	mockRepo.AssertExpectations(t)
@@ -125,7 +142,7 @@ func TestUserService_GetProfile_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 
@@ -145,7 +162,7 @@ func TestUserService_UpdateProfile_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 	existingUser := &models.User{
@@ -154,24 +171,22 @@ func TestUserService_UpdateProfile_Success(t *testing.T) {
 		Email: "john@example.com",
 	}
 
-	req := models.UserProfile{
-		Name: "John Smith",
-		Profile: models.UserProfile{
-			DietaryPreferences: []string{"vegan"},
-			SpiceLevel:         "high",
-		},
+	preferences, spiceLevel := []string{"vegan"}, "hot"
+	req := models.ProfilePatch{
+		DietaryPreferences: &preferences,
+		SpiceLevel:         &spiceLevel,
 	}
 
 	mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
 	mockRepo.On("Update", mock.Anything, userID, mock.AnythingOfType("*models.User")).Return(nil)
 
 	// Act
-	result, err := service.UpdateProfile(context.Background(), userID, req)
+	err := service.UpdateProfile(context.Background(), userID, req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Equal(t, req.Name, result.Name)
+	assert.Equal(t, preferences, existingUser.Profile.DietaryPreferences)
+	assert.Equal(t, spiceLevel, existingUser.Profile.SpiceLevel)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -179,30 +194,79 @@ func TestUserService_UpdateProfile_UserNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
-	req := models.UserProfileUpdateRequest{
-		Name: "John Smith",
+	spiceLevel := "hot"
+	req := models.ProfilePatch{
+		SpiceLevel: &spiceLevel,
 	}
 
 	mockRepo.On("GetByID", mock.Anything, userID).Return(nil, mongo.ErrNoDocuments)
 
 	// Act
-	result, err := service.UpdateProfile(context.Background(), userID, req)
+	err := service.UpdateProfile(context.Background(), userID, req)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "user not found")
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserService_UpdateUserProfile_KeepsOmittedFields(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewUserService(mockRepo, nil, nil, nil, log)
+
+	userID := primitive.NewObjectID()
+	goals := models.NutritionGoals{DailyCalories: 1800, Protein: 90, Carbs: 200, Fat: 60, Fiber: 30, Sodium: 1500}
+	existingUser := &models.User{
+		ID:   userID,
+		Name: "John Doe",
+		Profile: models.UserProfile{
+			SpiceLevel:       "medium",
+			Allergies:        []string{models.AllergenPeanut, models.AllergenDairy},
+			DietRules:        []string{models.DietRuleJain},
+			HealthConditions: []string{"diabetes"},
+			TimeZone:         "Asia/Kolkata",
+			NutritionGoals:   goals,
+			GoalsSource:      models.GoalsSourceCustom,
+		},
+	}
+
+	// The profile form only sends the fields it edits; goals change through the goals endpoints
+	var req models.ProfileUpdateRequest
+	body := `{"name": "John Smith", "profile": {"dietaryPreferences": ["vegetarian"], "spiceLevel": "hot", "favoriteRegions": ["South Indian"], "nutritionGoals": {"dailyCalories": 2500}}}`
+	assert.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	mockRepo.On("GetByID", mock.Anything, userID).Return(existingUser, nil)
+	mockRepo.On("Update", mock.Anything, userID, mock.AnythingOfType("*models.User")).Return(nil)
+
+	// Act
+	err := service.UpdateUserProfile(context.Background(), userID, req)
+
+	// Assert
+	assert.NoError(t, err)
+	profile := existingUser.Profile
+	assert.Equal(t, "John Smith", existingUser.Name)
+	assert.Equal(t, []string{"vegetarian"}, profile.DietaryPreferences)
+	assert.Equal(t, "hot", profile.SpiceLevel)
+	assert.Equal(t, []string{"South Indian"}, profile.FavoriteRegions)
+	assert.Equal(t, []string{models.AllergenPeanut, models.AllergenDairy}, profile.Allergies)
+	assert.Equal(t, []string{models.DietRuleJain}, profile.DietRules)
+	assert.Equal(t, []string{"diabetes"}, profile.HealthConditions)
+	assert.Equal(t, "Asia/Kolkata", profile.TimeZone)
+	assert.Equal(t, goals, profile.NutritionGoals)
+	assert.Equal(t, models.GoalsSourceCustom, profile.GoalsSource)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUserService_GetFavorites_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 	dishID1 := primitive.NewObjectID()
@@ -218,8 +282,8 @@ func TestUserService_GetFavorites_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 2)
-	assert.Equal(t, dishID1.Hex(), result[0])
-	assert.Equal(t, dishID2.Hex(), result[1])
+	assert.Equal(t, dishID1, result[0])
+	assert.Equal(t, dishID2, result[1])
 	mockRepo.AssertExpectations(t)
 }
 
@@ -227,15 +291,15 @@ func TestUserService_AddFavorite_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
 
-	mockRepo.On("AddFavorite", mock.Anything, userID, dishID).Return(nil)
+	mockRepo.On("AddToFavorites", mock.Anything, userID, dishID).Return(nil)
 
 	// Act
-	err := service.AddFavorite(context.Background(), userID, dishID)
+	err := service.AddToFavorites(context.Background(), userID, dishID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -246,15 +310,15 @@ func TestUserService_RemoveFavorite_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 	dishID := primitive.NewObjectID()
 
-	mockRepo.On("RemoveFavorite", mock.Anything, userID, dishID).Return(nil)
+	mockRepo.On("RemoveFromFavorites", mock.Anything, userID, dishID).Return(nil)
 
 	// Act
-	err := service.RemoveFavorite(context.Background(), userID, dishID)
+	err := service.RemoveFromFavorites(context.Background(), userID, dishID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -265,52 +329,87 @@ func TestUserService_DeleteAccount_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockUserRepositoryForUserService)
 	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+	service := NewUserService(mockRepo, nil, nil, nil, log)
 
 	userID := primitive.NewObjectID()
 
 	mockRepo.On("Delete", mock.Anything, userID).Return(nil)
 
 	// Act
-	err := service.DeleteAccount(context.Background(), userID)
+	err := service.Delete(context.Background(), userID)
 
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestUserService_ListUsers_Success(t *testing.T) {
-	// Arrange
-	mockRepo := new(MockUserRepositoryForUserService)
-	log := logger.New("info", "json")
-	service := NewUserService(mockRepo, log)
+func TestUserService_JoinHousehold_WithInvite(t *testing.T) {
+	householdID := primitive.NewObjectID()
+	memberID, joinerID := primitive.NewObjectID(), primitive.NewObjectID()
+	cfg := &config.Config{JWTSecret: "test-secret"}
+
+	tests := []struct {
+		name         string
+		memberLeft   bool
+		token        func(invite string) string
+		expectJoined bool
+	}{
+		{"invite from a member", false, func(invite string) string { return invite }, true},
+		{"member left after inviting", true, func(invite string) string { return invite }, false},
+		{"tampered invite", false, func(invite string) string { return invite + "x" }, false},
+		{"household ID instead of an invite", false, func(string) string { return householdID.Hex() }, false},
+	}
 
-	users := []*models.User{
-		{
-			ID:    primitive.NewObjectID(),
-			Name:  "User 1",
-			Email: "user1@example.com",
-		},
-		{
-			ID:    primitive.NewObjectID(),
-			Name:  "User 2",
-			Email: "user2@example.com",
-		},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := new(MockUserRepositoryForUserService)
+			mockDishRepo := new(MockDishRepository)
+			log := logger.New("info", "json")
+			service := NewUserService(mockRepo, nil, NewDishService(mockDishRepo, mockRepo, nil, nil, log), cfg, log)
+
+			member := &models.User{ID: memberID, HouseholdID: &householdID}
+			mockRepo.On("GetByID", mock.Anything, memberID).Return(member, nil)
+			mockRepo.On("GetByID", mock.Anything, joinerID).Return(&models.User{ID: joinerID}, nil).Maybe()
+			mockRepo.On("SetHousehold", mock.Anything, joinerID, &householdID).Return(nil).Maybe()
+			mockDishRepo.On("MoveHouseholdDishes", mock.Anything, joinerID, &householdID).Return(nil).Maybe()
+
+			invite, err := service.CreateHouseholdInvite(context.Background(), memberID)
+			assert.NoError(t, err)
+			if tt.memberLeft {
+				member.HouseholdID = nil
+			}
+
+			// Act
+			user, err := service.JoinHousehold(context.Background(), joinerID, tt.token(invite.Token))
+
+			// Assert
+			if tt.expectJoined {
+				assert.NoError(t, err)
+				assert.Equal(t, &householdID, user.HouseholdID)
+				mockRepo.AssertCalled(t, "SetHousehold", mock.Anything, joinerID, &householdID)
+			} else {
+				assert.EqualError(t, err, "invalid or expired invite")
+				mockRepo.AssertNotCalled(t, "SetHousehold", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
 	}
+}
 
-	limit := 10
-	skip := 0
+func TestUserService_CreateHouseholdInvite_NotInHousehold(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockUserRepositoryForUserService)
+	log := logger.New("info", "json")
+	service := NewUserService(mockRepo, nil, nil, &config.Config{JWTSecret: "test-secret"}, log)
 
-	mockRepo.On("List", mock.Anything, limit, skip).Return(users, nil)
+	userID := primitive.NewObjectID()
+	mockRepo.On("GetByID", mock.Anything, userID).Return(&models.User{ID: userID}, nil)
 
 	// Act
-	result, err := service.ListUsers(context.Background(), limit, skip)
+	invite, err := service.CreateHouseholdInvite(context.Background(), userID)
 
 	// Assert
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.Len(t, result, 2)
-	assert.Equal(t, users[0].Name, result[0].Name)
-	assert.Equal(t, users[1].Name, result[1].Name)
+	assert.Nil(t, invite)
+	assert.EqualError(t, err, "not in a household")
 	mockRepo.AssertExpectations(t)
 }